@@ -6,6 +6,7 @@ require (
 	github.com/go-resty/resty/v2 v2.16.5
 	github.com/gorilla/websocket v1.5.3
 	github.com/shopspring/decimal v1.4.0
+	google.golang.org/protobuf v1.34.2
 )
 
 require golang.org/x/net v0.33.0 // indirect
@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+// DefaultAggregatedIntervals 默认由1m K线合成的周期
+var DefaultAggregatedIntervals = []schema.Interval{
+	schema.Interval3m,
+	schema.Interval5m,
+	schema.Interval15m,
+	schema.Interval30m,
+	schema.Interval1h,
+	schema.Interval4h,
+	schema.Interval1d,
+}
+
+// pastBucketsLimit 每个币对每个目标周期保留的已滚动周期数，重连补齐的1m K线落在这些周期内时在原有结果上合并
+const pastBucketsLimit = 16
+
+// KlineAggregator 由1m K线合成更大周期的K线，周期按 UTC 对齐
+type KlineAggregator struct {
+	intervals []schema.Interval
+
+	mu      sync.Mutex
+	buckets map[string]*klineBucket
+	past    map[string][]*klineBucket // 最近已滚动的周期，按开盘时间升序
+}
+
+// klineBucket 单个币对单个目标周期正在合成的K线
+type klineBucket struct {
+	openTime  time.Time
+	acc       *schema.Kline // 已完结的1m K线合并结果
+	minutes   []uint64      // 已合并的已完结1m K线位图，按距周期开盘的分钟数索引，用于去重
+	first     time.Time     // 最早合并的已完结1m K线开盘时间，决定开盘价
+	lastFinal time.Time     // 最后合并的已完结1m K线开盘时间，决定收盘价
+	last      *schema.Kline // 最近一次输出的合成K线
+	finalized bool
+}
+
+func newKlineBucket(openTime time.Time, d time.Duration) *klineBucket {
+	n := int(d / time.Minute)
+	return &klineBucket{openTime: openTime, minutes: make([]uint64, (n+63)/64)}
+}
+
+// complete 是否已合并周期第一分钟的已完结K线；周期中途开始合成（如启动或订阅时）的K线缺少开盘价和前段成交量，
+// 在补齐之前不标记为已完结
+func (b *klineBucket) complete() bool {
+	return b.minutes[0]&1 != 0
+}
+
+// add 合并一条已完结的1m K线，补齐的历史数据可能乱序到达，按开盘时间决定开盘价和收盘价；重复推送返回 false
+func (b *klineBucket) add(kl schema.Kline) bool {
+	idx := int(kl.OpenTime.Sub(b.openTime) / time.Minute)
+	word, bit := idx/64, uint64(1)<<(idx%64)
+	if b.minutes[word]&bit != 0 {
+		return false
+	}
+	b.minutes[word] |= bit
+
+	merged := mergeKline(b.acc, kl)
+	switch {
+	case b.acc == nil:
+		b.first, b.lastFinal = kl.OpenTime, kl.OpenTime
+	case kl.OpenTime.Before(b.first):
+		merged.Open = kl.Open
+		merged.Close, merged.EventTime = b.acc.Close, b.acc.EventTime
+		b.first = kl.OpenTime
+	case kl.OpenTime.Before(b.lastFinal):
+		merged.Close, merged.EventTime = b.acc.Close, b.acc.EventTime
+	default:
+		b.lastFinal = kl.OpenTime
+	}
+	b.acc = &merged
+	return true
+}
+
+// NewKlineAggregator 创建K线合成器，未指定周期时使用 DefaultAggregatedIntervals；1m 及无法识别的周期会被忽略
+func NewKlineAggregator(intervals ...schema.Interval) *KlineAggregator {
+	if len(intervals) == 0 {
+		intervals = DefaultAggregatedIntervals
+	}
+	a := &KlineAggregator{buckets: make(map[string]*klineBucket), past: make(map[string][]*klineBucket)}
+	for _, interval := range intervals {
+		if d := interval.Duration(); d > time.Minute && d%time.Minute == 0 {
+			a.intervals = append(a.intervals, interval)
+		}
+	}
+	return a
+}
+
+// Intervals 返回合成的目标周期
+func (a *KlineAggregator) Intervals() []schema.Interval {
+	return append([]schema.Interval(nil), a.intervals...)
+}
+
+// Update 输入一条1m K线，返回受影响的合成K线。
+// 跨入新周期时若上一根未收到最后一分钟的完结推送，会先补发一条已完结的上一根K线；
+// 早于当前周期的已完结K线（如重连后补齐的历史数据）合并到所在的历史周期并输出重新合成的K线；
+// 缺少周期第一分钟的合成K线始终不标记为已完结
+func (a *KlineAggregator) Update(kl schema.Kline) []schema.Kline {
+	if kl.Interval != schema.Interval1m {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	out := make([]schema.Kline, 0, len(a.intervals))
+	for _, interval := range a.intervals {
+		out = append(out, a.updateBucket(kl, interval)...)
+	}
+	return out
+}
+
+func (a *KlineAggregator) updateBucket(kl schema.Kline, interval schema.Interval) []schema.Kline {
+	d := interval.Duration()
+	openTime := kl.OpenTime.Truncate(d)
+	key := cacheKey(kl.Exchange, kl.Market, kl.Symbol, string(interval))
+
+	var out []schema.Kline
+	b, ok := a.buckets[key]
+	switch {
+	case !ok:
+		b = newKlineBucket(openTime, d)
+		a.buckets[key] = b
+	case openTime.Before(b.openTime):
+		// 未完结的过期推送直接丢弃
+		if !kl.IsFinal {
+			return nil
+		}
+		b = a.pastBucket(key, openTime, d)
+	case openTime.After(b.openTime):
+		if !b.finalized && b.last != nil && b.complete() {
+			closed := *b.last
+			closed.IsFinal = true
+			out = append(out, closed)
+		}
+		a.retire(key, b)
+		b = newKlineBucket(openTime, d)
+		a.buckets[key] = b
+	}
+
+	var merged schema.Kline
+	if kl.IsFinal {
+		if !b.add(kl) {
+			return out
+		}
+		merged = *b.acc
+		// 收到周期内最后一分钟的完结推送时合成K线完结
+		if !kl.OpenTime.Add(time.Minute).Before(openTime.Add(d)) {
+			b.finalized = true
+		}
+	} else {
+		if b.finalized {
+			return out
+		}
+		merged = mergeKline(b.acc, kl)
+	}
+
+	merged.Interval = interval
+	merged.OpenTime = openTime
+	merged.CloseTime = openTime.Add(d - time.Millisecond)
+	merged.IsFinal = b.finalized && b.complete()
+	b.last = &merged
+
+	return append(out, merged)
+}
+
+// retire 保存滚动出的周期，超过 pastBucketsLimit 时丢弃最早的周期
+func (a *KlineAggregator) retire(key string, b *klineBucket) {
+	b.finalized = true
+	past := append(a.past[key], b)
+	if len(past) > pastBucketsLimit {
+		past = past[len(past)-pastBucketsLimit:]
+	}
+	a.past[key] = past
+}
+
+// pastBucket 返回开盘时间为 openTime 的历史周期，未保存时新建；断线期间整个周期都没有推送时
+// 该周期只由补齐的K线合成
+func (a *KlineAggregator) pastBucket(key string, openTime time.Time, d time.Duration) *klineBucket {
+	past := a.past[key]
+	idx := sort.Search(len(past), func(i int) bool { return !past[i].openTime.Before(openTime) })
+	if idx < len(past) && past[idx].openTime.Equal(openTime) {
+		return past[idx]
+	}
+
+	b := newKlineBucket(openTime, d)
+	b.finalized = true
+	past = append(past, nil)
+	copy(past[idx+1:], past[idx:])
+	past[idx] = b
+	if len(past) > pastBucketsLimit {
+		past = past[len(past)-pastBucketsLimit:]
+	}
+	a.past[key] = past
+	return b
+}
+
+// mergeKline 将一条1m K线合并到已有结果上，acc 为空时以该K线为起点
+func mergeKline(acc *schema.Kline, kl schema.Kline) schema.Kline {
+	if acc == nil {
+		return kl
+	}
+	merged := *acc
+	if kl.High.GreaterThan(merged.High) {
+		merged.High = kl.High
+	}
+	if kl.Low.LessThan(merged.Low) {
+		merged.Low = kl.Low
+	}
+	merged.Close = kl.Close
+	merged.Volume = merged.Volume.Add(kl.Volume)
+	merged.QuoteVolume = merged.QuoteVolume.Add(kl.QuoteVolume)
+	merged.AdaptVolume = merged.AdaptVolume.Add(kl.AdaptVolume)
+	merged.TradeNum += kl.TradeNum
+	merged.EventTime = kl.EventTime
+	return merged
+}
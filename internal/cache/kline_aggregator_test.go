@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestKlineAggregator_FiveMinute(t *testing.T) {
+	a := NewKlineAggregator(schema.Interval5m)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	minute := func(i int, final bool, open, high, low, close string) schema.Kline {
+		return schema.Kline{
+			Exchange: schema.OKX, Market: schema.SPOT, Symbol: "BTC-USDT", Interval: schema.Interval1m,
+			OpenTime: base.Add(time.Duration(i) * time.Minute),
+			Open:     decimal.RequireFromString(open), High: decimal.RequireFromString(high),
+			Low: decimal.RequireFromString(low), Close: decimal.RequireFromString(close),
+			Volume: decimal.NewFromInt(1), QuoteVolume: decimal.NewFromInt(100), TradeNum: 2, IsFinal: final,
+		}
+	}
+
+	// 未完结的1m K线只更新当前合成K线，不计入累计
+	out := a.Update(minute(0, false, "10", "12", "9", "11"))
+	if len(out) != 1 || out[0].IsFinal || !out[0].Volume.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("unexpected in-progress candle: %+v", out)
+	}
+	a.Update(minute(0, true, "10", "13", "9", "12"))
+	a.Update(minute(0, true, "10", "13", "9", "12")) // 重复推送
+
+	for i := 1; i < 4; i++ {
+		a.Update(minute(i, true, "12", "15", "8", "14"))
+	}
+	out = a.Update(minute(4, true, "14", "14", "7", "13"))
+	if len(out) != 1 {
+		t.Fatalf("expected 1 derived candle, got %d", len(out))
+	}
+	kl := out[0]
+	if !kl.IsFinal || kl.Interval != schema.Interval5m || !kl.OpenTime.Equal(base) || !kl.CloseTime.Equal(base.Add(5*time.Minute-time.Millisecond)) {
+		t.Fatalf("unexpected bucket: %+v", kl)
+	}
+	if !kl.Open.Equal(decimal.NewFromInt(10)) || !kl.High.Equal(decimal.NewFromInt(15)) || !kl.Low.Equal(decimal.NewFromInt(7)) || !kl.Close.Equal(decimal.NewFromInt(13)) {
+		t.Fatalf("unexpected OHLC: %+v", kl)
+	}
+	if !kl.Volume.Equal(decimal.NewFromInt(5)) || !kl.QuoteVolume.Equal(decimal.NewFromInt(500)) || kl.TradeNum != 10 {
+		t.Fatalf("unexpected volume: %+v", kl)
+	}
+
+	// 缺少最后一分钟的完结推送时，跨入新周期先补发上一根
+	a.Update(minute(5, true, "13", "13", "13", "13"))
+	out = a.Update(minute(10, false, "20", "20", "20", "20"))
+	if len(out) != 2 || !out[0].IsFinal || !out[0].OpenTime.Equal(base.Add(5*time.Minute)) || out[1].IsFinal {
+		t.Fatalf("unexpected rollover: %+v", out)
+	}
+}
+
+func TestKlineAggregator_BackfilledMinutes(t *testing.T) {
+	a := NewKlineAggregator(schema.Interval5m)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	minute := func(i int, close int64) schema.Kline {
+		p := decimal.NewFromInt(close)
+		return schema.Kline{
+			Exchange: schema.OKX, Market: schema.SPOT, Symbol: "BTC-USDT", Interval: schema.Interval1m,
+			OpenTime: base.Add(time.Duration(i) * time.Minute),
+			Open:     p, High: p, Low: p, Close: p, Volume: decimal.NewFromInt(1), IsFinal: true,
+		}
+	}
+
+	// 断线前收到 0、1 分钟，重连后直接进入下一个周期
+	a.Update(minute(0, 10))
+	a.Update(minute(1, 11))
+	out := a.Update(minute(6, 20))
+	if len(out) != 2 || !out[0].IsFinal || !out[0].Volume.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("unexpected rollover: %+v", out)
+	}
+
+	// 补齐的 2~4 分钟合并到已滚动的周期，重新输出已完结的合成K线
+	a.Update(minute(3, 13))
+	a.Update(minute(2, 12))
+	out = a.Update(minute(4, 14))
+	if len(out) != 1 {
+		t.Fatalf("expected 1 re-aggregated candle, got %d", len(out))
+	}
+	kl := out[0]
+	if !kl.IsFinal || !kl.OpenTime.Equal(base) || !kl.Volume.Equal(decimal.NewFromInt(5)) ||
+		!kl.Open.Equal(decimal.NewFromInt(10)) || !kl.Close.Equal(decimal.NewFromInt(14)) || !kl.High.Equal(decimal.NewFromInt(14)) {
+		t.Fatalf("unexpected re-aggregated candle: %+v", kl)
+	}
+
+	// 同一分钟重复补齐不重复累计
+	if out := a.Update(minute(3, 13)); len(out) != 0 {
+		t.Fatalf("duplicate minute re-aggregated: %+v", out)
+	}
+
+	// 当前周期内乱序到达的分钟按开盘时间决定开盘价和收盘价
+	out = a.Update(minute(5, 15))
+	if len(out) != 1 || !out[0].Open.Equal(decimal.NewFromInt(15)) || !out[0].Close.Equal(decimal.NewFromInt(20)) || !out[0].Volume.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("unexpected current candle: %+v", out)
+	}
+}
+
+func TestKlineAggregator_MidPeriodStart(t *testing.T) {
+	a := NewKlineAggregator(schema.Interval1h)
+
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	minute := func(i int, close int64) schema.Kline {
+		p := decimal.NewFromInt(close)
+		return schema.Kline{
+			Exchange: schema.OKX, Market: schema.SPOT, Symbol: "BTC-USDT", Interval: schema.Interval1m,
+			OpenTime: base.Add(time.Duration(i) * time.Minute),
+			Open:     p, High: p, Low: p, Close: p, Volume: decimal.NewFromInt(1), IsFinal: true,
+		}
+	}
+
+	// 10:37 开始收到推送，直到周期最后一分钟都不标记为已完结
+	for i := 37; i < 60; i++ {
+		for _, kl := range a.Update(minute(i, int64(i))) {
+			if kl.IsFinal {
+				t.Fatalf("partial candle marked final at minute %d: %+v", i, kl)
+			}
+		}
+	}
+
+	// 跨入新周期时不补发缺少开盘分钟的上一根
+	out := a.Update(minute(60, 60))
+	if len(out) != 1 || out[0].IsFinal || !out[0].OpenTime.Equal(base.Add(time.Hour)) {
+		t.Fatalf("unexpected rollover: %+v", out)
+	}
+
+	// 补齐的 10:36 仍不是周期第一分钟
+	if out := a.Update(minute(36, 36)); len(out) != 1 || out[0].IsFinal {
+		t.Fatalf("candle final before the first minute is backfilled: %+v", out)
+	}
+
+	// 补齐 10:00~10:35 后输出开盘价和成交量完整的已完结K线
+	for i := 35; i >= 0; i-- {
+		out = a.Update(minute(i, int64(i)))
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected 1 re-aggregated candle, got %d", len(out))
+	}
+	kl := out[0]
+	if !kl.IsFinal || !kl.OpenTime.Equal(base) || !kl.Open.Equal(decimal.Zero) || !kl.Close.Equal(decimal.NewFromInt(59)) ||
+		!kl.Volume.Equal(decimal.NewFromInt(60)) {
+		t.Fatalf("unexpected backfilled candle: %+v", kl)
+	}
+}
+
+func TestMemoryCache_DerivedKlines(t *testing.T) {
+	c := NewMemoryCache()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c.SetKline(schema.Kline{Exchange: schema.GATE, Market: schema.SPOT, Symbol: "BTC_USDT", Interval: schema.Interval1m, OpenTime: base, Close: decimal.NewFromInt(1)})
+	if _, ok := c.GetKline(schema.GATE, schema.SPOT, "BTC_USDT", schema.Interval1h); !ok {
+		t.Fatalf("expected derived 1h kline")
+	}
+
+	// 直接推送的周期优先，不再写入合成结果
+	c.SetKline(schema.Kline{Exchange: schema.GATE, Market: schema.SPOT, Symbol: "BTC_USDT", Interval: schema.Interval5m, OpenTime: base, Close: decimal.NewFromInt(2)})
+	c.SetKline(schema.Kline{Exchange: schema.GATE, Market: schema.SPOT, Symbol: "BTC_USDT", Interval: schema.Interval1m, OpenTime: base, Close: decimal.NewFromInt(3)})
+	kl, _ := c.GetKline(schema.GATE, schema.SPOT, "BTC_USDT", schema.Interval5m)
+	if !kl[0].Close.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("native 5m kline overwritten: %+v", kl[0])
+	}
+}
+
+func TestMemoryCache_BackfillReaggregates(t *testing.T) {
+	c := NewMemoryCache()
+	c.SetKlineAggregation(schema.Interval5m)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	minute := func(i int) schema.Kline {
+		return schema.Kline{Exchange: schema.GATE, Market: schema.SPOT, Symbol: "BTC_USDT", Interval: schema.Interval1m,
+			OpenTime: base.Add(time.Duration(i) * time.Minute), Volume: decimal.NewFromInt(1), IsFinal: true}
+	}
+
+	c.SetKline(minute(0))
+	c.SetKline(minute(5))
+	c.SetKline(minute(1))
+
+	klines, _ := c.GetKlines(schema.GATE, schema.SPOT, "BTC_USDT", schema.Interval5m, 0)
+	if len(klines) != 2 || !klines[0].OpenTime.Equal(base) || !klines[0].Volume.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("backfilled minute not re-aggregated: %+v", klines)
+	}
+}
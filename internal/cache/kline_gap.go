@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/pkg/logger"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+// backfillTimeout 单次补齐的总超时，避免 REST 请求阻塞重连流程
+const backfillTimeout = time.Minute
+
+// KlineFetcher 按开盘时间范围拉取历史K线，与 interfaces.RESTClient.GetKlines 签名一致
+type KlineFetcher func(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error)
+
+// KlineGapFiller 代替连接器直接写入K线缓存，记录每个币对每个周期最后一根已完结K线的开盘时间，
+// 重连并恢复订阅后通过 REST 补齐断线期间缺失的K线
+type KlineGapFiller struct {
+	cache *MemoryCache
+	fetch KlineFetcher
+
+	mu        sync.Mutex
+	lastFinal map[schema.KlineSubscription]time.Time
+}
+
+// NewKlineGapFiller 创建K线缺口补齐器，fetch 为空时只写入缓存不补齐
+func NewKlineGapFiller(c *MemoryCache, fetch KlineFetcher) *KlineGapFiller {
+	return &KlineGapFiller{
+		cache:     c,
+		fetch:     fetch,
+		lastFinal: make(map[schema.KlineSubscription]time.Time),
+	}
+}
+
+// SetKline 写入K线缓存，已完结K线同时更新最后开盘时间
+func (g *KlineGapFiller) SetKline(kl schema.Kline) {
+	g.cache.SetKline(kl)
+	if kl.IsFinal {
+		g.track(kl)
+	}
+}
+
+func (g *KlineGapFiller) track(kl schema.Kline) {
+	key := schema.KlineSubscription{Symbol: kl.Symbol, Interval: kl.Interval}
+
+	g.mu.Lock()
+	if last, ok := g.lastFinal[key]; !ok || kl.OpenTime.After(last) {
+		g.lastFinal[key] = kl.OpenTime
+	}
+	g.mu.Unlock()
+}
+
+// LastFinal 返回指定币对和周期最后一根已完结K线的开盘时间
+func (g *KlineGapFiller) LastFinal(symbol string, interval schema.Interval) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	last, ok := g.lastFinal[schema.KlineSubscription{Symbol: symbol, Interval: interval}]
+	return last, ok
+}
+
+// Forget 清除已退订K线的最后开盘时间，之后重新订阅时从新收到的已完结K线开始记录，
+// 不会按退订前的位置补齐
+func (g *KlineGapFiller) Forget(subs []schema.KlineSubscription) {
+	g.mu.Lock()
+	for _, sub := range subs {
+		delete(g.lastFinal, sub)
+	}
+	g.mu.Unlock()
+}
+
+// Backfill 检查当前订阅的K线在最后一根已完结K线之后是否缺少已收盘的周期，缺失时通过 REST 补齐写入缓存并发布补齐事件。
+// 从未收到过已完结K线的订阅无法判断缺口，直接跳过；整体耗时不超过 backfillTimeout
+func (g *KlineGapFiller) Backfill(ctx context.Context, subs []schema.KlineSubscription) {
+	if g.fetch == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, backfillTimeout)
+	defer cancel()
+
+	now := time.Now()
+	for _, sub := range subs {
+		last, ok := g.LastFinal(sub.Symbol, sub.Interval)
+		if !ok {
+			continue
+		}
+
+		d := sub.Interval.Duration()
+		if d <= 0 {
+			continue
+		}
+		// 缺口范围：最后一根已完结K线的下一根到当前时间之前最后一根已收盘的K线
+		from := last.Add(d)
+		to := now.Truncate(d).Add(-d)
+		if from.After(to) {
+			continue
+		}
+
+		klines, err := g.fetch(ctx, sub.Symbol, sub.Interval, from, to)
+		if err != nil {
+			logger.Warn("补齐K线缺口失败 %s %s [%s, %s]: %v", sub.Symbol, sub.Interval, from.Format(time.RFC3339), to.Format(time.RFC3339), err)
+			continue
+		}
+
+		repaired := schema.KlineGapRepaired{Symbol: sub.Symbol, Interval: sub.Interval, From: from, To: to}
+		for _, kl := range klines {
+			if !kl.IsFinal {
+				continue
+			}
+			g.SetKline(kl)
+			repaired.Exchange, repaired.Market = kl.Exchange, kl.Market
+			repaired.Count++
+		}
+		if repaired.Count == 0 {
+			continue
+		}
+
+		logger.Info("%s %s 补齐K线缺口 %s %s: %d 条", repaired.Exchange, repaired.Market, sub.Symbol, sub.Interval, repaired.Count)
+		g.cache.PublishKlineGapRepaired(repaired)
+	}
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestKlineGapFiller_Backfill(t *testing.T) {
+	c := NewMemoryCache()
+	var events []schema.KlineGapRepaired
+	c.OnKlineGapRepaired(func(e schema.KlineGapRepaired) { events = append(events, e) })
+
+	var gotFrom, gotTo time.Time
+	fetch := func(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+		gotFrom, gotTo = start, end
+		var out []schema.Kline
+		for t := start; !t.After(end); t = t.Add(time.Minute) {
+			out = append(out, schema.Kline{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: symbol, Interval: interval, OpenTime: t, IsFinal: true})
+		}
+		return out, nil
+	}
+	g := NewKlineGapFiller(c, fetch)
+
+	last := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	g.SetKline(schema.Kline{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: "BTCUSDT", Interval: schema.Interval1m, OpenTime: last, IsFinal: true})
+	// 未完结K线不影响最后开盘时间
+	g.SetKline(schema.Kline{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: "BTCUSDT", Interval: schema.Interval1m, OpenTime: last.Add(time.Minute)})
+
+	subs := []schema.KlineSubscription{
+		{Symbol: "BTCUSDT", Interval: schema.Interval1m},
+		{Symbol: "ETHUSDT", Interval: schema.Interval1m}, // 从未收到过已完结K线，跳过
+	}
+	g.Backfill(context.Background(), subs)
+
+	if !gotFrom.Equal(last.Add(time.Minute)) || !gotTo.After(gotFrom) {
+		t.Fatalf("unexpected gap range [%s, %s]", gotFrom, gotTo)
+	}
+	if len(events) != 1 || events[0].Symbol != "BTCUSDT" || events[0].Exchange != schema.BYBIT || events[0].Count < 8 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if lf, _ := g.LastFinal("BTCUSDT", schema.Interval1m); !lf.Equal(gotTo) {
+		t.Fatalf("last final not advanced: %s != %s", lf, gotTo)
+	}
+
+	// 已无缺口时不再拉取
+	gotFrom = time.Time{}
+	g.Backfill(context.Background(), subs)
+	if !gotFrom.IsZero() || len(events) != 1 {
+		t.Fatalf("unexpected second backfill")
+	}
+}
+
+func TestKlineGapFiller_Forget(t *testing.T) {
+	g := NewKlineGapFiller(NewMemoryCache(), nil)
+	g.SetKline(schema.Kline{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: "BTCUSDT", Interval: schema.Interval1m, OpenTime: time.Now(), IsFinal: true})
+	g.SetKline(schema.Kline{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: "BTCUSDT", Interval: schema.Interval5m, OpenTime: time.Now(), IsFinal: true})
+
+	// 退订后重新订阅不应从退订前的位置补齐
+	g.Forget([]schema.KlineSubscription{{Symbol: "BTCUSDT", Interval: schema.Interval1m}})
+	if _, ok := g.LastFinal("BTCUSDT", schema.Interval1m); ok {
+		t.Fatalf("last final should be cleared after unsubscribe")
+	}
+	if _, ok := g.LastFinal("BTCUSDT", schema.Interval5m); !ok {
+		t.Fatalf("other intervals must be kept")
+	}
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,11 +17,58 @@ import (
 type MemoryCache struct {
 	// 原子操作映射表 - 存储指向数据的原子指针
 	depths sync.Map // map[string]*unsafe.Pointer -> *schema.Depth
-	klines sync.Map // map[string]*unsafe.Pointer -> *[]schema.Kline
+	klines sync.Map // map[string]*klineSeries
+	trades sync.Map // map[string]*tradeBuffer
+	// 最优买卖价单独存放，读取时无需拷贝整个深度
+	bookTickers sync.Map // map[string]*unsafe.Pointer -> *schema.BookTicker
+	tickers     sync.Map // map[string]*unsafe.Pointer -> *schema.Ticker
+	fundings    sync.Map // map[string]*unsafe.Pointer -> *schema.FundingInfo
+	openInts    sync.Map // map[string]*unsafe.Pointer -> *schema.OpenInterest
+
+	// 强平事件不缓存，逐条分发给已注册的回调
+	liqMu       sync.RWMutex
+	liqHandlers []schema.LiquidationHandler
+	gapMu       sync.RWMutex
+	gapHandlers []schema.KlineGapHandler
+
+	// 每个币对每个周期保留的已完结K线条数，<= 0 时使用 DefaultKlineHistorySize
+	klineHistorySize atomic.Int64
+
+	// 由1m K线合成更大周期，已通过WS直接推送的周期不再写入合成结果
+	aggregator   atomic.Pointer[KlineAggregator]
+	nativeKlines sync.Map // map[string]struct{}
+}
+
+// MaxTradesPerSymbol 每个币对保留的最近成交条数
+const MaxTradesPerSymbol = 1000
+
+// tradeBuffer 固定容量的环形缓冲区，写满后覆盖最旧的成交
+type tradeBuffer struct {
+	mu     sync.RWMutex
+	trades []schema.Trade
+	next   int // 下一条写入位置
+	full   bool
+}
+
+// DefaultKlineHistorySize 每个币对每个周期默认保留的已完结K线条数
+const DefaultKlineHistorySize = 1000
+
+// klineSeries 单个币对单个周期的K线序列，写入加锁，读取只原子加载快照
+type klineSeries struct {
+	mu   sync.Mutex
+	snap unsafe.Pointer // *klineSnapshot
+}
+
+// klineSnapshot 不可变快照：closed 按开盘时间升序，写入只追加到已发布长度之后，读者持有的切片不会被修改
+type klineSnapshot struct {
+	closed  []schema.Kline
+	current *schema.Kline // 未完结K线
 }
 
 func NewMemoryCache() *MemoryCache {
-	return &MemoryCache{}
+	m := &MemoryCache{}
+	m.aggregator.Store(NewKlineAggregator())
+	return m
 }
 
 // cacheKey generates a cache key for exchange-specific data
@@ -91,39 +139,410 @@ func (m *MemoryCache) GetDepth(exchange schema.ExchangeName, market schema.Marke
 	return schema.Depth{}, false
 }
 
-func (m *MemoryCache) SetKline(kl schema.Kline) {
-	key := cacheKey(kl.Exchange, kl.Market, kl.Symbol, string(kl.Interval))
+// SetBookTicker 更新最优买卖价，只保留最新一条
+func (m *MemoryCache) SetBookTicker(bt schema.BookTicker) {
+	if bt.UpdatedAt.IsZero() {
+		bt.UpdatedAt = time.Now()
+	}
 
-	// 🚀 原子操作：创建新数据副本（只保留最新一条）
-	newKlineArray := &[]schema.Kline{kl}
+	key := cacheKey(bt.Exchange, bt.Market, bt.Symbol)
 
-	// 获取或创建原子指针
 	var nilPtr unsafe.Pointer
-	atomicPtrInterface, _ := m.klines.LoadOrStore(key, &nilPtr)
+	atomicPtrInterface, _ := m.bookTickers.LoadOrStore(key, &nilPtr)
 	atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
 
-	// 🚀 原子替换指针，零拷贝操作
-	atomic.StorePointer(atomicPtr, unsafe.Pointer(newKlineArray))
+	atomic.StorePointer(atomicPtr, unsafe.Pointer(&bt))
 }
 
-// AppendKline 保持向后兼容，但实际上只设置最新数据
-func (m *MemoryCache) AppendKline(kl schema.Kline) {
-	m.SetKline(kl)
+// GetBookTicker 读取最优买卖价
+func (m *MemoryCache) GetBookTicker(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.BookTicker, bool) {
+	key := cacheKey(exchange, market, symbol)
+
+	if atomicPtrInterface, ok := m.bookTickers.Load(key); ok {
+		atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
+		dataPtr := atomic.LoadPointer(atomicPtr)
+		if dataPtr != nil {
+			return *(*schema.BookTicker)(dataPtr), true
+		}
+	}
+
+	return schema.BookTicker{}, false
 }
 
-func (m *MemoryCache) GetKline(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval) ([]schema.Kline, bool) {
-	key := cacheKey(exchange, market, symbol, string(interval))
+// SetTicker 更新24小时行情，只保留最新一条
+func (m *MemoryCache) SetTicker(t schema.Ticker) {
+	if t.Timestamp.IsZero() {
+		t.Timestamp = time.Now()
+	}
 
-	// 🚀 原子操作：从原子缓存读取
-	if atomicPtrInterface, ok := m.klines.Load(key); ok {
+	key := cacheKey(t.Exchange, t.Market, t.Symbol)
+
+	var nilPtr unsafe.Pointer
+	atomicPtrInterface, _ := m.tickers.LoadOrStore(key, &nilPtr)
+	atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
+
+	atomic.StorePointer(atomicPtr, unsafe.Pointer(&t))
+}
+
+// GetTicker 读取24小时行情
+func (m *MemoryCache) GetTicker(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.Ticker, bool) {
+	key := cacheKey(exchange, market, symbol)
+
+	if atomicPtrInterface, ok := m.tickers.Load(key); ok {
 		atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
 		dataPtr := atomic.LoadPointer(atomicPtr)
 		if dataPtr != nil {
-			klineArray := (*[]schema.Kline)(dataPtr)
-			return *klineArray, true // 🚀 零拷贝返回
+			return *(*schema.Ticker)(dataPtr), true
 		}
 	}
 
-	// 数据不存在
+	return schema.Ticker{}, false
+}
+
+// SetFunding 更新标记价格、指数价格和资金费率，只保留最新一条
+func (m *MemoryCache) SetFunding(f schema.FundingInfo) {
+	if f.Timestamp.IsZero() {
+		f.Timestamp = time.Now()
+	}
+
+	key := cacheKey(f.Exchange, f.Market, f.Symbol)
+
+	var nilPtr unsafe.Pointer
+	atomicPtrInterface, _ := m.fundings.LoadOrStore(key, &nilPtr)
+	atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
+
+	atomic.StorePointer(atomicPtr, unsafe.Pointer(&f))
+}
+
+// GetFunding 读取标记价格、指数价格和资金费率
+func (m *MemoryCache) GetFunding(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.FundingInfo, bool) {
+	key := cacheKey(exchange, market, symbol)
+
+	if atomicPtrInterface, ok := m.fundings.Load(key); ok {
+		atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
+		dataPtr := atomic.LoadPointer(atomicPtr)
+		if dataPtr != nil {
+			return *(*schema.FundingInfo)(dataPtr), true
+		}
+	}
+
+	return schema.FundingInfo{}, false
+}
+
+// SetOpenInterest 更新合约持仓量，只保留最新一条
+func (m *MemoryCache) SetOpenInterest(oi schema.OpenInterest) {
+	if oi.Timestamp.IsZero() {
+		oi.Timestamp = time.Now()
+	}
+
+	key := cacheKey(oi.Exchange, oi.Market, oi.Symbol)
+
+	var nilPtr unsafe.Pointer
+	atomicPtrInterface, _ := m.openInts.LoadOrStore(key, &nilPtr)
+	atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
+
+	atomic.StorePointer(atomicPtr, unsafe.Pointer(&oi))
+}
+
+// GetOpenInterest 读取合约持仓量
+func (m *MemoryCache) GetOpenInterest(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.OpenInterest, bool) {
+	key := cacheKey(exchange, market, symbol)
+
+	if atomicPtrInterface, ok := m.openInts.Load(key); ok {
+		atomicPtr := atomicPtrInterface.(*unsafe.Pointer)
+		dataPtr := atomic.LoadPointer(atomicPtr)
+		if dataPtr != nil {
+			return *(*schema.OpenInterest)(dataPtr), true
+		}
+	}
+
+	return schema.OpenInterest{}, false
+}
+
+// OnLiquidation 注册强平事件回调，所有交易所和市场的事件都会分发给同一回调
+func (m *MemoryCache) OnLiquidation(h schema.LiquidationHandler) {
+	if h == nil {
+		return
+	}
+	m.liqMu.Lock()
+	m.liqHandlers = append(m.liqHandlers, h)
+	m.liqMu.Unlock()
+}
+
+// PublishLiquidation 将强平事件依次分发给已注册的回调，回调在调用方协程中同步执行
+func (m *MemoryCache) PublishLiquidation(l schema.Liquidation) {
+	if l.Timestamp.IsZero() {
+		l.Timestamp = time.Now()
+	}
+
+	m.liqMu.RLock()
+	handlers := m.liqHandlers
+	m.liqMu.RUnlock()
+
+	for _, h := range handlers {
+		h(l)
+	}
+}
+
+// OnKlineGapRepaired 注册K线缺口补齐事件回调，所有交易所和市场的事件都会分发给同一回调
+func (m *MemoryCache) OnKlineGapRepaired(h schema.KlineGapHandler) {
+	if h == nil {
+		return
+	}
+	m.gapMu.Lock()
+	m.gapHandlers = append(m.gapHandlers, h)
+	m.gapMu.Unlock()
+}
+
+// PublishKlineGapRepaired 将K线缺口补齐事件依次分发给已注册的回调，回调在调用方协程中同步执行
+func (m *MemoryCache) PublishKlineGapRepaired(e schema.KlineGapRepaired) {
+	m.gapMu.RLock()
+	handlers := m.gapHandlers
+	m.gapMu.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
+
+// SetKlineHistorySize 设置每个币对每个周期保留的已完结K线条数，只影响之后的写入
+func (m *MemoryCache) SetKlineHistorySize(n int) {
+	m.klineHistorySize.Store(int64(n))
+}
+
+func (m *MemoryCache) klineHistoryLimit() int {
+	if n := m.klineHistorySize.Load(); n > 0 {
+		return int(n)
+	}
+	return DefaultKlineHistorySize
+}
+
+// SetKlineAggregation 设置由1m K线合成的周期，不传周期时关闭合成
+func (m *MemoryCache) SetKlineAggregation(intervals ...schema.Interval) {
+	if len(intervals) == 0 {
+		m.aggregator.Store(nil)
+		return
+	}
+	m.aggregator.Store(NewKlineAggregator(intervals...))
+}
+
+// SetKline 写入一条K线并由1m K线合成更大周期的K线
+func (m *MemoryCache) SetKline(kl schema.Kline) {
+	key := cacheKey(kl.Exchange, kl.Market, kl.Symbol, string(kl.Interval))
+	if kl.Interval != schema.Interval1m {
+		m.nativeKlines.Store(key, struct{}{})
+		m.storeKline(key, kl)
+		return
+	}
+
+	m.storeKline(key, kl)
+
+	aggregator := m.aggregator.Load()
+	if aggregator == nil {
+		return
+	}
+	for _, derived := range aggregator.Update(kl) {
+		derivedKey := cacheKey(derived.Exchange, derived.Market, derived.Symbol, string(derived.Interval))
+		if _, native := m.nativeKlines.Load(derivedKey); native {
+			continue
+		}
+		m.storeKline(derivedKey, derived)
+	}
+}
+
+// storeKline 写入K线序列：未完结K线按开盘时间覆盖当前K线，已完结K线追加到历史，
+// 早于最新历史的K线（如补齐的历史数据）按开盘时间插入或替换
+func (m *MemoryCache) storeKline(key string, kl schema.Kline) {
+
+	seriesInterface, ok := m.klines.Load(key)
+	if !ok {
+		seriesInterface, _ = m.klines.LoadOrStore(key, &klineSeries{})
+	}
+	series := seriesInterface.(*klineSeries)
+
+	series.mu.Lock()
+	defer series.mu.Unlock()
+
+	var old klineSnapshot
+	if p := atomic.LoadPointer(&series.snap); p != nil {
+		old = *(*klineSnapshot)(p)
+	}
+	next := klineSnapshot{closed: old.closed, current: old.current}
+
+	n := len(old.closed)
+	if kl.IsFinal {
+		switch {
+		case n == 0 || kl.OpenTime.After(old.closed[n-1].OpenTime):
+			// 常规路径：追加到已发布长度之后，不影响读者持有的快照
+			next.closed = append(old.closed, kl)
+		default:
+			next.closed = insertKline(old.closed, kl)
+		}
+		if next.current != nil && !next.current.OpenTime.After(kl.OpenTime) {
+			next.current = nil
+		}
+		if limit := m.klineHistoryLimit(); len(next.closed) > limit {
+			next.closed = next.closed[len(next.closed)-limit:]
+		}
+	} else {
+		// 已完结之后收到的同一根或更早的未完结推送视为过期数据
+		if n > 0 && !kl.OpenTime.After(old.closed[n-1].OpenTime) {
+			return
+		}
+		if old.current != nil && kl.OpenTime.Before(old.current.OpenTime) {
+			return
+		}
+		next.current = &kl
+	}
+
+	atomic.StorePointer(&series.snap, unsafe.Pointer(&next))
+}
+
+// insertKline 复制一份历史并按开盘时间插入，相同开盘时间时替换
+func insertKline(closed []schema.Kline, kl schema.Kline) []schema.Kline {
+	idx := sort.Search(len(closed), func(i int) bool { return !closed[i].OpenTime.Before(kl.OpenTime) })
+	out := make([]schema.Kline, 0, len(closed)+1)
+	out = append(out, closed[:idx]...)
+	out = append(out, kl)
+	if idx < len(closed) && closed[idx].OpenTime.Equal(kl.OpenTime) {
+		idx++
+	}
+	return append(out, closed[idx:]...)
+}
+
+// AppendKline 与 SetKline 相同，保留用于向后兼容
+func (m *MemoryCache) AppendKline(kl schema.Kline) {
+	m.SetKline(kl)
+}
+
+func (m *MemoryCache) loadKlines(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval) (klineSnapshot, bool) {
+	key := cacheKey(exchange, market, symbol, string(interval))
+	seriesInterface, ok := m.klines.Load(key)
+	if !ok {
+		return klineSnapshot{}, false
+	}
+	p := atomic.LoadPointer(&seriesInterface.(*klineSeries).snap)
+	if p == nil {
+		return klineSnapshot{}, false
+	}
+	return *(*klineSnapshot)(p), true
+}
+
+// GetKline 返回只包含最新一条K线的切片（未完结K线优先）
+func (m *MemoryCache) GetKline(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval) ([]schema.Kline, bool) {
+	snap, ok := m.loadKlines(exchange, market, symbol, interval)
+	if !ok {
+		return []schema.Kline{}, false
+	}
+	if snap.current != nil {
+		return []schema.Kline{*snap.current}, true
+	}
+	if n := len(snap.closed); n > 0 {
+		return []schema.Kline{snap.closed[n-1]}, true
+	}
 	return []schema.Kline{}, false
 }
+
+// GetKlines 返回最近 limit 条K线（包含未完结K线），按开盘时间由旧到新排列；limit <= 0 时返回全部
+func (m *MemoryCache) GetKlines(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval, limit int) ([]schema.Kline, bool) {
+	snap, ok := m.loadKlines(exchange, market, symbol, interval)
+	if !ok {
+		return []schema.Kline{}, false
+	}
+
+	total := len(snap.closed)
+	if snap.current != nil {
+		total++
+	}
+	if total == 0 {
+		return []schema.Kline{}, false
+	}
+	if limit <= 0 || limit > total {
+		limit = total
+	}
+
+	out := make([]schema.Kline, 0, limit)
+	if snap.current != nil {
+		limit--
+	}
+	out = append(out, snap.closed[len(snap.closed)-limit:]...)
+	if snap.current != nil {
+		out = append(out, *snap.current)
+	}
+	return out, true
+}
+
+// GetKlinesRange 返回开盘时间在 [from, to] 内的K线（包含未完结K线），按开盘时间由旧到新排列
+func (m *MemoryCache) GetKlinesRange(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval, from, to time.Time) ([]schema.Kline, bool) {
+	snap, ok := m.loadKlines(exchange, market, symbol, interval)
+	if !ok {
+		return []schema.Kline{}, false
+	}
+
+	start := sort.Search(len(snap.closed), func(i int) bool { return !snap.closed[i].OpenTime.Before(from) })
+	end := sort.Search(len(snap.closed), func(i int) bool { return snap.closed[i].OpenTime.After(to) })
+
+	out := make([]schema.Kline, 0, end-start+1)
+	out = append(out, snap.closed[start:end]...)
+	if c := snap.current; c != nil && !c.OpenTime.Before(from) && !c.OpenTime.After(to) {
+		out = append(out, *c)
+	}
+	if len(out) == 0 {
+		return []schema.Kline{}, false
+	}
+	return out, true
+}
+
+// AddTrade 追加一条公共成交，每个币对最多保留 MaxTradesPerSymbol 条
+func (m *MemoryCache) AddTrade(t schema.Trade) {
+	key := cacheKey(t.Exchange, t.Market, t.Symbol)
+
+	bufInterface, ok := m.trades.Load(key)
+	if !ok {
+		bufInterface, _ = m.trades.LoadOrStore(key, &tradeBuffer{trades: make([]schema.Trade, MaxTradesPerSymbol)})
+	}
+	buf := bufInterface.(*tradeBuffer)
+
+	buf.mu.Lock()
+	buf.trades[buf.next] = t
+	buf.next = (buf.next + 1) % len(buf.trades)
+	if buf.next == 0 {
+		buf.full = true
+	}
+	buf.mu.Unlock()
+}
+
+// GetTrades 返回最近 n 条成交，按时间由旧到新排列；n <= 0 时返回缓冲区内全部成交
+func (m *MemoryCache) GetTrades(exchange schema.ExchangeName, market schema.MarketType, symbol string, n int) ([]schema.Trade, bool) {
+	key := cacheKey(exchange, market, symbol)
+
+	bufInterface, ok := m.trades.Load(key)
+	if !ok {
+		return []schema.Trade{}, false
+	}
+	buf := bufInterface.(*tradeBuffer)
+
+	buf.mu.RLock()
+	defer buf.mu.RUnlock()
+
+	size := buf.next
+	if buf.full {
+		size = len(buf.trades)
+	}
+	if size == 0 {
+		return []schema.Trade{}, false
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+
+	out := make([]schema.Trade, n)
+	start := buf.next - n
+	if start < 0 {
+		start += len(buf.trades)
+	}
+	for i := 0; i < n; i++ {
+		out[i] = buf.trades[(start+i)%len(buf.trades)]
+	}
+	return out, true
+}
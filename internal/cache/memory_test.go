@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestGetTrades_RingBuffer(t *testing.T) {
+	c := NewMemoryCache()
+
+	if _, ok := c.GetTrades(schema.BINANCE, schema.SPOT, "BTCUSDT", 10); ok {
+		t.Fatalf("expected no trades before any write")
+	}
+
+	total := MaxTradesPerSymbol + 5
+	for i := 0; i < total; i++ {
+		c.AddTrade(schema.Trade{Exchange: schema.BINANCE, Market: schema.SPOT, Symbol: "BTCUSDT", TradeID: fmt.Sprintf("%d", i)})
+	}
+
+	// 写满后覆盖最旧的成交
+	all, ok := c.GetTrades(schema.BINANCE, schema.SPOT, "BTCUSDT", 0)
+	if !ok || len(all) != MaxTradesPerSymbol {
+		t.Fatalf("expected %d trades, got %d", MaxTradesPerSymbol, len(all))
+	}
+	if all[0].TradeID != "5" || all[len(all)-1].TradeID != fmt.Sprintf("%d", total-1) {
+		t.Fatalf("unexpected order: first=%s last=%s", all[0].TradeID, all[len(all)-1].TradeID)
+	}
+
+	// 最近 n 条按时间由旧到新排列
+	recent, _ := c.GetTrades(schema.BINANCE, schema.SPOT, "BTCUSDT", 3)
+	for i, want := range []int{total - 3, total - 2, total - 1} {
+		if recent[i].TradeID != fmt.Sprintf("%d", want) {
+			t.Fatalf("recent[%d] = %s, want %d", i, recent[i].TradeID, want)
+		}
+	}
+}
+
+func TestBookTicker_LatestOnly(t *testing.T) {
+	c := NewMemoryCache()
+
+	if _, ok := c.GetBookTicker(schema.OKX, schema.SPOT, "BTC-USDT"); ok {
+		t.Fatalf("expected no book ticker before any write")
+	}
+
+	c.SetBookTicker(schema.BookTicker{Exchange: schema.OKX, Market: schema.SPOT, Symbol: "BTC-USDT", LastUpdateId: "1"})
+	c.SetBookTicker(schema.BookTicker{Exchange: schema.OKX, Market: schema.SPOT, Symbol: "BTC-USDT", LastUpdateId: "2"})
+
+	bt, ok := c.GetBookTicker(schema.OKX, schema.SPOT, "BTC-USDT")
+	if !ok || bt.LastUpdateId != "2" || bt.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected book ticker: %+v", bt)
+	}
+}
+
+func TestTicker_LatestOnly(t *testing.T) {
+	c := NewMemoryCache()
+
+	if _, ok := c.GetTicker(schema.BINANCE, schema.SPOT, "BTCUSDT"); ok {
+		t.Fatalf("expected no ticker before any write")
+	}
+
+	c.SetTicker(schema.Ticker{Exchange: schema.BINANCE, Market: schema.SPOT, Symbol: "BTCUSDT", Price: decimal.NewFromInt(100)})
+	c.SetTicker(schema.Ticker{Exchange: schema.BINANCE, Market: schema.SPOT, Symbol: "BTCUSDT", Price: decimal.NewFromInt(101)})
+
+	tk, ok := c.GetTicker(schema.BINANCE, schema.SPOT, "BTCUSDT")
+	if !ok || !tk.Price.Equal(decimal.NewFromInt(101)) || tk.Timestamp.IsZero() {
+		t.Fatalf("unexpected ticker: %+v", tk)
+	}
+}
+
+func TestFunding_LatestOnly(t *testing.T) {
+	c := NewMemoryCache()
+
+	if _, ok := c.GetFunding(schema.BINANCE, schema.FUTURESUSDT, "BTCUSDT"); ok {
+		t.Fatalf("expected no funding before any write")
+	}
+
+	c.SetFunding(schema.FundingInfo{Exchange: schema.BINANCE, Market: schema.FUTURESUSDT, Symbol: "BTCUSDT", FundingRate: decimal.RequireFromString("0.0001")})
+	c.SetFunding(schema.FundingInfo{Exchange: schema.BINANCE, Market: schema.FUTURESUSDT, Symbol: "BTCUSDT", FundingRate: decimal.RequireFromString("0.0002")})
+
+	f, ok := c.GetFunding(schema.BINANCE, schema.FUTURESUSDT, "BTCUSDT")
+	if !ok || f.FundingRate.String() != "0.0002" || f.Timestamp.IsZero() {
+		t.Fatalf("unexpected funding: %+v", f)
+	}
+	if _, ok := c.GetFunding(schema.BINANCE, schema.FUTURESCOIN, "BTCUSDT"); ok {
+		t.Fatalf("funding should be keyed by market")
+	}
+}
+
+func TestOpenInterest_LatestOnly(t *testing.T) {
+	c := NewMemoryCache()
+
+	c.SetOpenInterest(schema.OpenInterest{Exchange: schema.OKX, Market: schema.FUTURESUSDT, Symbol: "BTC-USDT-SWAP", OpenInterest: decimal.NewFromInt(100)})
+	c.SetOpenInterest(schema.OpenInterest{Exchange: schema.OKX, Market: schema.FUTURESUSDT, Symbol: "BTC-USDT-SWAP", OpenInterest: decimal.NewFromInt(120)})
+
+	oi, ok := c.GetOpenInterest(schema.OKX, schema.FUTURESUSDT, "BTC-USDT-SWAP")
+	if !ok || !oi.OpenInterest.Equal(decimal.NewFromInt(120)) || oi.Timestamp.IsZero() {
+		t.Fatalf("unexpected open interest: %+v", oi)
+	}
+}
+
+func TestPublishLiquidation_EveryEvent(t *testing.T) {
+	c := NewMemoryCache()
+
+	var got []schema.Liquidation
+	c.OnLiquidation(func(l schema.Liquidation) { got = append(got, l) })
+
+	c.PublishLiquidation(schema.Liquidation{Exchange: schema.BINANCE, Market: schema.FUTURESUSDT, Symbol: "BTCUSDT", Side: schema.OrderSideSell, Quantity: decimal.NewFromInt(1)})
+	c.PublishLiquidation(schema.Liquidation{Exchange: schema.BINANCE, Market: schema.FUTURESUSDT, Symbol: "BTCUSDT", Side: schema.OrderSideBuy, Quantity: decimal.NewFromInt(2)})
+
+	if len(got) != 2 || got[0].Side != schema.OrderSideSell || !got[1].Quantity.Equal(decimal.NewFromInt(2)) || got[1].Timestamp.IsZero() {
+		t.Fatalf("unexpected liquidations: %+v", got)
+	}
+}
+
+func TestKlineHistory_UpsertAndTrim(t *testing.T) {
+	c := NewMemoryCache()
+	c.SetKlineHistorySize(3)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	kline := func(i int, final bool, close string) schema.Kline {
+		return schema.Kline{
+			Exchange: schema.BINANCE, Market: schema.SPOT, Symbol: "BTCUSDT", Interval: schema.Interval1m,
+			OpenTime: base.Add(time.Duration(i) * time.Minute), Close: decimal.RequireFromString(close), IsFinal: final,
+		}
+	}
+
+	// 未完结K线按开盘时间覆盖，完结后进入历史
+	c.SetKline(kline(0, false, "1"))
+	c.SetKline(kline(0, false, "2"))
+	c.SetKline(kline(0, true, "3"))
+	c.SetKline(kline(0, false, "4")) // 完结后的过期推送
+	all, ok := c.GetKlines(schema.BINANCE, schema.SPOT, "BTCUSDT", schema.Interval1m, 0)
+	if !ok || len(all) != 1 || !all[0].Close.Equal(decimal.NewFromInt(3)) {
+		t.Fatalf("unexpected klines after upsert: %+v", all)
+	}
+
+	for i := 1; i <= 4; i++ {
+		c.SetKline(kline(i, true, fmt.Sprintf("%d", 10+i)))
+	}
+	c.SetKline(kline(5, false, "20"))
+
+	// 历史只保留 3 条，未完结K线排在最后
+	all, _ = c.GetKlines(schema.BINANCE, schema.SPOT, "BTCUSDT", schema.Interval1m, 0)
+	if len(all) != 4 || !all[0].OpenTime.Equal(base.Add(2*time.Minute)) || all[3].IsFinal {
+		t.Fatalf("unexpected trimmed klines: %+v", all)
+	}
+	latest, _ := c.GetKline(schema.BINANCE, schema.SPOT, "BTCUSDT", schema.Interval1m)
+	if len(latest) != 1 || !latest[0].Close.Equal(decimal.NewFromInt(20)) {
+		t.Fatalf("unexpected latest kline: %+v", latest)
+	}
+
+	// 补齐的历史K线按开盘时间替换，不影响之前读到的切片
+	c.SetKline(kline(3, true, "99"))
+	if !all[1].Close.Equal(decimal.NewFromInt(13)) {
+		t.Fatalf("snapshot was mutated: %+v", all[1])
+	}
+	rng, ok := c.GetKlinesRange(schema.BINANCE, schema.SPOT, "BTCUSDT", schema.Interval1m, base.Add(3*time.Minute), base.Add(4*time.Minute))
+	if !ok || len(rng) != 2 || !rng[0].Close.Equal(decimal.NewFromInt(99)) || !rng[1].Close.Equal(decimal.NewFromInt(14)) {
+		t.Fatalf("unexpected range: %+v", rng)
+	}
+
+	last2, _ := c.GetKlines(schema.BINANCE, schema.SPOT, "BTCUSDT", schema.Interval1m, 2)
+	if len(last2) != 2 || !last2[0].OpenTime.Equal(base.Add(4*time.Minute)) {
+		t.Fatalf("unexpected last 2 klines: %+v", last2)
+	}
+}
@@ -11,32 +11,46 @@ import (
 type SubscriptionManagerImpl struct {
 	mu sync.RWMutex
 
-	// subscribed symbols for kline
-	klineSymbols map[string]struct{}
+	// subscribed kline intervals per symbol
+	klineSymbols map[string]map[schema.Interval]struct{}
 	// subscribed symbols for depth
 	depthSymbols map[string]struct{}
-	// kline interval for all symbols (all symbols use the same interval)
-	klineInterval schema.Interval
+	// subscribed symbols for public trades (managed independently of kline/depth)
+	tradeSymbols map[string]struct{}
+	// subscribed symbols for best bid/offer (managed independently of kline/depth)
+	bookTickerSymbols map[string]struct{}
+	// subscribed symbols for 24h rolling ticker (managed independently of kline/depth)
+	tickerSymbols map[string]struct{}
+	// subscribed symbols for mark/index price and funding rate (futures only)
+	fundingSymbols map[string]struct{}
+	// subscribed symbols for open interest (futures only)
+	openInterestSymbols map[string]struct{}
+	// subscribed symbols for liquidation orders (futures only)
+	liquidationSymbols map[string]struct{}
 }
 
 // NewSubscriptionManager creates a new subscription manager
 func NewSubscriptionManager() interfaces.SubscriptionManager {
 	return &SubscriptionManagerImpl{
-		klineSymbols:  make(map[string]struct{}),
-		depthSymbols:  make(map[string]struct{}),
-		klineInterval: schema.Interval1m, // default interval
+		klineSymbols:        make(map[string]map[schema.Interval]struct{}),
+		depthSymbols:        make(map[string]struct{}),
+		tradeSymbols:        make(map[string]struct{}),
+		bookTickerSymbols:   make(map[string]struct{}),
+		tickerSymbols:       make(map[string]struct{}),
+		fundingSymbols:      make(map[string]struct{}),
+		openInterestSymbols: make(map[string]struct{}),
+		liquidationSymbols:  make(map[string]struct{}),
 	}
 }
 
-// SubscribeSymbols adds symbols to all subscriptions (kline, depth, etc.), returns newly added symbols
+// SubscribeSymbols adds symbols to kline (default interval) and depth subscriptions, returns newly added symbols
 func (sm *SubscriptionManagerImpl) SubscribeSymbols(symbols []string) []string {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	var newlyAdded []string
 	for _, symbol := range symbols {
-		if _, exists := sm.klineSymbols[symbol]; !exists {
-			sm.klineSymbols[symbol] = struct{}{}
+		if sm.addKline(symbol, schema.DefaultKlineInterval) {
 			newlyAdded = append(newlyAdded, symbol)
 		}
 		if _, exists := sm.depthSymbols[symbol]; !exists {
@@ -48,7 +62,7 @@ func (sm *SubscriptionManagerImpl) SubscribeSymbols(symbols []string) []string {
 	return newlyAdded
 }
 
-// UnsubscribeSymbols removes symbols from all subscriptions, returns actually removed symbols
+// UnsubscribeSymbols removes symbols from kline (all intervals) and depth subscriptions, returns actually removed symbols
 func (sm *SubscriptionManagerImpl) UnsubscribeSymbols(symbols []string) []string {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -72,13 +86,31 @@ func (sm *SubscriptionManagerImpl) GetSubscribedSymbols() []string {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	symbols := make([]string, 0, len(sm.klineSymbols)+len(sm.depthSymbols))
+	symbols := make([]string, 0, len(sm.klineSymbols)+len(sm.depthSymbols)+len(sm.tradeSymbols)+len(sm.bookTickerSymbols)+len(sm.tickerSymbols)+len(sm.fundingSymbols)+len(sm.openInterestSymbols)+len(sm.liquidationSymbols))
 	for symbol := range sm.klineSymbols {
 		symbols = append(symbols, symbol)
 	}
 	for symbol := range sm.depthSymbols {
 		symbols = append(symbols, symbol)
 	}
+	for symbol := range sm.tradeSymbols {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range sm.bookTickerSymbols {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range sm.tickerSymbols {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range sm.fundingSymbols {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range sm.openInterestSymbols {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range sm.liquidationSymbols {
+		symbols = append(symbols, symbol)
+	}
 	return symbols
 }
 
@@ -87,26 +119,95 @@ func (sm *SubscriptionManagerImpl) ClearAll() {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	sm.klineSymbols = make(map[string]struct{})
+	sm.klineSymbols = make(map[string]map[schema.Interval]struct{})
 	sm.depthSymbols = make(map[string]struct{})
-	sm.klineInterval = schema.Interval1m
+	sm.tradeSymbols = make(map[string]struct{})
+	sm.bookTickerSymbols = make(map[string]struct{})
+	sm.tickerSymbols = make(map[string]struct{})
+	sm.fundingSymbols = make(map[string]struct{})
+	sm.openInterestSymbols = make(map[string]struct{})
+	sm.liquidationSymbols = make(map[string]struct{})
 }
 
-// SubscribeKlineSymbols adds symbols to kline subscription only
-func (sm *SubscriptionManagerImpl) SubscribeKlineSymbols(symbols []string) []string {
+// SubscribeKlineSymbols adds symbols to kline subscription only, using schema.DefaultKlineInterval when no interval is given
+func (sm *SubscriptionManagerImpl) SubscribeKlineSymbols(symbols []string, intervals ...schema.Interval) []schema.KlineSubscription {
+	if len(intervals) == 0 {
+		intervals = []schema.Interval{schema.DefaultKlineInterval}
+	}
+
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	var newlyAdded []string
+	var newlyAdded []schema.KlineSubscription
 	for _, symbol := range symbols {
-		if _, exists := sm.klineSymbols[symbol]; !exists {
-			sm.klineSymbols[symbol] = struct{}{}
-			newlyAdded = append(newlyAdded, symbol)
+		for _, interval := range intervals {
+			if sm.addKline(symbol, interval) {
+				newlyAdded = append(newlyAdded, schema.KlineSubscription{Symbol: symbol, Interval: interval})
+			}
 		}
 	}
 	return newlyAdded
 }
 
+// UnsubscribeKlineSymbols removes the given intervals from kline subscription only, all intervals when none given
+func (sm *SubscriptionManagerImpl) UnsubscribeKlineSymbols(symbols []string, intervals ...schema.Interval) []schema.KlineSubscription {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var removed []schema.KlineSubscription
+	for _, symbol := range symbols {
+		subscribed, exists := sm.klineSymbols[symbol]
+		if !exists {
+			continue
+		}
+		targets := intervals
+		if len(targets) == 0 {
+			targets = make([]schema.Interval, 0, len(subscribed))
+			for interval := range subscribed {
+				targets = append(targets, interval)
+			}
+		}
+		for _, interval := range targets {
+			if _, ok := subscribed[interval]; ok {
+				delete(subscribed, interval)
+				removed = append(removed, schema.KlineSubscription{Symbol: symbol, Interval: interval})
+			}
+		}
+		if len(subscribed) == 0 {
+			delete(sm.klineSymbols, symbol)
+		}
+	}
+	return removed
+}
+
+// GetKlineSubscriptions returns all currently subscribed kline symbol/interval pairs
+func (sm *SubscriptionManagerImpl) GetKlineSubscriptions() []schema.KlineSubscription {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var subs []schema.KlineSubscription
+	for symbol, intervals := range sm.klineSymbols {
+		for interval := range intervals {
+			subs = append(subs, schema.KlineSubscription{Symbol: symbol, Interval: interval})
+		}
+	}
+	return subs
+}
+
+// addKline adds a single symbol/interval pair, caller must hold the lock
+func (sm *SubscriptionManagerImpl) addKline(symbol string, interval schema.Interval) bool {
+	intervals, exists := sm.klineSymbols[symbol]
+	if !exists {
+		intervals = make(map[schema.Interval]struct{})
+		sm.klineSymbols[symbol] = intervals
+	}
+	if _, ok := intervals[interval]; ok {
+		return false
+	}
+	intervals[interval] = struct{}{}
+	return true
+}
+
 // SubscribeDepthSymbols adds symbols to depth subscription only
 func (sm *SubscriptionManagerImpl) SubscribeDepthSymbols(symbols []string) []string {
 	sm.mu.Lock()
@@ -122,7 +223,7 @@ func (sm *SubscriptionManagerImpl) SubscribeDepthSymbols(symbols []string) []str
 	return newlyAdded
 }
 
-// GetKlineSymbols returns all currently subscribed kline symbols
+// GetKlineSymbols returns all symbols with at least one subscribed kline interval
 func (sm *SubscriptionManagerImpl) GetKlineSymbols() []string {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
@@ -145,3 +246,255 @@ func (sm *SubscriptionManagerImpl) GetDepthSymbols() []string {
 	}
 	return symbols
 }
+
+// SubscribeTradeSymbols adds symbols to trade subscription only
+func (sm *SubscriptionManagerImpl) SubscribeTradeSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var newlyAdded []string
+	for _, symbol := range symbols {
+		if _, exists := sm.tradeSymbols[symbol]; !exists {
+			sm.tradeSymbols[symbol] = struct{}{}
+			newlyAdded = append(newlyAdded, symbol)
+		}
+	}
+	return newlyAdded
+}
+
+// UnsubscribeTradeSymbols removes symbols from trade subscription only
+func (sm *SubscriptionManagerImpl) UnsubscribeTradeSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var actuallyRemoved []string
+	for _, symbol := range symbols {
+		if _, exists := sm.tradeSymbols[symbol]; exists {
+			delete(sm.tradeSymbols, symbol)
+			actuallyRemoved = append(actuallyRemoved, symbol)
+		}
+	}
+	return actuallyRemoved
+}
+
+// GetTradeSymbols returns all currently subscribed trade symbols
+func (sm *SubscriptionManagerImpl) GetTradeSymbols() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0, len(sm.tradeSymbols))
+	for symbol := range sm.tradeSymbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// SubscribeBookTickerSymbols adds symbols to book ticker subscription only
+func (sm *SubscriptionManagerImpl) SubscribeBookTickerSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var newlyAdded []string
+	for _, symbol := range symbols {
+		if _, exists := sm.bookTickerSymbols[symbol]; !exists {
+			sm.bookTickerSymbols[symbol] = struct{}{}
+			newlyAdded = append(newlyAdded, symbol)
+		}
+	}
+	return newlyAdded
+}
+
+// UnsubscribeBookTickerSymbols removes symbols from book ticker subscription only
+func (sm *SubscriptionManagerImpl) UnsubscribeBookTickerSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var actuallyRemoved []string
+	for _, symbol := range symbols {
+		if _, exists := sm.bookTickerSymbols[symbol]; exists {
+			delete(sm.bookTickerSymbols, symbol)
+			actuallyRemoved = append(actuallyRemoved, symbol)
+		}
+	}
+	return actuallyRemoved
+}
+
+// GetBookTickerSymbols returns all currently subscribed book ticker symbols
+func (sm *SubscriptionManagerImpl) GetBookTickerSymbols() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0, len(sm.bookTickerSymbols))
+	for symbol := range sm.bookTickerSymbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// SubscribeTickerSymbols adds symbols to 24h ticker subscription only
+func (sm *SubscriptionManagerImpl) SubscribeTickerSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var newlyAdded []string
+	for _, symbol := range symbols {
+		if _, exists := sm.tickerSymbols[symbol]; !exists {
+			sm.tickerSymbols[symbol] = struct{}{}
+			newlyAdded = append(newlyAdded, symbol)
+		}
+	}
+	return newlyAdded
+}
+
+// UnsubscribeTickerSymbols removes symbols from 24h ticker subscription only
+func (sm *SubscriptionManagerImpl) UnsubscribeTickerSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var actuallyRemoved []string
+	for _, symbol := range symbols {
+		if _, exists := sm.tickerSymbols[symbol]; exists {
+			delete(sm.tickerSymbols, symbol)
+			actuallyRemoved = append(actuallyRemoved, symbol)
+		}
+	}
+	return actuallyRemoved
+}
+
+// GetTickerSymbols returns all currently subscribed 24h ticker symbols
+func (sm *SubscriptionManagerImpl) GetTickerSymbols() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0, len(sm.tickerSymbols))
+	for symbol := range sm.tickerSymbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// SubscribeFundingSymbols adds symbols to funding subscription only
+func (sm *SubscriptionManagerImpl) SubscribeFundingSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var newlyAdded []string
+	for _, symbol := range symbols {
+		if _, exists := sm.fundingSymbols[symbol]; !exists {
+			sm.fundingSymbols[symbol] = struct{}{}
+			newlyAdded = append(newlyAdded, symbol)
+		}
+	}
+	return newlyAdded
+}
+
+// UnsubscribeFundingSymbols removes symbols from funding subscription only
+func (sm *SubscriptionManagerImpl) UnsubscribeFundingSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var actuallyRemoved []string
+	for _, symbol := range symbols {
+		if _, exists := sm.fundingSymbols[symbol]; exists {
+			delete(sm.fundingSymbols, symbol)
+			actuallyRemoved = append(actuallyRemoved, symbol)
+		}
+	}
+	return actuallyRemoved
+}
+
+// GetFundingSymbols returns all currently subscribed funding symbols
+func (sm *SubscriptionManagerImpl) GetFundingSymbols() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0, len(sm.fundingSymbols))
+	for symbol := range sm.fundingSymbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// SubscribeOpenInterestSymbols adds symbols to open interest subscription only
+func (sm *SubscriptionManagerImpl) SubscribeOpenInterestSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var newlyAdded []string
+	for _, symbol := range symbols {
+		if _, exists := sm.openInterestSymbols[symbol]; !exists {
+			sm.openInterestSymbols[symbol] = struct{}{}
+			newlyAdded = append(newlyAdded, symbol)
+		}
+	}
+	return newlyAdded
+}
+
+// UnsubscribeOpenInterestSymbols removes symbols from open interest subscription only
+func (sm *SubscriptionManagerImpl) UnsubscribeOpenInterestSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var actuallyRemoved []string
+	for _, symbol := range symbols {
+		if _, exists := sm.openInterestSymbols[symbol]; exists {
+			delete(sm.openInterestSymbols, symbol)
+			actuallyRemoved = append(actuallyRemoved, symbol)
+		}
+	}
+	return actuallyRemoved
+}
+
+// GetOpenInterestSymbols returns all currently subscribed open interest symbols
+func (sm *SubscriptionManagerImpl) GetOpenInterestSymbols() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0, len(sm.openInterestSymbols))
+	for symbol := range sm.openInterestSymbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// SubscribeLiquidationSymbols adds symbols to liquidation subscription only
+func (sm *SubscriptionManagerImpl) SubscribeLiquidationSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var newlyAdded []string
+	for _, symbol := range symbols {
+		if _, exists := sm.liquidationSymbols[symbol]; !exists {
+			sm.liquidationSymbols[symbol] = struct{}{}
+			newlyAdded = append(newlyAdded, symbol)
+		}
+	}
+	return newlyAdded
+}
+
+// UnsubscribeLiquidationSymbols removes symbols from liquidation subscription only
+func (sm *SubscriptionManagerImpl) UnsubscribeLiquidationSymbols(symbols []string) []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var actuallyRemoved []string
+	for _, symbol := range symbols {
+		if _, exists := sm.liquidationSymbols[symbol]; exists {
+			delete(sm.liquidationSymbols, symbol)
+			actuallyRemoved = append(actuallyRemoved, symbol)
+		}
+	}
+	return actuallyRemoved
+}
+
+// GetLiquidationSymbols returns all currently subscribed liquidation symbols
+func (sm *SubscriptionManagerImpl) GetLiquidationSymbols() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0, len(sm.liquidationSymbols))
+	for symbol := range sm.liquidationSymbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
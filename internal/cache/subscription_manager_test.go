@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestKlineSubscriptions_PerInterval(t *testing.T) {
+	sm := NewSubscriptionManager()
+
+	// 未指定周期时使用默认的1m
+	if added := sm.SubscribeKlineSymbols([]string{"BTCUSDT"}); len(added) != 1 || added[0].Interval != schema.DefaultKlineInterval {
+		t.Fatalf("unexpected default subscription: %+v", added)
+	}
+	added := sm.SubscribeKlineSymbols([]string{"BTCUSDT", "ETHUSDT"}, schema.Interval1m, schema.Interval1h)
+	if len(added) != 3 {
+		t.Fatalf("expected 3 new pairs, got %+v", added)
+	}
+	if n := len(sm.GetKlineSubscriptions()); n != 4 {
+		t.Fatalf("expected 4 subscriptions, got %d", n)
+	}
+
+	// 只退订指定周期，币对仍保留其它周期
+	if removed := sm.UnsubscribeKlineSymbols([]string{"BTCUSDT"}, schema.Interval1h); len(removed) != 1 {
+		t.Fatalf("unexpected removed: %+v", removed)
+	}
+	if n := len(sm.GetKlineSymbols()); n != 2 {
+		t.Fatalf("expected 2 kline symbols, got %d", n)
+	}
+
+	// 未指定周期时退订全部周期
+	if removed := sm.UnsubscribeKlineSymbols([]string{"ETHUSDT"}); len(removed) != 2 {
+		t.Fatalf("unexpected removed: %+v", removed)
+	}
+	if subs := sm.GetKlineSubscriptions(); len(subs) != 1 || subs[0].Symbol != "BTCUSDT" || subs[0].Interval != schema.Interval1m {
+		t.Fatalf("unexpected remaining subscriptions: %+v", subs)
+	}
+}
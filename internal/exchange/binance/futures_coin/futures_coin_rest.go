@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -19,17 +22,137 @@ const (
 	apiV1Kline                = "/dapi/v1/klines"
 	apiV1Depth                = "/dapi/v1/depth"
 	apiV1ExchangeInfo         = "/dapi/v1/exchangeInfo"
+	apiV1OpenInterest         = "/dapi/v1/openInterest"
+	apiV1PremiumIndex         = "/dapi/v1/premiumIndex"
+	apiOpenInterestHist       = "/futures/data/openInterestHist"
+	apiLongShortAccountRatio  = "/futures/data/globalLongShortAccountRatio"
 )
 
 // FuturesCoinREST implements RESTClient for Binance Coin-margined Futures.
 type FuturesCoinREST struct {
 	http *resty.Client
+
+	// 合约面值缓存，symbol -> contractSize（USD）
+	sizeMu        sync.RWMutex
+	contractSizes map[string]decimal.Decimal
 }
 
 func NewFuturesCoinREST() *FuturesCoinREST {
 	return &FuturesCoinREST{
-		http: resty.New().SetBaseURL(binanceFuturesCoinBaseURL),
+		http:          resty.New().SetBaseURL(binanceFuturesCoinBaseURL),
+		contractSizes: make(map[string]decimal.Decimal),
+	}
+}
+
+// cachedContractSize 只读取已缓存的合约面值，不发起请求
+func (f *FuturesCoinREST) cachedContractSize(symbol string) (decimal.Decimal, bool) {
+	f.sizeMu.RLock()
+	defer f.sizeMu.RUnlock()
+	v, ok := f.contractSizes[symbol]
+	return v, ok
+}
+
+// contractSize 获取合约面值（每张合约对应的 USD 数量），首次调用时从 exchangeInfo 加载全部合约
+func (f *FuturesCoinREST) contractSize(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	f.sizeMu.RLock()
+	v, ok := f.contractSizes[symbol]
+	f.sizeMu.RUnlock()
+	if ok {
+		return v, nil
+	}
+
+	var resp struct {
+		Symbols []struct {
+			Symbol       string          `json:"symbol"`
+			ContractSize decimal.Decimal `json:"contractSize"`
+		} `json:"symbols"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiV1ExchangeInfo)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if r.IsError() {
+		return decimal.Zero, errors.New(r.Status())
 	}
+
+	f.sizeMu.Lock()
+	defer f.sizeMu.Unlock()
+	for _, s := range resp.Symbols {
+		if s.ContractSize.IsPositive() {
+			f.contractSizes[s.Symbol] = s.ContractSize
+		}
+	}
+	v, ok = f.contractSizes[symbol]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("contract %s not found", symbol)
+	}
+	return v, nil
+}
+
+// GetTicker 获取单个合约的24小时滚动行情（dapi 带 symbol 参数时同样返回数组）
+func (f *FuturesCoinREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	tickers, err := f.tickers(ctx, symbol)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	for _, t := range tickers {
+		if t.Symbol == symbol {
+			return t, nil
+		}
+	}
+	return schema.Ticker{}, fmt.Errorf("ticker %s not found", symbol)
+}
+
+// GetTickers 获取全部合约的24小时滚动行情
+func (f *FuturesCoinREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	return f.tickers(ctx, "")
+}
+
+// tickers 请求24小时行情，volume 为张数，按合约面值换算出 USD 成交额，baseVolume 即基础币成交量
+func (f *FuturesCoinREST) tickers(ctx context.Context, symbol string) ([]schema.Ticker, error) {
+	var resp []schema.BinanceFuturesTickerResponse
+	req := f.http.R().SetContext(ctx).SetResult(&resp)
+	if symbol != "" {
+		req.SetQueryParam("symbol", symbol)
+	}
+	r, err := req.Get(apiV1Ticker24hr)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+
+	logger.Debug("Binance Futures Coin Ticker 原始响应: %s", string(r.Body()))
+
+	out := make([]schema.Ticker, 0, len(resp))
+	for _, t := range resp {
+		price, _ := decimal.NewFromString(t.LastPrice)
+		open, _ := decimal.NewFromString(t.OpenPrice)
+		high, _ := decimal.NewFromString(t.HighPrice)
+		low, _ := decimal.NewFromString(t.LowPrice)
+		contracts, _ := decimal.NewFromString(t.Volume)
+		baseVolume, _ := decimal.NewFromString(t.BaseVolume)
+
+		var quoteVolume decimal.Decimal
+		if cs, err := f.contractSize(ctx, t.Symbol); err == nil {
+			quoteVolume = contracts.Mul(cs)
+		}
+
+		out = append(out, schema.Ticker{
+			Exchange:  schema.BINANCE,
+			Market:    schema.FUTURESCOIN,
+			Symbol:    t.Symbol,
+			Price:     price,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Volume:    baseVolume,
+			QuoteVol:  quoteVolume,
+			Timestamp: time.UnixMilli(t.CloseTime),
+		})
+	}
+	return out, nil
 }
 
 func (f *FuturesCoinREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
@@ -78,6 +201,107 @@ func (f *FuturesCoinREST) GetDepth(ctx context.Context, symbol string, limit int
 	}, nil
 }
 
+// maxKlinesPerRequest Binance Futures Coin K线接口单次最多返回的条数
+const maxKlinesPerRequest = 1500
+
+// maxKlineWindow dapi K线接口 startTime 与 endTime 的最大跨度
+const maxKlineWindow = 200 * 24 * time.Hour
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按开盘时间由旧到新分页拉取，end 为零值时取到当前时间
+func (f *FuturesCoinREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	from := start
+	for !from.After(end) {
+		to := end
+		if windowEnd := from.Add(maxKlineWindow); windowEnd.Before(to) {
+			to = windowEnd
+		}
+		rows, err := f.klines(ctx, symbol, interval, from, to)
+		if err != nil {
+			return nil, err
+		}
+		page := convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+
+		// 本窗口已取完则进入下一个时间窗口，否则从最后一根的下一根继续
+		next := to.Add(time.Millisecond)
+		if len(page) >= maxKlinesPerRequest {
+			next = page[len(page)-1].OpenTime.Add(interval.Duration())
+		}
+		if !next.After(from) {
+			break
+		}
+		from = next
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// klines 拉取一页K线
+func (f *FuturesCoinREST) klines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) (schema.BinanceKlineResponse, error) {
+	var resp schema.BinanceKlineResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"symbol":    symbol,
+		"interval":  string(interval),
+		"startTime": strconv.FormatInt(start.UnixMilli(), 10),
+		"endTime":   strconv.FormatInt(end.UnixMilli(), 10),
+		"limit":     strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV1Kline)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	logger.Debug("Binance Futures Coin Kline 返回 %d 条", len(resp))
+	return resp, nil
+}
+
+// convertKlines 转换K线数组，与 WebSocket 推送一致，成交量为合约张数，成交额为基础币数量；收盘时间未到的K线标记为未完结
+func convertKlines(rows schema.BinanceKlineResponse, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		openTime, _ := row[0].Int64()
+		closeTime, _ := row[6].Int64()
+		tradeNum, _ := row[8].Int64()
+		open, _ := decimal.NewFromString(row[1].String())
+		high, _ := decimal.NewFromString(row[2].String())
+		low, _ := decimal.NewFromString(row[3].String())
+		close, _ := decimal.NewFromString(row[4].String())
+		volume, _ := decimal.NewFromString(row[5].String())
+		quoteVolume, _ := decimal.NewFromString(row[7].String())
+		out = append(out, schema.Kline{
+			Exchange:    schema.BINANCE,
+			Market:      schema.FUTURESCOIN,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    time.UnixMilli(openTime),
+			CloseTime:   time.UnixMilli(closeTime),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			TradeNum:    tradeNum,
+			IsFinal:     time.UnixMilli(closeTime).Before(now),
+		})
+	}
+	return out
+}
+
 func (f *FuturesCoinREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
 	var resp struct {
 		Timezone   string `json:"timezone"`
@@ -100,6 +324,9 @@ func (f *FuturesCoinREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 			Filters           []struct {
 				FilterType  string      `json:"filterType"`
 				MinQty      interface{} `json:"minQty,omitempty"`
+				MaxQty      interface{} `json:"maxQty,omitempty"`
+				StepSize    interface{} `json:"stepSize,omitempty"`
+				TickSize    interface{} `json:"tickSize,omitempty"`
 				MinNotional interface{} `json:"minNotional,omitempty"`
 			} `json:"filters"`
 		} `json:"symbols"`
@@ -120,18 +347,24 @@ func (f *FuturesCoinREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 	// 转换交易对信息
 	symbols := make([]schema.Symbol, 0, len(resp.Symbols))
 	for _, s := range resp.Symbols {
-		// 只缓存可交易的币对
-		if s.ContractStatus != "TRADING" {
+		// 已交割或已下架的合约不再缓存
+		status, ok := symbolStatus(s.ContractStatus)
+		if !ok {
 			continue
 		}
 
-		// 从filters中提取minQty和minNotional
-		var minQty, minNotional string
+		// 从filters中提取数量、价格步长和minNotional
+		var minQty, maxQty, stepSize, tickSize, minNotional string
 		for _, filter := range s.Filters {
+			if filter.FilterType == "PRICE_FILTER" {
+				tickSize, _ = filter.TickSize.(string)
+			}
 			if filter.FilterType == "LOT_SIZE" {
 				if minQtyVal, ok := filter.MinQty.(string); ok {
 					minQty = minQtyVal
 				}
+				maxQty, _ = filter.MaxQty.(string)
+				stepSize, _ = filter.StepSize.(string)
 			}
 			if filter.FilterType == "MIN_NOTIONAL" {
 				if minNotionalVal, ok := filter.MinNotional.(string); ok {
@@ -155,6 +388,10 @@ func (f *FuturesCoinREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 			PricePrecision:    s.PricePrecision,
 			MinQuantity:       minQty,
 			MinNotional:       minNotional,
+			MaxQuantity:       maxQty,
+			TickSize:          tickSize,
+			StepSize:          stepSize,
+			Status:            status,
 		})
 	}
 
@@ -179,3 +416,159 @@ func (f *FuturesCoinREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 		Timezone:   resp.Timezone,
 	}, nil
 }
+
+// symbolStatus 归一化合约状态，已交割（DELIVERED）和已下架（CLOSE）的合约返回 false
+func symbolStatus(status string) (schema.SymbolStatus, bool) {
+	switch status {
+	case "TRADING":
+		return schema.SymbolStatusTrading, true
+	case "PENDING_TRADING":
+		return schema.SymbolStatusPreTrading, true
+	case "DELIVERED", "CLOSE":
+		return "", false
+	default:
+		return schema.SymbolStatusHalt, true
+	}
+}
+
+// GetOpenInterest 获取合约当前持仓量，openInterest 为合约张数，按面值得到USD价值，再按标记价格换算为基础币数量
+func (f *FuturesCoinREST) GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error) {
+	var resp struct {
+		Symbol       string `json:"symbol"`
+		OpenInterest string `json:"openInterest"`
+		Time         int64  `json:"time"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetQueryParam("symbol", symbol).SetResult(&resp).Get(apiV1OpenInterest)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	if r.IsError() {
+		return schema.OpenInterest{}, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	cs, err := f.contractSize(ctx, symbol)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	markPrice, err := f.markPrice(ctx, symbol)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+
+	contracts, _ := decimal.NewFromString(resp.OpenInterest)
+	value := contracts.Mul(cs)
+	openInterest := decimal.Zero
+	if markPrice.IsPositive() {
+		openInterest = value.Div(markPrice)
+	}
+	return schema.OpenInterest{
+		Exchange:     schema.BINANCE,
+		Market:       schema.FUTURESCOIN,
+		Symbol:       resp.Symbol,
+		OpenInterest: openInterest,
+		Value:        value,
+		Timestamp:    time.UnixMilli(resp.Time),
+	}, nil
+}
+
+// markPrice 获取合约标记价格
+func (f *FuturesCoinREST) markPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	var resp []struct {
+		Symbol    string `json:"symbol"`
+		MarkPrice string `json:"markPrice"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetQueryParam("symbol", symbol).SetResult(&resp).Get(apiV1PremiumIndex)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if r.IsError() {
+		return decimal.Zero, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+	if len(resp) == 0 {
+		return decimal.Zero, fmt.Errorf("no mark price for %s", symbol)
+	}
+	return decimal.NewFromString(resp[0].MarkPrice)
+}
+
+// GetOpenInterestHistory 获取永续合约持仓量历史，sumOpenInterest 为合约张数，sumOpenInterestValue 为基础币数量
+func (f *FuturesCoinREST) GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	cs, err := f.contractSize(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []struct {
+		Pair                 string `json:"pair"`
+		SumOpenInterest      string `json:"sumOpenInterest"`
+		SumOpenInterestValue string `json:"sumOpenInterestValue"`
+		Timestamp            int64  `json:"timestamp"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"pair":         pairOf(symbol),
+		"contractType": "PERPETUAL",
+		"period":       string(period),
+		"limit":        fmt.Sprintf("%d", limit),
+	}).Get(apiOpenInterestHist)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	out := make([]schema.OpenInterest, 0, len(resp))
+	for _, row := range resp {
+		contracts, _ := decimal.NewFromString(row.SumOpenInterest)
+		openInterest, _ := decimal.NewFromString(row.SumOpenInterestValue)
+		out = append(out, schema.OpenInterest{
+			Exchange:     schema.BINANCE,
+			Market:       schema.FUTURESCOIN,
+			Symbol:       symbol,
+			OpenInterest: openInterest,
+			Value:        contracts.Mul(cs),
+			Timestamp:    time.UnixMilli(row.Timestamp),
+		})
+	}
+	return out, nil
+}
+
+// GetLongShortRatio 获取全市场多空账户比历史，币本位按标的交易对查询
+func (f *FuturesCoinREST) GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	var resp []schema.BinanceLongShortRatioResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"pair":   pairOf(symbol),
+		"period": string(period),
+		"limit":  fmt.Sprintf("%d", limit),
+	}).Get(apiLongShortAccountRatio)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	out := make([]schema.LongShortRatio, 0, len(resp))
+	for _, row := range resp {
+		ratio, _ := decimal.NewFromString(row.LongShortRatio)
+		longAccount, _ := decimal.NewFromString(row.LongAccount)
+		shortAccount, _ := decimal.NewFromString(row.ShortAccount)
+		out = append(out, schema.LongShortRatio{
+			Exchange:       schema.BINANCE,
+			Market:         schema.FUTURESCOIN,
+			Symbol:         symbol,
+			LongShortRatio: ratio,
+			LongAccount:    longAccount,
+			ShortAccount:   shortAccount,
+			Timestamp:      time.UnixMilli(row.Timestamp),
+		})
+	}
+	return out, nil
+}
+
+// pairOf 合约对应的标的交易对，BTCUSD_PERP -> BTCUSD
+func pairOf(symbol string) string {
+	if i := strings.Index(symbol, "_"); i > 0 {
+		return symbol[:i]
+	}
+	return symbol
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -19,6 +20,9 @@ const (
 	apiV1Kline                = "/fapi/v1/klines"
 	apiV1Depth                = "/fapi/v1/depth"
 	apiV1ExchangeInfo         = "/fapi/v1/exchangeInfo"
+	apiV1OpenInterest         = "/fapi/v1/openInterest"
+	apiOpenInterestHist       = "/futures/data/openInterestHist"
+	apiLongShortAccountRatio  = "/futures/data/globalLongShortAccountRatio"
 )
 
 // FuturesUSDTREST implements RESTClient for Binance USDT-margined Futures.
@@ -32,6 +36,61 @@ func NewFuturesUSDTREST() *FuturesUSDTREST {
 	}
 }
 
+// GetTicker 获取单个合约的24小时滚动行情
+func (f *FuturesUSDTREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	var resp schema.BinanceFuturesTickerResponse
+	r, err := f.http.R().SetContext(ctx).SetQueryParam("symbol", symbol).SetResult(&resp).Get(apiV1Ticker24hr)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	if r.IsError() {
+		return schema.Ticker{}, errors.New(r.Status())
+	}
+
+	logger.Debug("Binance Futures USDT Ticker 原始响应: %s", string(r.Body()))
+	return convertTicker(resp), nil
+}
+
+// GetTickers 获取全部合约的24小时滚动行情
+func (f *FuturesUSDTREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	var resp []schema.BinanceFuturesTickerResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiV1Ticker24hr)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+
+	out := make([]schema.Ticker, 0, len(resp))
+	for _, t := range resp {
+		out = append(out, convertTicker(t))
+	}
+	return out, nil
+}
+
+func convertTicker(t schema.BinanceFuturesTickerResponse) schema.Ticker {
+	price, _ := decimal.NewFromString(t.LastPrice)
+	open, _ := decimal.NewFromString(t.OpenPrice)
+	high, _ := decimal.NewFromString(t.HighPrice)
+	low, _ := decimal.NewFromString(t.LowPrice)
+	volume, _ := decimal.NewFromString(t.Volume)
+	quoteVolume, _ := decimal.NewFromString(t.QuoteVolume)
+
+	return schema.Ticker{
+		Exchange:  schema.BINANCE,
+		Market:    schema.FUTURESUSDT,
+		Symbol:    t.Symbol,
+		Price:     price,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Volume:    volume,
+		QuoteVol:  quoteVolume,
+		Timestamp: time.UnixMilli(t.CloseTime),
+	}
+}
+
 func (f *FuturesUSDTREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
 	var resp struct {
 		LastUpdateID int64      `json:"lastUpdateId"`
@@ -75,6 +134,100 @@ func (f *FuturesUSDTREST) GetDepth(ctx context.Context, symbol string, limit int
 	}, nil
 }
 
+// maxKlinesPerRequest Binance Futures USDT K线接口单次最多返回的条数
+const maxKlinesPerRequest = 1500
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按开盘时间由旧到新分页拉取，end 为零值时取到当前时间
+func (f *FuturesUSDTREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	from := start
+	for !from.After(end) {
+		rows, err := f.klines(ctx, symbol, interval, from, end)
+		if err != nil {
+			return nil, err
+		}
+		page := convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+		if len(page) < maxKlinesPerRequest {
+			break
+		}
+
+		// 从最后一根的下一根继续拉取
+		next := page[len(page)-1].OpenTime.Add(interval.Duration())
+		if !next.After(from) {
+			break
+		}
+		from = next
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// klines 拉取一页K线
+func (f *FuturesUSDTREST) klines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) (schema.BinanceKlineResponse, error) {
+	var resp schema.BinanceKlineResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"symbol":    symbol,
+		"interval":  string(interval),
+		"startTime": strconv.FormatInt(start.UnixMilli(), 10),
+		"endTime":   strconv.FormatInt(end.UnixMilli(), 10),
+		"limit":     strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV1Kline)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	logger.Debug("Binance Futures USDT Kline 返回 %d 条", len(resp))
+	return resp, nil
+}
+
+// convertKlines 转换K线数组，成交量为基础币数量，成交额为USDT数量；收盘时间未到的K线标记为未完结
+func convertKlines(rows schema.BinanceKlineResponse, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		openTime, _ := row[0].Int64()
+		closeTime, _ := row[6].Int64()
+		tradeNum, _ := row[8].Int64()
+		open, _ := decimal.NewFromString(row[1].String())
+		high, _ := decimal.NewFromString(row[2].String())
+		low, _ := decimal.NewFromString(row[3].String())
+		close, _ := decimal.NewFromString(row[4].String())
+		volume, _ := decimal.NewFromString(row[5].String())
+		quoteVolume, _ := decimal.NewFromString(row[7].String())
+		out = append(out, schema.Kline{
+			Exchange:    schema.BINANCE,
+			Market:      schema.FUTURESUSDT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    time.UnixMilli(openTime),
+			CloseTime:   time.UnixMilli(closeTime),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			TradeNum:    tradeNum,
+			IsFinal:     time.UnixMilli(closeTime).Before(now),
+		})
+	}
+	return out
+}
+
 func (f *FuturesUSDTREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
 	var resp struct {
 		Timezone   string `json:"timezone"`
@@ -146,8 +299,9 @@ func (f *FuturesUSDTREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 	// 转换交易对信息
 	symbols := make([]schema.Symbol, 0, len(resp.Symbols))
 	for _, s := range resp.Symbols {
-		// 只处理状态为TRADING的交易对
-		if s.Status != "TRADING" {
+		// 已交割或已下架的合约不再保留
+		status, ok := symbolStatus(s.Status)
+		if !ok {
 			continue
 		}
 
@@ -162,14 +316,19 @@ func (f *FuturesUSDTREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 			// 精度信息
 			QuantityPrecision: s.QuantityPrecision,
 			PricePrecision:    s.PricePrecision,
+
+			Status: status,
 		}
 
 		// 解析过滤器信息
 		for _, filter := range s.Filters {
 			switch filter.FilterType {
+			case "PRICE_FILTER":
+				symbol.TickSize = filter.TickSize
 			case "LOT_SIZE":
 				symbol.MinQuantity = filter.MinQty
 				symbol.MaxQuantity = filter.MaxQty
+				symbol.StepSize = filter.StepSize
 			case "MIN_NOTIONAL":
 				symbol.MinNotional = filter.MinNotional
 			case "NOTIONAL":
@@ -201,3 +360,111 @@ func (f *FuturesUSDTREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeI
 		Timezone:   resp.Timezone,
 	}, nil
 }
+
+// symbolStatus 归一化合约状态，已交割（DELIVERED）和已下架（CLOSE）的合约返回 false
+func symbolStatus(status string) (schema.SymbolStatus, bool) {
+	switch status {
+	case "TRADING":
+		return schema.SymbolStatusTrading, true
+	case "PENDING_TRADING":
+		return schema.SymbolStatusPreTrading, true
+	case "DELIVERED", "CLOSE":
+		return "", false
+	default:
+		return schema.SymbolStatusHalt, true
+	}
+}
+
+// GetOpenInterest 获取合约当前持仓量，openInterest 为基础币数量，接口不提供持仓价值
+func (f *FuturesUSDTREST) GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error) {
+	var resp struct {
+		Symbol       string `json:"symbol"`
+		OpenInterest string `json:"openInterest"`
+		Time         int64  `json:"time"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetQueryParam("symbol", symbol).SetResult(&resp).Get(apiV1OpenInterest)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	if r.IsError() {
+		return schema.OpenInterest{}, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	openInterest, _ := decimal.NewFromString(resp.OpenInterest)
+	return schema.OpenInterest{
+		Exchange:     schema.BINANCE,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       resp.Symbol,
+		OpenInterest: openInterest,
+		Timestamp:    time.UnixMilli(resp.Time),
+	}, nil
+}
+
+// GetOpenInterestHistory 获取合约持仓量历史，sumOpenInterest 为基础币数量，sumOpenInterestValue 为USDT价值
+func (f *FuturesUSDTREST) GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	var resp []struct {
+		Symbol               string `json:"symbol"`
+		SumOpenInterest      string `json:"sumOpenInterest"`
+		SumOpenInterestValue string `json:"sumOpenInterestValue"`
+		Timestamp            int64  `json:"timestamp"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"symbol": symbol,
+		"period": string(period),
+		"limit":  fmt.Sprintf("%d", limit),
+	}).Get(apiOpenInterestHist)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	out := make([]schema.OpenInterest, 0, len(resp))
+	for _, row := range resp {
+		openInterest, _ := decimal.NewFromString(row.SumOpenInterest)
+		value, _ := decimal.NewFromString(row.SumOpenInterestValue)
+		out = append(out, schema.OpenInterest{
+			Exchange:     schema.BINANCE,
+			Market:       schema.FUTURESUSDT,
+			Symbol:       symbol,
+			OpenInterest: openInterest,
+			Value:        value,
+			Timestamp:    time.UnixMilli(row.Timestamp),
+		})
+	}
+	return out, nil
+}
+
+// GetLongShortRatio 获取全市场多空账户比历史
+func (f *FuturesUSDTREST) GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	var resp []schema.BinanceLongShortRatioResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"symbol": symbol,
+		"period": string(period),
+		"limit":  fmt.Sprintf("%d", limit),
+	}).Get(apiLongShortAccountRatio)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	out := make([]schema.LongShortRatio, 0, len(resp))
+	for _, row := range resp {
+		ratio, _ := decimal.NewFromString(row.LongShortRatio)
+		longAccount, _ := decimal.NewFromString(row.LongAccount)
+		shortAccount, _ := decimal.NewFromString(row.ShortAccount)
+		out = append(out, schema.LongShortRatio{
+			Exchange:       schema.BINANCE,
+			Market:         schema.FUTURESUSDT,
+			Symbol:         symbol,
+			LongShortRatio: ratio,
+			LongAccount:    longAccount,
+			ShortAccount:   shortAccount,
+			Timestamp:      time.UnixMilli(row.Timestamp),
+		})
+	}
+	return out, nil
+}
@@ -134,3 +134,29 @@ func TestBinanceFuturesUSDTREST_ExchangeInfo(t *testing.T) {
 			rateLimit.IntervalNum, rateLimit.Limit)
 	}
 }
+
+// 跨度超过单次上限，验证分页、去重与升序
+func TestBinanceFuturesUSDTREST_Klines(t *testing.T) {
+	r := NewFuturesUSDTREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-2000 * time.Minute)
+	klines, err := r.GetKlines(ctx, "BTCUSDT", schema.Interval1m, start, end)
+	if err != nil {
+		t.Fatalf("klines error: %v", err)
+	}
+	if len(klines) < 1800 {
+		t.Fatalf("expected at least 1800 klines, got %d", len(klines))
+	}
+	for i, kl := range klines {
+		if kl.OpenTime.Before(start) || kl.OpenTime.After(end) {
+			t.Fatalf("kline %d out of range: %v", i, kl.OpenTime)
+		}
+		if i > 0 && !kl.OpenTime.After(klines[i-1].OpenTime) {
+			t.Fatalf("klines not ascending at %d: %v <= %v", i, kl.OpenTime, klines[i-1].OpenTime)
+		}
+	}
+	log.Printf("Binance USDT合约 REST Klines: %d 条, 首条=%v, 末条=%v", len(klines), klines[0].OpenTime, klines[len(klines)-1].OpenTime)
+}
@@ -25,14 +25,41 @@ import (
 const (
 	wsURL = "wss://fstream.binance.com/stream"
 
-	channelKline = "kline"       // @250ms
-	channelDepth = "depth@500ms" // 默认@250ms, 可选@100ms, @500ms (为什么不用@250? 因为盘口闪烁太频繁效果反而不好)
+	channelKline      = "kline" // @250ms
+	channelTrade      = "aggTrade"
+	channelBookTicker = "bookTicker"   // 实时推送最优挂单
+	channelTickerArr  = "!ticker@arr"  // 全市场24小时滚动行情，每秒推送一次，按已订阅币对过滤
+	channelMarkPrice  = "markPrice@1s" // 标记价格、指数价格与资金费率，每秒推送一次
+	channelForceOrder = "forceOrder"   // 强平订单，同一币对每秒最多推送一条
+	channelDepth      = "depth@500ms"  // 默认@250ms, 可选@100ms, @500ms (为什么不用@250? 因为盘口闪烁太频繁效果反而不好)
 
 	// 使用全局配置的健康检查间隔
 	pongWait  = 60 * time.Second
 	writeWait = 10 * time.Second
 )
 
+// klineIntervals 支持的K线周期，Binance 的周期写法与 schema.Interval 一致
+var klineIntervals = map[schema.Interval]struct{}{
+	schema.Interval1m:  {},
+	schema.Interval3m:  {},
+	schema.Interval5m:  {},
+	schema.Interval15m: {},
+	schema.Interval30m: {},
+	schema.Interval1h:  {},
+	schema.Interval4h:  {},
+	schema.Interval1d:  {},
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for binance", interval)
+		}
+	}
+	return nil
+}
+
 type binanceSubscriptionMessage struct {
 	Method string   `json:"method"`
 	Params []string `json:"params"`
@@ -51,6 +78,7 @@ type FuturesUSDTWS struct {
 	conn               *websocket.Conn
 	mu                 sync.RWMutex
 	cache              *cache.MemoryCache
+	gaps               *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
 	subs               interfaces.SubscriptionManager
 	rest               interfaces.RESTClient
 	orderBooks         map[string]*orderBook
@@ -63,10 +91,15 @@ type FuturesUSDTWS struct {
 	reconnectMu    sync.RWMutex
 }
 
-func NewFuturesUSDTWS(cache *cache.MemoryCache, subs interfaces.SubscriptionManager, rest interfaces.RESTClient) *FuturesUSDTWS {
+func NewFuturesUSDTWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest interfaces.RESTClient) *FuturesUSDTWS {
+	var fetch cache.KlineFetcher
+	if rest != nil {
+		fetch = rest.GetKlines
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	return &FuturesUSDTWS{
-		cache:      cache,
+		cache:      c,
+		gaps:       cache.NewKlineGapFiller(c, fetch),
 		subs:       subs,
 		rest:       rest,
 		orderBooks: make(map[string]*orderBook),
@@ -121,7 +154,11 @@ func (f *FuturesUSDTWS) Close() error {
 	return nil
 }
 
-func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string) error {
+func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
+	}
+
 	// Convert symbols to uppercase for consistency
 	upperSymbols := make([]string, len(symbols))
 	for i, symbol := range symbols {
@@ -129,13 +166,13 @@ func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string) er
 	}
 
 	// 只订阅K线频道
-	newlyAdded := f.subs.SubscribeKlineSymbols(upperSymbols)
+	newlyAdded := f.subs.SubscribeKlineSymbols(upperSymbols, intervals...)
 	if len(newlyAdded) == 0 {
 		logger.Info("Binance Futures USDT WS 所有币对都已订阅 kline，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("Binance Futures USDT WS 新增订阅 kline: %v (固定1m)", newlyAdded)
+	logger.Info("Binance Futures USDT WS 新增订阅 kline: %v", newlyAdded)
 
 	f.mu.RLock()
 	conn := f.conn
@@ -145,25 +182,27 @@ func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string) er
 		return nil
 	}
 
-	// 构建并发送 kline 订阅消息（固定1m）
-	subMsg := f.buildKlineSubscriptionMessage(newlyAdded, "1m")
+	// 构建并发送 kline 订阅消息
+	subMsg := f.buildKlineSubscriptionMessage("SUBSCRIBE", newlyAdded)
 	return f.SendMessage(ctx, subMsg)
 }
 
-func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
+func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
 	// Convert symbols to uppercase for consistency
 	upperSymbols := make([]string, len(symbols))
 	for i, symbol := range symbols {
 		upperSymbols[i] = strings.ToUpper(symbol)
 	}
 
-	actuallyRemoved := f.subs.UnsubscribeSymbols(upperSymbols)
+	// 只退订K线频道的指定周期，未指定周期时退订全部周期
+	actuallyRemoved := f.subs.UnsubscribeKlineSymbols(upperSymbols, intervals...)
 	if len(actuallyRemoved) == 0 {
-		logger.Info("Binance Futures USDT WS 所有币对都已退订 kline，跳过退订请求")
+		logger.Info("Binance Futures USDT WS 所有币对都未订阅 kline，跳过退订请求")
 		return nil
 	}
 
 	logger.Info("Binance Futures USDT WS 退订 kline: %v", actuallyRemoved)
+	f.gaps.Forget(actuallyRemoved)
 
 	f.mu.RLock()
 	conn := f.conn
@@ -172,7 +211,7 @@ func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string)
 		logger.Warn("Binance Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return f.resubscribe(ctx)
+	return f.SendMessage(ctx, f.buildKlineSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
 }
 
 func (f *FuturesUSDTWS) SubscribeDepth(ctx context.Context, symbols []string) error {
@@ -229,6 +268,276 @@ func (f *FuturesUSDTWS) UnsubscribeDepth(ctx context.Context, symbols []string)
 	return f.resubscribe(ctx)
 }
 
+func (f *FuturesUSDTWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := f.subs.SubscribeTradeSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 新增订阅 trades: %v", newlyAdded)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildTradeSubscriptionMessage("SUBSCRIBE", newlyAdded))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := f.subs.UnsubscribeTradeSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已退订 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 退订 trades: %v", actuallyRemoved)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildTradeSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
+}
+
+func (f *FuturesUSDTWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := f.subs.SubscribeBookTickerSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已订阅 bookTicker，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 新增订阅 bookTicker: %v", newlyAdded)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildBookTickerSubscriptionMessage("SUBSCRIBE", newlyAdded))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := f.subs.UnsubscribeBookTickerSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已退订 bookTicker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 退订 bookTicker: %v", actuallyRemoved)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildBookTickerSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
+}
+
+func (f *FuturesUSDTWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := f.subs.SubscribeTickerSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已订阅 ticker，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 新增订阅 ticker: %v", newlyAdded)
+
+	// 全市场行情流只需订阅一次，已有币对订阅时只更新过滤集合
+	if len(f.subs.GetTickerSymbols()) > len(newlyAdded) {
+		return nil
+	}
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildTickerSubscriptionMessage("SUBSCRIBE"))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := f.subs.UnsubscribeTickerSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都未订阅 ticker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 退订 ticker: %v", actuallyRemoved)
+
+	// 仍有币对订阅时保留全市场行情流
+	if len(f.subs.GetTickerSymbols()) > 0 {
+		return nil
+	}
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildTickerSubscriptionMessage("UNSUBSCRIBE"))
+}
+
+func (f *FuturesUSDTWS) SubscribeFunding(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := f.subs.SubscribeFundingSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已订阅 markPrice，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 新增订阅 markPrice: %v", newlyAdded)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildFundingSubscriptionMessage("SUBSCRIBE", newlyAdded))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeFunding(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := f.subs.UnsubscribeFundingSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已退订 markPrice，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 退订 markPrice: %v", actuallyRemoved)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildFundingSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
+}
+
+func (f *FuturesUSDTWS) SubscribeLiquidations(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := f.subs.SubscribeLiquidationSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已订阅 forceOrder，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 新增订阅 forceOrder: %v", newlyAdded)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildForceOrderSubscriptionMessage("SUBSCRIBE", newlyAdded))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeLiquidations(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := f.subs.UnsubscribeLiquidationSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance Futures USDT WS 所有币对都已退订 forceOrder，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance Futures USDT WS 退订 forceOrder: %v", actuallyRemoved)
+
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return f.SendMessage(ctx, f.buildForceOrderSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
+}
+
 func (f *FuturesUSDTWS) SendMessage(ctx context.Context, message interface{}) error {
 	f.mu.RLock()
 	conn := f.conn
@@ -285,17 +594,25 @@ func (f *FuturesUSDTWS) resubscribe(ctx context.Context) error {
 	return f.SendMessage(ctx, subMsg)
 }
 
+// buildTickerSubscriptionMessage builds !ticker@arr subscribe/unsubscribe message
+func (f *FuturesUSDTWS) buildTickerSubscriptionMessage(method string) *binanceSubscriptionMessage {
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: []string{channelTickerArr},
+		ID:     f.generateRandomID(),
+	}
+}
+
 // buildSubscriptionMessage builds unified subscription message
 func (f *FuturesUSDTWS) buildSubscriptionMessage() *binanceSubscriptionMessage {
 	var streams []string
 
-	// Get kline and depth symbols separately
-	klineSymbols := f.subs.GetKlineSymbols()
+	// Get kline and depth subscriptions separately
 	depthSymbols := f.subs.GetDepthSymbols()
 
-	// Add kline streams for kline symbols
-	for _, symbol := range klineSymbols {
-		streams = append(streams, fmt.Sprintf("%s@%s_1m", strings.ToLower(symbol), channelKline))
+	// Add kline streams for every subscribed symbol/interval pair
+	for _, sub := range f.subs.GetKlineSubscriptions() {
+		streams = append(streams, klineStream(sub))
 	}
 
 	// Add depth streams for depth symbols
@@ -303,6 +620,31 @@ func (f *FuturesUSDTWS) buildSubscriptionMessage() *binanceSubscriptionMessage {
 		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelDepth))
 	}
 
+	// Add aggTrade streams for trade symbols
+	for _, symbol := range f.subs.GetTradeSymbols() {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelTrade))
+	}
+
+	// Add bookTicker streams for book ticker symbols
+	for _, symbol := range f.subs.GetBookTickerSymbols() {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelBookTicker))
+	}
+
+	// Add markPrice streams for funding symbols
+	for _, symbol := range f.subs.GetFundingSymbols() {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelMarkPrice))
+	}
+
+	// Add forceOrder streams for liquidation symbols
+	for _, symbol := range f.subs.GetLiquidationSymbols() {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelForceOrder))
+	}
+
+	// 有币对订阅24小时行情时订阅全市场行情流
+	if len(f.subs.GetTickerSymbols()) > 0 {
+		streams = append(streams, channelTickerArr)
+	}
+
 	if len(streams) == 0 {
 		return nil
 	}
@@ -315,19 +657,24 @@ func (f *FuturesUSDTWS) buildSubscriptionMessage() *binanceSubscriptionMessage {
 }
 
 // buildKlineSubscriptionMessage builds kline subscription message
-func (f *FuturesUSDTWS) buildKlineSubscriptionMessage(symbols []string, interval schema.Interval) *binanceSubscriptionMessage {
+func (f *FuturesUSDTWS) buildKlineSubscriptionMessage(method string, subs []schema.KlineSubscription) *binanceSubscriptionMessage {
 	var streams []string
-	for _, symbol := range symbols {
-		streams = append(streams, fmt.Sprintf("%s@%s_%s", strings.ToLower(symbol), channelKline, interval))
+	for _, sub := range subs {
+		streams = append(streams, klineStream(sub))
 	}
 
 	return &binanceSubscriptionMessage{
-		Method: "SUBSCRIBE",
+		Method: method,
 		Params: streams,
 		ID:     f.generateRandomID(),
 	}
 }
 
+// klineStream builds kline stream name, e.g. btcusdt@kline_1h
+func klineStream(sub schema.KlineSubscription) string {
+	return fmt.Sprintf("%s@%s_%s", strings.ToLower(sub.Symbol), channelKline, sub.Interval)
+}
+
 // buildDepthSubscriptionMessage builds depth subscription message
 func (f *FuturesUSDTWS) buildDepthSubscriptionMessage(symbols []string) *binanceSubscriptionMessage {
 	var streams []string
@@ -342,6 +689,62 @@ func (f *FuturesUSDTWS) buildDepthSubscriptionMessage(symbols []string) *binance
 	}
 }
 
+// buildTradeSubscriptionMessage builds aggTrade subscribe/unsubscribe message
+func (f *FuturesUSDTWS) buildTradeSubscriptionMessage(method string, symbols []string) *binanceSubscriptionMessage {
+	var streams []string
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelTrade))
+	}
+
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: streams,
+		ID:     f.generateRandomID(),
+	}
+}
+
+// buildBookTickerSubscriptionMessage builds bookTicker subscribe/unsubscribe message
+func (f *FuturesUSDTWS) buildBookTickerSubscriptionMessage(method string, symbols []string) *binanceSubscriptionMessage {
+	var streams []string
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelBookTicker))
+	}
+
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: streams,
+		ID:     f.generateRandomID(),
+	}
+}
+
+// buildFundingSubscriptionMessage builds markPrice subscribe/unsubscribe message
+func (f *FuturesUSDTWS) buildFundingSubscriptionMessage(method string, symbols []string) *binanceSubscriptionMessage {
+	var streams []string
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelMarkPrice))
+	}
+
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: streams,
+		ID:     f.generateRandomID(),
+	}
+}
+
+// buildForceOrderSubscriptionMessage builds forceOrder subscribe/unsubscribe message
+func (f *FuturesUSDTWS) buildForceOrderSubscriptionMessage(method string, symbols []string) *binanceSubscriptionMessage {
+	var streams []string
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelForceOrder))
+	}
+
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: streams,
+		ID:     f.generateRandomID(),
+	}
+}
+
 func (f *FuturesUSDTWS) generateRandomID() int64 {
 	b := make([]byte, 8)
 	rand.Read(b)
@@ -381,6 +784,17 @@ func (f *FuturesUSDTWS) handleRawMessage(message []byte) {
 }
 
 func (f *FuturesUSDTWS) handleStreamData(stream string, data interface{}) {
+	// 全市场行情流名称不含币对，推送数据为数组
+	if stream == channelTickerArr {
+		dataBytes, err := json.Marshal(data)
+		if err != nil {
+			logger.Error("Binance Futures USDT WS 序列化数据失败: %v", err)
+			return
+		}
+		f.handleTickers(dataBytes)
+		return
+	}
+
 	// Parse stream name to get symbol and channel
 	// 支持格式：btcusdt@depth@500ms, btcusdt@kline@1m
 	parts := strings.Split(stream, "@")
@@ -405,6 +819,14 @@ func (f *FuturesUSDTWS) handleStreamData(stream string, data interface{}) {
 		f.handleKline(symbol, dataBytes)
 	case strings.HasPrefix(channel, "depth"):
 		f.handleDepth(symbol, dataBytes)
+	case channel == channelTrade:
+		f.handleTrade(symbol, dataBytes)
+	case channel == channelBookTicker:
+		f.handleBookTicker(symbol, dataBytes)
+	case channel == channelMarkPrice:
+		f.handleMarkPrice(symbol, dataBytes)
+	case channel == channelForceOrder:
+		f.handleForceOrder(symbol, dataBytes)
 	default:
 		logger.Debug("Binance Futures USDT WS 未知频道: %s", channel)
 	}
@@ -494,7 +916,217 @@ func (f *FuturesUSDTWS) handleKline(symbol string, data json.RawMessage) {
 	}
 
 	// Store kline data to cache
-	f.cache.SetKline(k)
+	f.gaps.SetKline(k)
+}
+
+// handleTrade 处理归集成交，m 为 true 表示买方是挂单方，即主动卖出
+func (f *FuturesUSDTWS) handleTrade(symbol string, data json.RawMessage) {
+	var tradeData struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		S  string `json:"s"` // Symbol
+		A  int64  `json:"a"` // Aggregate trade ID
+		P  string `json:"p"` // Price
+		Q  string `json:"q"` // Quantity
+		T  int64  `json:"T"` // Trade time
+		M  bool   `json:"m"` // Is the buyer the market maker?
+	}
+	if err := json.Unmarshal(data, &tradeData); err != nil {
+		logger.Error("Binance Futures USDT WS 解析aggTrade失败: %v", err)
+		return
+	}
+
+	price, _ := decimal.NewFromString(tradeData.P)
+	quantity, _ := decimal.NewFromString(tradeData.Q)
+	side := schema.OrderSideBuy
+	if tradeData.M {
+		side = schema.OrderSideSell
+	}
+
+	f.cache.AddTrade(schema.Trade{
+		Exchange:  schema.BINANCE,
+		Market:    schema.FUTURESUSDT,
+		Symbol:    symbol,
+		TradeID:   strconv.FormatInt(tradeData.A, 10),
+		Side:      side,
+		Price:     price,
+		Quantity:  quantity,
+		QuoteQty:  price.Mul(quantity),
+		Timestamp: time.UnixMilli(tradeData.T),
+	})
+}
+
+// handleBookTicker 处理最优挂单推送，u 为订单簿更新ID
+func (f *FuturesUSDTWS) handleBookTicker(symbol string, data json.RawMessage) {
+	var bt struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		T  int64  `json:"T"` // Transaction time
+		U  int64  `json:"u"` // Order book updateId
+		S  string `json:"s"` // Symbol
+		B  string `json:"b"` // Best bid price
+		BQ string `json:"B"` // Best bid qty
+		A  string `json:"a"` // Best ask price
+		AQ string `json:"A"` // Best ask qty
+	}
+	if err := json.Unmarshal(data, &bt); err != nil {
+		logger.Error("Binance Futures USDT WS 解析bookTicker失败: %v", err)
+		return
+	}
+
+	bidPrice, _ := decimal.NewFromString(bt.B)
+	bidQty, _ := decimal.NewFromString(bt.BQ)
+	askPrice, _ := decimal.NewFromString(bt.A)
+	askQty, _ := decimal.NewFromString(bt.AQ)
+
+	f.cache.SetBookTicker(schema.BookTicker{
+		Exchange:     schema.BINANCE,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       symbol,
+		BidPrice:     bidPrice,
+		BidQty:       bidQty,
+		AskPrice:     askPrice,
+		AskQty:       askQty,
+		UpdatedAt:    time.UnixMilli(bt.T),
+		LastUpdateId: strconv.FormatInt(bt.U, 10),
+	})
+}
+
+// handleForceOrder 处理强平订单推送，S 为强平订单方向，ap 为成交均价，z 为累计成交量
+func (f *FuturesUSDTWS) handleForceOrder(symbol string, data json.RawMessage) {
+	var fo struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		O  struct {
+			S  string `json:"s"`  // Symbol
+			Sd string `json:"S"`  // Side
+			Q  string `json:"q"`  // Original quantity
+			P  string `json:"p"`  // Price
+			AP string `json:"ap"` // Average price
+			Z  string `json:"z"`  // Order filled accumulated quantity
+			T  int64  `json:"T"`  // Order trade time
+		} `json:"o"`
+	}
+	if err := json.Unmarshal(data, &fo); err != nil {
+		logger.Error("Binance Futures USDT WS 解析forceOrder失败: %v", err)
+		return
+	}
+
+	order := fo.O
+	price, _ := decimal.NewFromString(order.AP)
+	if price.IsZero() {
+		price, _ = decimal.NewFromString(order.P)
+	}
+	quantity, _ := decimal.NewFromString(order.Z)
+	if quantity.IsZero() {
+		quantity, _ = decimal.NewFromString(order.Q)
+	}
+	quoteQty := price.Mul(quantity)
+	side := schema.OrderSideBuy
+	if strings.EqualFold(order.Sd, "SELL") {
+		side = schema.OrderSideSell
+	}
+
+	f.cache.PublishLiquidation(schema.Liquidation{
+		Exchange:  schema.BINANCE,
+		Market:    schema.FUTURESUSDT,
+		Symbol:    symbol,
+		Side:      side,
+		Price:     price,
+		Quantity:  quantity,
+		QuoteQty:  quoteQty,
+		Timestamp: time.UnixMilli(order.T),
+	})
+}
+
+// handleMarkPrice 处理标记价格推送，包含指数价格、资金费率和下次资金费时间
+func (f *FuturesUSDTWS) handleMarkPrice(symbol string, data json.RawMessage) {
+	var mp struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		S  string `json:"s"` // Symbol
+		P  string `json:"p"` // Mark price
+		I  string `json:"i"` // Index price
+		R  string `json:"r"` // Funding rate
+		T  int64  `json:"T"` // Next funding time
+	}
+	if err := json.Unmarshal(data, &mp); err != nil {
+		logger.Error("Binance Futures USDT WS 解析markPrice失败: %v", err)
+		return
+	}
+
+	markPrice, _ := decimal.NewFromString(mp.P)
+	indexPrice, _ := decimal.NewFromString(mp.I)
+	fundingRate, _ := decimal.NewFromString(mp.R)
+
+	info := schema.FundingInfo{
+		Exchange:    schema.BINANCE,
+		Market:      schema.FUTURESUSDT,
+		Symbol:      symbol,
+		MarkPrice:   markPrice,
+		IndexPrice:  indexPrice,
+		FundingRate: fundingRate,
+		Timestamp:   time.UnixMilli(mp.Et),
+	}
+	if mp.T > 0 {
+		info.NextFundingTime = time.UnixMilli(mp.T)
+	}
+	f.cache.SetFunding(info)
+}
+
+// handleTickers 处理全市场24小时行情推送，只缓存已订阅的币对
+func (f *FuturesUSDTWS) handleTickers(data json.RawMessage) {
+	var tickers []struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		S  string `json:"s"` // Symbol
+		C  string `json:"c"` // Last price
+		CT int64  `json:"C"` // Statistics close time
+		O  string `json:"o"` // Open price
+		OT int64  `json:"O"` // Statistics open time
+		H  string `json:"h"` // High price
+		L  string `json:"l"` // Low price
+		LT int64  `json:"L"` // Last trade Id
+		V  string `json:"v"` // Total traded base asset volume
+		Q  string `json:"q"` // Total traded quote asset volume
+		LQ string `json:"Q"` // Last quantity
+	}
+	if err := json.Unmarshal(data, &tickers); err != nil {
+		logger.Error("Binance Futures USDT WS 解析ticker失败: %v", err)
+		return
+	}
+
+	subscribed := make(map[string]struct{})
+	for _, symbol := range f.subs.GetTickerSymbols() {
+		subscribed[symbol] = struct{}{}
+	}
+
+	for _, t := range tickers {
+		symbol := strings.ToUpper(t.S)
+		if _, ok := subscribed[symbol]; !ok {
+			continue
+		}
+
+		price, _ := decimal.NewFromString(t.C)
+		open, _ := decimal.NewFromString(t.O)
+		high, _ := decimal.NewFromString(t.H)
+		low, _ := decimal.NewFromString(t.L)
+		volume, _ := decimal.NewFromString(t.V)
+		quoteVolume, _ := decimal.NewFromString(t.Q)
+
+		f.cache.SetTicker(schema.Ticker{
+			Exchange:  schema.BINANCE,
+			Market:    schema.FUTURESUSDT,
+			Symbol:    symbol,
+			Price:     price,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Volume:    volume,
+			QuoteVol:  quoteVolume,
+			Timestamp: time.UnixMilli(t.Et),
+		})
+	}
 }
 
 func (f *FuturesUSDTWS) handleDepth(symbol string, data json.RawMessage) {
@@ -1126,6 +1758,8 @@ func (f *FuturesUSDTWS) reconnect() {
 	}
 
 	logger.Info("Binance Futures USDT WS 重连成功")
+	// 补齐断线期间缺失的K线
+	f.gaps.Backfill(f.ctx, f.subs.GetKlineSubscriptions())
 
 	// 重连成功，重置计数器
 	f.reconnectMu.Lock()
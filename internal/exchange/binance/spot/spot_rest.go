@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -17,6 +18,8 @@ const (
 	spotBaseURL = "https://api.binance.com"
 
 	// API endpoints
+	apiV3Ticker24hr   = "/api/v3/ticker/24hr"
+	apiV3Klines       = "/api/v3/klines"
 	apiV3Depth        = "/api/v3/depth"
 	apiV3ExchangeInfo = "/api/v3/exchangeInfo"
 )
@@ -31,6 +34,61 @@ func NewSpotREST() *SpotREST {
 	return &SpotREST{http: c}
 }
 
+// GetTicker 获取单个币对的24小时滚动行情
+func (s *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	var resp schema.BinanceTickerResponse
+	r, err := s.http.R().SetContext(ctx).SetQueryParam("symbol", symbol).SetResult(&resp).Get(apiV3Ticker24hr)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	if r.IsError() {
+		return schema.Ticker{}, errors.New(r.Status())
+	}
+
+	logger.Debug("Binance Spot Ticker 原始响应: %s", string(r.Body()))
+	return convertTicker(resp), nil
+}
+
+// GetTickers 获取全部币对的24小时滚动行情
+func (s *SpotREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	var resp []schema.BinanceTickerResponse
+	r, err := s.http.R().SetContext(ctx).SetResult(&resp).Get(apiV3Ticker24hr)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+
+	out := make([]schema.Ticker, 0, len(resp))
+	for _, t := range resp {
+		out = append(out, convertTicker(t))
+	}
+	return out, nil
+}
+
+func convertTicker(t schema.BinanceTickerResponse) schema.Ticker {
+	price, _ := decimal.NewFromString(t.LastPrice)
+	open, _ := decimal.NewFromString(t.OpenPrice)
+	high, _ := decimal.NewFromString(t.HighPrice)
+	low, _ := decimal.NewFromString(t.LowPrice)
+	volume, _ := decimal.NewFromString(t.Volume)
+	quoteVolume, _ := decimal.NewFromString(t.QuoteVolume)
+
+	return schema.Ticker{
+		Exchange:  schema.BINANCE,
+		Market:    schema.SPOT,
+		Symbol:    t.Symbol,
+		Price:     price,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Volume:    volume,
+		QuoteVol:  quoteVolume,
+		Timestamp: time.UnixMilli(t.CloseTime),
+	}
+}
+
 func (s *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
 	var resp struct {
 		LastUpdateID int64      `json:"lastUpdateId"`
@@ -79,6 +137,100 @@ func (s *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (sche
 	}, nil
 }
 
+// maxKlinesPerRequest Binance Spot K线接口单次最多返回的条数
+const maxKlinesPerRequest = 1000
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按开盘时间由旧到新分页拉取，end 为零值时取到当前时间
+func (s *SpotREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	from := start
+	for !from.After(end) {
+		rows, err := s.klines(ctx, symbol, interval, from, end)
+		if err != nil {
+			return nil, err
+		}
+		page := convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+		if len(page) < maxKlinesPerRequest {
+			break
+		}
+
+		// 从最后一根的下一根继续拉取
+		next := page[len(page)-1].OpenTime.Add(interval.Duration())
+		if !next.After(from) {
+			break
+		}
+		from = next
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// klines 拉取一页K线
+func (s *SpotREST) klines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) (schema.BinanceKlineResponse, error) {
+	var resp schema.BinanceKlineResponse
+	r, err := s.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"symbol":    symbol,
+		"interval":  string(interval),
+		"startTime": strconv.FormatInt(start.UnixMilli(), 10),
+		"endTime":   strconv.FormatInt(end.UnixMilli(), 10),
+		"limit":     strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV3Klines)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	logger.Debug("Binance Spot Kline 返回 %d 条", len(resp))
+	return resp, nil
+}
+
+// convertKlines 转换K线数组，成交量为基础币数量，成交额为计价币数量；收盘时间未到的K线标记为未完结
+func convertKlines(rows schema.BinanceKlineResponse, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		openTime, _ := row[0].Int64()
+		closeTime, _ := row[6].Int64()
+		tradeNum, _ := row[8].Int64()
+		open, _ := decimal.NewFromString(row[1].String())
+		high, _ := decimal.NewFromString(row[2].String())
+		low, _ := decimal.NewFromString(row[3].String())
+		close, _ := decimal.NewFromString(row[4].String())
+		volume, _ := decimal.NewFromString(row[5].String())
+		quoteVolume, _ := decimal.NewFromString(row[7].String())
+		out = append(out, schema.Kline{
+			Exchange:    schema.BINANCE,
+			Market:      schema.SPOT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    time.UnixMilli(openTime),
+			CloseTime:   time.UnixMilli(closeTime),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			TradeNum:    tradeNum,
+			IsFinal:     time.UnixMilli(closeTime).Before(now),
+		})
+	}
+	return out
+}
+
 func (s *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
 	var resp struct {
 		Timezone   string `json:"timezone"`
@@ -145,8 +297,8 @@ func (s *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, er
 	// 转换交易对信息
 	symbols := make([]schema.Symbol, 0, len(resp.Symbols))
 	for _, s := range resp.Symbols {
-		// 只处理现货交易且状态为TRADING的交易对
-		if s.Status != "TRADING" || !s.IsSpotTradingAllowed {
+		// 只处理现货交易对，非 TRADING 状态的交易对保留并标记状态
+		if !s.IsSpotTradingAllowed {
 			continue
 		}
 
@@ -161,14 +313,19 @@ func (s *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, er
 			// 初始化精度信息
 			QuantityPrecision: s.BaseAssetPrecision,
 			PricePrecision:    s.QuoteAssetPrecision,
+
+			Status: schema.SymbolStatus(s.Status), // Binance 现货状态与 SymbolStatus 取值一致
 		}
 
 		// 解析过滤器信息
 		for _, filter := range s.Filters {
 			switch filter.FilterType {
+			case "PRICE_FILTER":
+				symbol.TickSize = filter.TickSize
 			case "LOT_SIZE":
 				symbol.MinQuantity = filter.MinQty
 				symbol.MaxQuantity = filter.MaxQty
+				symbol.StepSize = filter.StepSize
 			case "MIN_NOTIONAL":
 				symbol.MinNotional = filter.MinNotional
 			case "NOTIONAL":
@@ -1,6 +1,7 @@
 package spot
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -28,14 +29,40 @@ const (
 	spotWSBase = "wss://stream.binance.com:9443/ws"
 
 	// WebSocket channel names
-	channelKline = "kline" //默认@1000ms, 支持@2000ms
-	channelDepth = "depth" // 默认@1000ms, 可选@100ms
+	channelKline      = "kline" //默认@1000ms, 支持@2000ms
+	channelDepth      = "depth" // 默认@1000ms, 可选@100ms
+	channelTrade      = "aggTrade"
+	channelBookTicker = "bookTicker"  // 实时推送最优挂单，现货推送不带事件类型字段
+	channelTickerArr  = "!ticker@arr" // 全市场24小时滚动行情，每秒推送一次，按已订阅币对过滤
 
 	// WebSocket event types
 	eventKline = "kline"
 	eventDepth = "depthUpdate"
+	eventTrade = "aggTrade"
 )
 
+// klineIntervals 支持的K线周期，Binance 的周期写法与 schema.Interval 一致
+var klineIntervals = map[schema.Interval]struct{}{
+	schema.Interval1m:  {},
+	schema.Interval3m:  {},
+	schema.Interval5m:  {},
+	schema.Interval15m: {},
+	schema.Interval30m: {},
+	schema.Interval1h:  {},
+	schema.Interval4h:  {},
+	schema.Interval1d:  {},
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for binance", interval)
+		}
+	}
+	return nil
+}
+
 // binanceSubscriptionMessage represents Binance WebSocket subscription message
 type binanceSubscriptionMessage struct {
 	Method string   `json:"method"`
@@ -48,6 +75,7 @@ type SpotWS struct {
 	conn   interfaces.WSConn
 	mu     sync.RWMutex
 	cache  *cache.MemoryCache
+	gaps   *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
 
 	// rest client for snapshots
 	rest interfaces.RESTClient
@@ -80,6 +108,10 @@ type orderBook struct {
 }
 
 func NewSpotWS(c *cache.MemoryCache, rest interfaces.RESTClient) *SpotWS {
+	var fetch cache.KlineFetcher
+	if rest != nil {
+		fetch = rest.GetKlines
+	}
 	d := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
 		HandshakeTimeout: 10 * time.Second,
@@ -88,6 +120,7 @@ func NewSpotWS(c *cache.MemoryCache, rest interfaces.RESTClient) *SpotWS {
 	ws := &SpotWS{
 		dialer:      d,
 		cache:       c,
+		gaps:        cache.NewKlineGapFiller(c, fetch),
 		subs:        cache.NewSubscriptionManager(),
 		rest:        rest,
 		orderBooks:  make(map[string]*orderBook),
@@ -180,7 +213,11 @@ func (s *SpotWS) SendMessage(ctx context.Context, message interface{}) error {
 	return nil
 }
 
-func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
+func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
+	}
+
 	// Convert symbols to uppercase for consistency
 	upperSymbols := make([]string, len(symbols))
 	for i, symbol := range symbols {
@@ -188,13 +225,13 @@ func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
 	}
 
 	// 只订阅K线频道
-	newlyAdded := s.subs.SubscribeKlineSymbols(upperSymbols)
+	newlyAdded := s.subs.SubscribeKlineSymbols(upperSymbols, intervals...)
 	if len(newlyAdded) == 0 {
 		logger.Info("Binance WS 所有币对都已订阅 kline，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("Binance WS 新增订阅 kline: %v (固定1m)", newlyAdded)
+	logger.Info("Binance WS 新增订阅 kline: %v", newlyAdded)
 
 	s.mu.RLock()
 	conn := s.conn
@@ -204,26 +241,27 @@ func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
 		return nil
 	}
 
-	// 构建并发送 kline 订阅消息（固定1m）
-	subMsg := s.buildKlineSubscriptionMessage(newlyAdded, "1m")
+	// 构建并发送 kline 订阅消息
+	subMsg := s.buildKlineSubscriptionMessage("SUBSCRIBE", newlyAdded)
 	return s.SendMessage(ctx, subMsg)
 }
 
-func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
+func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
 	// Convert symbols to uppercase for consistency
 	upperSymbols := make([]string, len(symbols))
 	for i, symbol := range symbols {
 		upperSymbols[i] = strings.ToUpper(symbol)
 	}
 
-	// 退订所有频道
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
+	// 只退订K线频道的指定周期，未指定周期时退订全部周期
+	actuallyRemoved := s.subs.UnsubscribeKlineSymbols(upperSymbols, intervals...)
 	if len(actuallyRemoved) == 0 {
 		logger.Info("Binance WS 所有币对都未订阅 kline，跳过退订请求")
 		return nil
 	}
 
 	logger.Info("Binance WS 退订 kline: %v", actuallyRemoved)
+	s.gaps.Forget(actuallyRemoved)
 
 	s.mu.RLock()
 	conn := s.conn
@@ -232,7 +270,7 @@ func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
 		logger.Warn("Binance WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.resubscribe(ctx)
+	return s.SendMessage(ctx, s.buildKlineSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
 }
 
 func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
@@ -290,6 +328,172 @@ func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
 	return s.resubscribe(ctx)
 }
 
+func (s *SpotWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := s.subs.SubscribeTradeSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance WS 新增订阅 trades: %v", newlyAdded)
+
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return s.SendMessage(ctx, s.buildTradeSubscriptionMessage("SUBSCRIBE", newlyAdded))
+}
+
+func (s *SpotWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := s.subs.UnsubscribeTradeSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance WS 退订 trades: %v", actuallyRemoved)
+
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return s.SendMessage(ctx, s.buildTradeSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
+}
+
+func (s *SpotWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := s.subs.SubscribeBookTickerSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance WS 所有币对都已订阅 bookTicker，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance WS 新增订阅 bookTicker: %v", newlyAdded)
+
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return s.SendMessage(ctx, s.buildBookTickerSubscriptionMessage("SUBSCRIBE", newlyAdded))
+}
+
+func (s *SpotWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := s.subs.UnsubscribeBookTickerSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance WS 所有币对都未订阅 bookTicker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance WS 退订 bookTicker: %v", actuallyRemoved)
+
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return s.SendMessage(ctx, s.buildBookTickerSubscriptionMessage("UNSUBSCRIBE", actuallyRemoved))
+}
+
+func (s *SpotWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	newlyAdded := s.subs.SubscribeTickerSymbols(upperSymbols)
+	if len(newlyAdded) == 0 {
+		logger.Info("Binance WS 所有币对都已订阅 ticker，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Binance WS 新增订阅 ticker: %v", newlyAdded)
+
+	// 全市场行情流只需订阅一次，已有币对订阅时只更新过滤集合
+	if len(s.subs.GetTickerSymbols()) > len(newlyAdded) {
+		return nil
+	}
+
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return s.SendMessage(ctx, s.buildTickerSubscriptionMessage("SUBSCRIBE"))
+}
+
+func (s *SpotWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	// Convert symbols to uppercase for consistency
+	upperSymbols := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		upperSymbols[i] = strings.ToUpper(symbol)
+	}
+
+	actuallyRemoved := s.subs.UnsubscribeTickerSymbols(upperSymbols)
+	if len(actuallyRemoved) == 0 {
+		logger.Info("Binance WS 所有币对都未订阅 ticker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Binance WS 退订 ticker: %v", actuallyRemoved)
+
+	// 仍有币对订阅时保留全市场行情流
+	if len(s.subs.GetTickerSymbols()) > 0 {
+		return nil
+	}
+
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		logger.Warn("Binance WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	return s.SendMessage(ctx, s.buildTickerSubscriptionMessage("UNSUBSCRIBE"))
+}
+
 // applySubscriptions sends subscription messages to the WebSocket server
 func (s *SpotWS) applySubscriptions(ctx context.Context) error {
 	// Build subscription message
@@ -304,18 +508,23 @@ func (s *SpotWS) applySubscriptions(ctx context.Context) error {
 }
 
 // buildKlineSubscriptionMessage builds kline subscription message
-func (s *SpotWS) buildKlineSubscriptionMessage(symbols []string, interval schema.Interval) *binanceSubscriptionMessage {
+func (s *SpotWS) buildKlineSubscriptionMessage(method string, subs []schema.KlineSubscription) *binanceSubscriptionMessage {
 	var streams []string
-	for _, symbol := range symbols {
-		streams = append(streams, fmt.Sprintf("%s@%s_%s", strings.ToLower(symbol), channelKline, interval))
+	for _, sub := range subs {
+		streams = append(streams, klineStream(sub))
 	}
 
 	return &binanceSubscriptionMessage{
-		Method: "SUBSCRIBE",
+		Method: method,
 		Params: streams,
 	}
 }
 
+// klineStream builds kline stream name, e.g. btcusdt@kline_1h
+func klineStream(sub schema.KlineSubscription) string {
+	return fmt.Sprintf("%s@%s_%s", strings.ToLower(sub.Symbol), channelKline, sub.Interval)
+}
+
 // buildDepthSubscriptionMessage builds depth subscription message
 func (s *SpotWS) buildDepthSubscriptionMessage(symbols []string) *binanceSubscriptionMessage {
 	var streams []string
@@ -329,16 +538,49 @@ func (s *SpotWS) buildDepthSubscriptionMessage(symbols []string) *binanceSubscri
 	}
 }
 
+// buildTradeSubscriptionMessage builds aggTrade subscribe/unsubscribe message
+func (s *SpotWS) buildTradeSubscriptionMessage(method string, symbols []string) *binanceSubscriptionMessage {
+	var streams []string
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelTrade))
+	}
+
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: streams,
+	}
+}
+
+// buildBookTickerSubscriptionMessage builds bookTicker subscribe/unsubscribe message
+func (s *SpotWS) buildBookTickerSubscriptionMessage(method string, symbols []string) *binanceSubscriptionMessage {
+	var streams []string
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelBookTicker))
+	}
+
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: streams,
+	}
+}
+
+// buildTickerSubscriptionMessage builds !ticker@arr subscribe/unsubscribe message
+func (s *SpotWS) buildTickerSubscriptionMessage(method string) *binanceSubscriptionMessage {
+	return &binanceSubscriptionMessage{
+		Method: method,
+		Params: []string{channelTickerArr},
+	}
+}
+
 func (s *SpotWS) buildSubscriptionMessage() *binanceSubscriptionMessage {
 	var streams []string
 
-	// Get kline and depth symbols separately
-	klineSymbols := s.subs.GetKlineSymbols()
+	// Get kline and depth subscriptions separately
 	depthSymbols := s.subs.GetDepthSymbols()
 
-	// Add kline streams for kline symbols
-	for _, symbol := range klineSymbols {
-		streams = append(streams, fmt.Sprintf("%s@%s_1m", strings.ToLower(symbol), channelKline))
+	// Add kline streams for every subscribed symbol/interval pair
+	for _, sub := range s.subs.GetKlineSubscriptions() {
+		streams = append(streams, klineStream(sub))
 	}
 
 	// Add depth streams for depth symbols
@@ -346,6 +588,21 @@ func (s *SpotWS) buildSubscriptionMessage() *binanceSubscriptionMessage {
 		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelDepth))
 	}
 
+	// Add aggTrade streams for trade symbols
+	for _, symbol := range s.subs.GetTradeSymbols() {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelTrade))
+	}
+
+	// Add bookTicker streams for book ticker symbols
+	for _, symbol := range s.subs.GetBookTickerSymbols() {
+		streams = append(streams, fmt.Sprintf("%s@%s", strings.ToLower(symbol), channelBookTicker))
+	}
+
+	// 有币对订阅24小时行情时订阅全市场行情流
+	if len(s.subs.GetTickerSymbols()) > 0 {
+		streams = append(streams, channelTickerArr)
+	}
+
 	if len(streams) == 0 {
 		return nil
 	}
@@ -409,6 +666,12 @@ func (s *SpotWS) StartReading(ctx context.Context) error {
 }
 
 func (s *SpotWS) handleMessage(data json.RawMessage) {
+	// 全市场行情流推送的是数组
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		s.handleTickers(data)
+		return
+	}
+
 	// 首先尝试解析为订阅确认消息
 	var subResponse struct {
 		Result interface{} `json:"result"`
@@ -421,8 +684,9 @@ func (s *SpotWS) handleMessage(data json.RawMessage) {
 			// 再次检查是否包含事件字段，避免误判
 			var eventCheck struct {
 				E string `json:"e"` // Event type
+				S string `json:"s"` // Symbol，bookTicker 推送没有事件类型但带有交易对
 			}
-			if json.Unmarshal(data, &eventCheck) == nil && eventCheck.E == "" {
+			if json.Unmarshal(data, &eventCheck) == nil && eventCheck.E == "" && eventCheck.S == "" {
 				logger.Info("Binance WS 收到订阅确认消息")
 				return
 			}
@@ -443,12 +707,20 @@ func (s *SpotWS) handleMessage(data json.RawMessage) {
 			s.handleKline(symbol, data)
 		case eventDepth:
 			s.handleDepth(symbol, data)
+		case eventTrade:
+			s.handleTrade(symbol, data)
 		default:
 			logger.Error("Binance WS 未知事件类型: %s", event.E)
 		}
 		return
 	}
 
+	// 现货 bookTicker 推送不带事件类型字段
+	if event.E == "" && event.S != "" {
+		s.handleBookTicker(strings.ToUpper(event.S), data)
+		return
+	}
+
 	logger.Error("Binance WS 无法解析消息: %s", string(data))
 }
 
@@ -544,7 +816,132 @@ func (s *SpotWS) handleKline(symbol string, data json.RawMessage) {
 		AdaptVolume: adaptVolume,
 	}
 
-	s.cache.SetKline(k)
+	s.gaps.SetKline(k)
+}
+
+// handleTrade 处理归集成交，m 为 true 表示买方是挂单方，即主动卖出
+func (s *SpotWS) handleTrade(symbol string, data json.RawMessage) {
+	var tradeData struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		S  string `json:"s"` // Symbol
+		A  int64  `json:"a"` // Aggregate trade ID
+		P  string `json:"p"` // Price
+		Q  string `json:"q"` // Quantity
+		T  int64  `json:"T"` // Trade time
+		M  bool   `json:"m"` // Is the buyer the market maker?
+	}
+	if err := json.Unmarshal(data, &tradeData); err != nil {
+		logger.Error("Binance WS 解析aggTrade失败: %v", err)
+		return
+	}
+
+	price, _ := decimal.NewFromString(tradeData.P)
+	quantity, _ := decimal.NewFromString(tradeData.Q)
+	side := schema.OrderSideBuy
+	if tradeData.M {
+		side = schema.OrderSideSell
+	}
+
+	s.cache.AddTrade(schema.Trade{
+		Exchange:  schema.BINANCE,
+		Market:    schema.SPOT,
+		Symbol:    symbol,
+		TradeID:   strconv.FormatInt(tradeData.A, 10),
+		Side:      side,
+		Price:     price,
+		Quantity:  quantity,
+		QuoteQty:  price.Mul(quantity),
+		Timestamp: time.UnixMilli(tradeData.T),
+	})
+}
+
+// handleBookTicker 处理最优挂单推送，u 为订单簿更新ID
+func (s *SpotWS) handleBookTicker(symbol string, data json.RawMessage) {
+	var bt struct {
+		U  int64  `json:"u"` // Order book updateId
+		S  string `json:"s"` // Symbol
+		B  string `json:"b"` // Best bid price
+		BQ string `json:"B"` // Best bid qty
+		A  string `json:"a"` // Best ask price
+		AQ string `json:"A"` // Best ask qty
+	}
+	if err := json.Unmarshal(data, &bt); err != nil {
+		logger.Error("Binance WS 解析bookTicker失败: %v", err)
+		return
+	}
+
+	bidPrice, _ := decimal.NewFromString(bt.B)
+	bidQty, _ := decimal.NewFromString(bt.BQ)
+	askPrice, _ := decimal.NewFromString(bt.A)
+	askQty, _ := decimal.NewFromString(bt.AQ)
+
+	s.cache.SetBookTicker(schema.BookTicker{
+		Exchange:     schema.BINANCE,
+		Market:       schema.SPOT,
+		Symbol:       symbol,
+		BidPrice:     bidPrice,
+		BidQty:       bidQty,
+		AskPrice:     askPrice,
+		AskQty:       askQty,
+		UpdatedAt:    time.Now(),
+		LastUpdateId: strconv.FormatInt(bt.U, 10),
+	})
+}
+
+// handleTickers 处理全市场24小时行情推送，只缓存已订阅的币对
+func (s *SpotWS) handleTickers(data json.RawMessage) {
+	var tickers []struct {
+		E  string `json:"e"` // Event type
+		Et int64  `json:"E"` // Event time
+		S  string `json:"s"` // Symbol
+		C  string `json:"c"` // Last price
+		CT int64  `json:"C"` // Statistics close time
+		O  string `json:"o"` // Open price
+		OT int64  `json:"O"` // Statistics open time
+		H  string `json:"h"` // High price
+		L  string `json:"l"` // Low price
+		LT int64  `json:"L"` // Last trade Id
+		V  string `json:"v"` // Total traded base asset volume
+		Q  string `json:"q"` // Total traded quote asset volume
+		LQ string `json:"Q"` // Last quantity
+	}
+	if err := json.Unmarshal(data, &tickers); err != nil {
+		logger.Error("Binance WS 解析ticker失败: %v", err)
+		return
+	}
+
+	subscribed := make(map[string]struct{})
+	for _, symbol := range s.subs.GetTickerSymbols() {
+		subscribed[symbol] = struct{}{}
+	}
+
+	for _, t := range tickers {
+		symbol := strings.ToUpper(t.S)
+		if _, ok := subscribed[symbol]; !ok {
+			continue
+		}
+
+		price, _ := decimal.NewFromString(t.C)
+		open, _ := decimal.NewFromString(t.O)
+		high, _ := decimal.NewFromString(t.H)
+		low, _ := decimal.NewFromString(t.L)
+		volume, _ := decimal.NewFromString(t.V)
+		quoteVolume, _ := decimal.NewFromString(t.Q)
+
+		s.cache.SetTicker(schema.Ticker{
+			Exchange:  schema.BINANCE,
+			Market:    schema.SPOT,
+			Symbol:    symbol,
+			Price:     price,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Volume:    volume,
+			QuoteVol:  quoteVolume,
+			Timestamp: time.UnixMilli(t.Et),
+		})
+	}
 }
 
 func (s *SpotWS) handleDepth(symbol string, data json.RawMessage) {
@@ -988,6 +1385,8 @@ func (s *SpotWS) attemptReconnect(ctx context.Context) {
 	}
 
 	logger.Info("Binance WS 重连成功")
+	// 补齐断线期间缺失的K线
+	s.gaps.Backfill(ctx, s.subs.GetKlineSubscriptions())
 
 	// 重连成功，重置计数器
 	s.healthMu.Lock()
@@ -13,9 +13,11 @@ type FuturesCoinExchange struct {
 }
 
 func NewFuturesCoinExchange(c *cache.MemoryCache) *FuturesCoinExchange {
+	subs := cache.NewSubscriptionManager()
+	rest := NewFuturesCoinREST()
 	return &FuturesCoinExchange{
-		rest: NewFuturesCoinREST(),
-		ws:   NewFuturesCoinWS(c),
+		rest: rest,
+		ws:   NewFuturesCoinWS(c, subs, rest),
 	}
 }
 
@@ -0,0 +1,67 @@
+//go:build integration
+
+package futures_coin
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestBybitFuturesCoinREST_Depth(t *testing.T) {
+	r := NewFuturesCoinREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	d, err := r.GetDepth(ctx, "BTCUSD", 5)
+	if err != nil {
+		t.Fatalf("depth error: %v", err)
+	}
+	if len(d.Bids) == 0 || len(d.Asks) == 0 {
+		t.Fatalf("empty orderbook")
+	}
+	log.Printf("Bybit Futures Coin REST Depth: Bids=%d, Asks=%d, 最佳买价=%v(%v BTC), 最佳卖价=%v(%v BTC)",
+		len(d.Bids), len(d.Asks), d.Bids[0].Price, d.Bids[0].Quantity, d.Asks[0].Price, d.Asks[0].Quantity)
+}
+
+func TestBybitFuturesCoinREST_ExchangeInfo(t *testing.T) {
+	r := NewFuturesCoinREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	info, err := r.GetExchangeInfo(ctx)
+	if err != nil {
+		t.Fatalf("exchange info error: %v", err)
+	}
+	if len(info.Symbols) == 0 {
+		t.Fatalf("no symbols")
+	}
+	log.Printf("Bybit Futures Coin ExchangeInfo: 合约数=%d, 示例=%+v", len(info.Symbols), info.Symbols[0])
+}
+
+// 跨度超过单次上限，验证分页、去重与升序
+func TestBybitFuturesCoinREST_Klines(t *testing.T) {
+	r := NewFuturesCoinREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-1500 * time.Minute)
+	klines, err := r.GetKlines(ctx, "BTCUSD", schema.Interval1m, start, end)
+	if err != nil {
+		t.Fatalf("klines error: %v", err)
+	}
+	if len(klines) < 1350 {
+		t.Fatalf("expected at least 1350 klines, got %d", len(klines))
+	}
+	for i, kl := range klines {
+		if kl.OpenTime.Before(start) || kl.OpenTime.After(end) {
+			t.Fatalf("kline %d out of range: %v", i, kl.OpenTime)
+		}
+		if i > 0 && !kl.OpenTime.After(klines[i-1].OpenTime) {
+			t.Fatalf("klines not ascending at %d: %v <= %v", i, kl.OpenTime, klines[i-1].OpenTime)
+		}
+	}
+	log.Printf("Bybit 币本位合约 REST Klines: %d 条, 首条=%v, 末条=%v", len(klines), klines[0].OpenTime, klines[len(klines)-1].OpenTime)
+}
@@ -5,11 +5,15 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
 
 	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
@@ -18,84 +22,490 @@ import (
 )
 
 const (
-	BybitFuturesCoinWSBase = "wss://stream.bybit.com/v5/public/linear"
+	BybitFuturesCoinWSBase = "wss://stream.bybit.com/v5/public/inverse"
+
+	topicKlinePrefix  = "kline." // kline.{interval}.{symbol}，周期见 klineIntervals
+	topicTradePrefix  = "publicTrade."
+	topicBBOPrefix    = "orderbook.1."   // 最优一档，每次推送均为快照
+	topicTickerPrefix = "tickers."       // 24小时行情，首次推送快照，之后只推送变化的字段；合约同时包含标记价格、指数价格和资金费率
+	topicDepthPrefix  = "orderbook.200." // 首次推送200档快照，之后100ms增量推送
+	// 强平订单，旧的 liquidation topic 每个币对每秒最多推送一条，allLiquidation 推送全部强平
+	topicLiquidationPrefix = "allLiquidation."
+
+	// Bybit 建议每20秒发送一次 {"op":"ping"} 保持连接
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 单条订阅消息的最大 topic 数
+	maxArgsPerRequest = 10
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
 )
 
+type bybitMessage struct {
+	Op   string   `json:"op"`
+	Args []string `json:"args,omitempty"`
+}
+
+// bybitTradeData publicTrade topic 推送数据
+type bybitTradeData struct {
+	Ts      int64  `json:"T"`
+	Symbol  string `json:"s"`
+	Side    string `json:"S"` // 主动成交方向 Buy/Sell
+	Size    string `json:"v"`
+	Price   string `json:"p"`
+	TradeId string `json:"i"`
+}
+
+// bybitLiquidationData 是 allLiquidation 频道单条推送数据
+type bybitLiquidationData struct {
+	Ts     int64  `json:"T"` // 更新时间，毫秒
+	Symbol string `json:"s"`
+	Side   string `json:"S"` // 被强平的仓位方向 Buy/Sell
+	Size   string `json:"v"` // 张数（USD）
+	Price  string `json:"p"` // 破产价格
+}
+
+// bybitBookData 是 orderbook 频道单条推送数据
+type bybitBookData struct {
+	Symbol string     `json:"s"`
+	Bids   [][]string `json:"b"` // [价格, 张数]
+	Asks   [][]string `json:"a"`
+	U      int64      `json:"u"`   // 更新ID，增量连续递增，u=1 表示服务重启后的快照
+	Seq    int64      `json:"seq"` // 跨序列号，单调递增
+}
+
+// klineIntervals schema.Interval 到 Bybit K线周期的映射，分钟数表示，日线为 D
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1",
+	schema.Interval3m:  "3",
+	schema.Interval5m:  "5",
+	schema.Interval15m: "15",
+	schema.Interval30m: "30",
+	schema.Interval1h:  "60",
+	schema.Interval4h:  "240",
+	schema.Interval1d:  "D",
+}
+
+type bybitKlineData struct {
+	Start     int64  `json:"start"`
+	End       int64  `json:"end"`
+	Interval  string `json:"interval"`
+	Open      string `json:"open"`
+	Close     string `json:"close"`
+	High      string `json:"high"`
+	Low       string `json:"low"`
+	Volume    string `json:"volume"`
+	Turnover  string `json:"turnover"`
+	Confirm   bool   `json:"confirm"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// orderBook 本地订单簿，数量单位为张（1张=1 USD）
+type orderBook struct {
+	updateId int64
+	seq      int64
+	bids     map[string]decimal.Decimal // price -> 张数（USD）
+	asks     map[string]decimal.Decimal // price -> 张数（USD）
+}
+
 // FuturesCoinWS implements WSConnector for Bybit Coin-margined Futures.
 type FuturesCoinWS struct {
-	dialer *websocket.Dialer
-	conn   interfaces.WSConn
-	mu     sync.RWMutex
-	cache  *cache.MemoryCache
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+	rest    *FuturesCoinREST
 
-	// subscription state
-	subs struct {
-		tickers map[string]struct{}
-		kline   map[string]schema.Interval
-		depth   map[string]struct{}
-	}
+	// per-symbol local order books
+	orderBooks map[string]*orderBook
 
-	// internal control
-	stopCh chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewFuturesCoinWS(c *cache.MemoryCache) *FuturesCoinWS {
+func NewFuturesCoinWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *FuturesCoinREST) *FuturesCoinWS {
 	d := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
 		HandshakeTimeout: 10 * time.Second,
 		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
 	}
-	ws := &FuturesCoinWS{dialer: d, cache: c}
-	ws.subs.tickers = make(map[string]struct{})
-	ws.subs.kline = make(map[string]schema.Interval)
-	ws.subs.depth = make(map[string]struct{})
-	ws.stopCh = make(chan struct{})
-	return ws
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FuturesCoinWS{
+		dialer:     d,
+		cache:      c,
+		gaps:       cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:       subs,
+		rest:       rest,
+		orderBooks: make(map[string]*orderBook),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
 }
 
 func (f *FuturesCoinWS) Connect(ctx context.Context) error {
-	// TODO: Implement Bybit futures coin WS connection
-	return errors.New("not implemented")
+	f.mu.Lock()
+	if f.conn != nil {
+		f.mu.Unlock()
+		logger.Info("Bybit Futures Coin WS 已连接，跳过连接")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 开始连接...")
+	conn, _, err := f.dialer.DialContext(ctx, BybitFuturesCoinWSBase, nil)
+	if err != nil {
+		f.mu.Unlock()
+		logger.Error("Bybit Futures Coin WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024)
+	f.conn = conn
+	f.mu.Unlock()
+
+	now := time.Now()
+	f.healthMu.Lock()
+	f.lastMessage = now
+	f.lastPing = now
+	f.healthMu.Unlock()
+
+	logger.Info("Bybit Futures Coin WS 连接成功")
+
+	// 重连时自动恢复订阅
+	_ = f.applySubscriptions(ctx)
+	return nil
 }
 
 func (f *FuturesCoinWS) Close() error {
-	// TODO: Implement Bybit futures coin WS close
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn != nil {
+		err := f.conn.Close()
+		f.conn = nil
+		return err
+	}
 	return nil
 }
 
-func (f *FuturesCoinWS) SubscribeTickers(ctx context.Context, symbols []string) error {
-	// TODO: Implement Bybit futures coin WS ticker subscription
-	return errors.New("not implemented")
-}
+func (f *FuturesCoinWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
+	}
 
-func (f *FuturesCoinWS) UnsubscribeTickers(ctx context.Context, symbols []string) error {
-	// TODO: Implement Bybit futures coin WS ticker unsubscription
-	return errors.New("not implemented")
-}
+	newlyAdded := f.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅 kline，跳过订阅请求")
+		return nil
+	}
 
-func (f *FuturesCoinWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// TODO: Implement Bybit futures coin WS kline subscription
-	return errors.New("not implemented")
+	logger.Info("Bybit Futures Coin WS 新增订阅 kline: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", buildKlineTopics(newlyAdded))
 }
 
-func (f *FuturesCoinWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// TODO: Implement Bybit futures coin WS kline unsubscription
-	return errors.New("not implemented")
+// UnsubscribeKline 只退订K线 topic 的指定周期，未指定周期时退订全部周期
+func (f *FuturesCoinWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅 kline，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订 kline: %v", removed)
+	f.gaps.Forget(removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", buildKlineTopics(removed))
 }
 
 func (f *FuturesCoinWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// TODO: Implement Bybit futures coin WS depth subscription
-	return errors.New("not implemented")
+	newlyAdded := f.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅 depth，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅 depth: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", buildTopics(topicDepthPrefix, newlyAdded))
 }
 
 func (f *FuturesCoinWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// TODO: Implement %!s(MISSING) futures coin WS depth unsubscription
-	return errors.New("not implemented")
+	return f.unsubscribe(ctx, symbols, "depth")
 }
 
-func (f *FuturesCoinWS) StartReading(ctx context.Context) error {
-	// TODO: Implement Bybit futures coin WS start
-	return errors.New("not implemented")
+func (f *FuturesCoinWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅 trades: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", buildTopics(topicTradePrefix, newlyAdded))
+}
+
+func (f *FuturesCoinWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订 trades: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", buildTopics(topicTradePrefix, removed))
+}
+
+func (f *FuturesCoinWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅 bbo，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅 bbo: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", buildTopics(topicBBOPrefix, newlyAdded))
+}
+
+func (f *FuturesCoinWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅 bbo，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订 bbo: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", buildTopics(topicBBOPrefix, removed))
+}
+
+func (f *FuturesCoinWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅 tickers，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅 tickers: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 已订阅资金费率或持仓量的币对共用 tickers topic，无需重复订阅
+	topics := buildTopics(topicTickerPrefix, excludeSymbols(newlyAdded, append(f.subs.GetFundingSymbols(), f.subs.GetOpenInterestSymbols()...)))
+	if len(topics) == 0 {
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", topics)
+}
+
+func (f *FuturesCoinWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅 tickers，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订 tickers: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅资金费率或持仓量的币对保留 tickers topic
+	topics := buildTopics(topicTickerPrefix, excludeSymbols(removed, append(f.subs.GetFundingSymbols(), f.subs.GetOpenInterestSymbols()...)))
+	if len(topics) == 0 {
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", topics)
+}
+
+func (f *FuturesCoinWS) SubscribeFunding(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeFundingSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅资金费率，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅资金费率: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 资金费率来自 tickers topic，已订阅行情或持仓量的币对无需重复订阅
+	topics := buildTopics(topicTickerPrefix, excludeSymbols(newlyAdded, append(f.subs.GetTickerSymbols(), f.subs.GetOpenInterestSymbols()...)))
+	if len(topics) == 0 {
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", topics)
+}
+
+func (f *FuturesCoinWS) UnsubscribeFunding(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeFundingSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅资金费率，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订资金费率: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅行情或持仓量的币对保留 tickers topic
+	topics := buildTopics(topicTickerPrefix, excludeSymbols(removed, append(f.subs.GetTickerSymbols(), f.subs.GetOpenInterestSymbols()...)))
+	if len(topics) == 0 {
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", topics)
+}
+
+func (f *FuturesCoinWS) SubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅持仓量，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅持仓量: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 持仓量来自 tickers topic，已订阅行情或资金费率的币对无需重复订阅
+	topics := buildTopics(topicTickerPrefix, excludeSymbols(newlyAdded, append(f.subs.GetTickerSymbols(), f.subs.GetFundingSymbols()...)))
+	if len(topics) == 0 {
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", topics)
+}
+
+func (f *FuturesCoinWS) UnsubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅持仓量，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订持仓量: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅行情或资金费率的币对保留 tickers topic
+	topics := buildTopics(topicTickerPrefix, excludeSymbols(removed, append(f.subs.GetTickerSymbols(), f.subs.GetFundingSymbols()...)))
+	if len(topics) == 0 {
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", topics)
+}
+
+func (f *FuturesCoinWS) SubscribeLiquidations(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeLiquidationSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都已订阅 allLiquidation，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 新增订阅 allLiquidation: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", buildTopics(topicLiquidationPrefix, newlyAdded))
+}
+
+func (f *FuturesCoinWS) UnsubscribeLiquidations(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeLiquidationSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅 allLiquidation，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订 allLiquidation: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTopics(ctx, "unsubscribe", buildTopics(topicLiquidationPrefix, removed))
+}
+
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 orderbook topic
+func (f *FuturesCoinWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(f.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("Bybit Futures Coin WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
+	}
+
+	logger.Info("Bybit Futures Coin WS 退订 %s: %v", kind, removed)
+
+	f.mu.Lock()
+	for _, symbol := range removed {
+		delete(f.orderBooks, symbol)
+	}
+	f.mu.Unlock()
+
+	if !f.isConnected() {
+		logger.Warn("Bybit Futures Coin WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	topics := append(buildKlineTopics(klines), buildTopics(topicDepthPrefix, removed)...)
+	return f.sendTopics(ctx, "unsubscribe", topics)
 }
 
 // SendMessage sends a message to WebSocket server
@@ -106,27 +516,693 @@ func (f *FuturesCoinWS) SendMessage(ctx context.Context, message interface{}) er
 	if conn == nil {
 		return errors.New("WebSocket connection not established")
 	}
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("Bybit Futures Coin WS SendMessage: %s", string(jsonData))
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("Bybit Futures Coin WS 序列化消息失败: %v", err)
+		return err
 	}
-	return conn.WriteJSON(message)
+
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		logger.Error("Bybit Futures Coin WS 发送消息失败: %v", err)
+		return err
+	}
+
+	logger.Info("Bybit Futures Coin WS SendMessage: %s", string(data))
+	return nil
 }
 
-// HandlePing 处理接收到的 ping（空实现）
-func (ws *FuturesCoinWS) HandlePing(data []byte) error {
-	// TODO: 实现 Bybit 币本位期货 的 ping 处理
+// sendTopics 按 maxArgsPerRequest 分批发送订阅/退订请求
+func (f *FuturesCoinWS) sendTopics(ctx context.Context, op string, topics []string) error {
+	for start := 0; start < len(topics); start += maxArgsPerRequest {
+		end := start + maxArgsPerRequest
+		if end > len(topics) {
+			end = len(topics)
+		}
+		if err := f.SendMessage(ctx, &bybitMessage{Op: op, Args: topics[start:end]}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// SendPing 主动发送 ping（空实现）
-func (ws *FuturesCoinWS) SendPing(ctx context.Context) error {
-	// TODO: 实现 Bybit 币本位期货 的主动 ping
+func (f *FuturesCoinWS) applySubscriptions(ctx context.Context) error {
+	topics := append(buildKlineTopics(f.subs.GetKlineSubscriptions()), buildTopics(topicDepthPrefix, f.subs.GetDepthSymbols())...)
+	topics = append(topics, buildTopics(topicTradePrefix, f.subs.GetTradeSymbols())...)
+	topics = append(topics, buildTopics(topicBBOPrefix, f.subs.GetBookTickerSymbols())...)
+	topics = append(topics, buildTopics(topicTickerPrefix, dedupe(append(append(f.subs.GetTickerSymbols(), f.subs.GetFundingSymbols()...), f.subs.GetOpenInterestSymbols()...)))...)
+	topics = append(topics, buildTopics(topicLiquidationPrefix, f.subs.GetLiquidationSymbols())...)
+	if len(topics) == 0 {
+		logger.Info("Bybit Futures Coin WS 无订阅")
+		return nil
+	}
+	return f.sendTopics(ctx, "subscribe", topics)
+}
+
+// resubscribeDepth 丢弃本地订单簿并重新订阅 orderbook topic，服务端会重新推送全量快照
+func (f *FuturesCoinWS) resubscribeDepth(symbol string) {
+	f.mu.Lock()
+	delete(f.orderBooks, symbol)
+	f.mu.Unlock()
+
+	topic := []string{topicDepthPrefix + symbol}
+	if err := f.sendTopics(f.ctx, "unsubscribe", topic); err != nil {
+		logger.Error("Bybit Futures Coin WS 退订深度失败 %s: %v", symbol, err)
+		return
+	}
+	if err := f.sendTopics(f.ctx, "subscribe", topic); err != nil {
+		logger.Error("Bybit Futures Coin WS 重新订阅深度失败 %s: %v", symbol, err)
+	}
+}
+
+// buildKlineTopics 按币对和周期构建K线 topic，如 kline.60.BTCUSDT
+func buildKlineTopics(subs []schema.KlineSubscription) []string {
+	topics := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		topics = append(topics, topicKlinePrefix+klineIntervals[sub.Interval]+"."+sub.Symbol)
+	}
+	return topics
+}
+
+// parseKlineTopic 由K线 topic 解析币对和周期
+func parseKlineTopic(topic string) (string, schema.Interval, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(topic, topicKlinePrefix), ".", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	for interval, v := range klineIntervals {
+		if v == parts[0] {
+			return parts[1], interval, true
+		}
+	}
+	return "", "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for bybit", interval)
+		}
+	}
 	return nil
 }
 
-// StartHealthCheck 启动连接健康监控（空实现）
-func (ws *FuturesCoinWS) StartHealthCheck(ctx context.Context) error {
-	// TODO: 实现 Bybit 币本位期货 的健康检查
+func buildTopics(prefix string, symbols []string) []string {
+	topics := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		topics = append(topics, prefix+symbol)
+	}
+	return topics
+}
+
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+// excludeSymbols 返回 symbols 中不在 exclude 里的币对
+func excludeSymbols(symbols, exclude []string) []string {
+	skip := make(map[string]struct{}, len(exclude))
+	for _, s := range exclude {
+		skip[s] = struct{}{}
+	}
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := skip[s]; !ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (f *FuturesCoinWS) isConnected() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
+func (f *FuturesCoinWS) StartReading(ctx context.Context) error {
+	logger.Info("Bybit Futures Coin WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Bybit Futures Coin WS 上下文取消")
+				return
+			case <-f.ctx.Done():
+				logger.Info("Bybit Futures Coin WS 已关闭")
+				return
+			default:
+			}
+
+			f.mu.RLock()
+			conn := f.conn
+			f.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if f.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("Bybit Futures Coin WS 读取消息失败: %v", err)
+				f.reconnect(ctx)
+				continue
+			}
+
+			f.healthMu.Lock()
+			f.lastMessage = time.Now()
+			f.healthMu.Unlock()
+
+			f.handleRawMessage(message)
+		}
+	}()
+
 	return nil
 }
+
+func (f *FuturesCoinWS) handleRawMessage(message []byte) {
+	logger.Debug("Bybit Futures Coin WS 收到原始消息: %s", string(message))
+
+	var msg struct {
+		// 操作响应
+		Op      string `json:"op"`
+		Success *bool  `json:"success"`
+		RetMsg  string `json:"ret_msg"`
+		// 数据推送
+		Topic string          `json:"topic"`
+		Type  string          `json:"type"`
+		Ts    int64           `json:"ts"`
+		Data  json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析原始消息失败: %v", err)
+		return
+	}
+
+	if msg.Op != "" {
+		switch {
+		case msg.Op == "ping" || msg.Op == "pong":
+			logger.Debug("Bybit Futures Coin WS 收到 pong")
+		case msg.Success != nil && !*msg.Success:
+			logger.Error("Bybit Futures Coin WS %s 失败: %s", msg.Op, msg.RetMsg)
+		default:
+			logger.Info("Bybit Futures Coin WS %s 成功", msg.Op)
+		}
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(msg.Topic, topicKlinePrefix):
+		symbol, interval, ok := parseKlineTopic(msg.Topic)
+		if !ok {
+			logger.Warn("Bybit Futures Coin WS 未知K线 topic: %s", msg.Topic)
+			return
+		}
+		f.handleKline(symbol, interval, msg.Data)
+	case strings.HasPrefix(msg.Topic, topicTradePrefix):
+		f.handleTrade(msg.Data)
+	case strings.HasPrefix(msg.Topic, topicBBOPrefix):
+		f.handleBBO(msg.Type, msg.Ts, msg.Data)
+	case strings.HasPrefix(msg.Topic, topicTickerPrefix):
+		f.handleTicker(msg.Type, msg.Ts, msg.Data)
+	case strings.HasPrefix(msg.Topic, topicLiquidationPrefix):
+		f.handleLiquidation(msg.Data)
+	case strings.HasPrefix(msg.Topic, topicDepthPrefix):
+		f.handleDepth(strings.TrimPrefix(msg.Topic, topicDepthPrefix), msg.Type, msg.Ts, msg.Data)
+	default:
+		logger.Debug("Bybit Futures Coin WS 未知 topic: %s", msg.Topic)
+	}
+}
+
+// handleTrade 处理公共成交，S 为主动成交方向；反向合约 v 为合约张数（1张=1USD），按成交价换算为基础币数量
+func (f *FuturesCoinWS) handleTrade(data json.RawMessage) {
+	var rows []bybitTradeData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析publicTrade失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Price)
+		size, _ := decimal.NewFromString(row.Size)
+		quantity := contractsToBase(size, price)
+		quoteQty := size
+
+		side := schema.OrderSideBuy
+		if row.Side == "Sell" {
+			side = schema.OrderSideSell
+		}
+
+		f.cache.AddTrade(schema.Trade{
+			Exchange:  schema.BYBIT,
+			Market:    schema.FUTURESCOIN,
+			Symbol:    row.Symbol,
+			TradeID:   row.TradeId,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(row.Ts),
+		})
+	}
+}
+
+// handleBBO 处理最优一档推送，数量为合约张数（1张=1USD），按价格换算为基础币数量；
+// 增量推送只包含变化的一侧，另一侧沿用缓存中的值
+func (f *FuturesCoinWS) handleBBO(msgType string, ts int64, data json.RawMessage) {
+	var book bybitBookData
+	if err := json.Unmarshal(data, &book); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析bbo失败: %v", err)
+		return
+	}
+
+	bt := schema.BookTicker{Exchange: schema.BYBIT, Market: schema.FUTURESCOIN, Symbol: book.Symbol}
+	if msgType == "delta" {
+		if cached, ok := f.cache.GetBookTicker(schema.BYBIT, schema.FUTURESCOIN, book.Symbol); ok {
+			bt = cached
+		}
+	}
+	if len(book.Bids) > 0 && len(book.Bids[0]) >= 2 {
+		price, _ := decimal.NewFromString(book.Bids[0][0])
+		qty, _ := decimal.NewFromString(book.Bids[0][1])
+		bt.BidPrice, bt.BidQty = price, contractsToBase(qty, price)
+	}
+	if len(book.Asks) > 0 && len(book.Asks[0]) >= 2 {
+		price, _ := decimal.NewFromString(book.Asks[0][0])
+		qty, _ := decimal.NewFromString(book.Asks[0][1])
+		bt.AskPrice, bt.AskQty = price, contractsToBase(qty, price)
+	}
+	bt.UpdatedAt = time.UnixMilli(ts)
+	bt.LastUpdateId = fmt.Sprintf("%d", book.U)
+
+	f.cache.SetBookTicker(bt)
+}
+
+// handleTicker 处理24小时行情推送，增量推送与缓存中的最新值合并；资金费信息和持仓量同样合并后写入缓存
+func (f *FuturesCoinWS) handleTicker(msgType string, ts int64, data json.RawMessage) {
+	var d schema.BybitTicker
+	if err := json.Unmarshal(data, &d); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析tickers失败: %v", err)
+		return
+	}
+
+	t := schema.Ticker{Exchange: schema.BYBIT, Market: schema.FUTURESCOIN, Symbol: d.Symbol}
+	if msgType == "delta" {
+		if cached, ok := f.cache.GetTicker(schema.BYBIT, schema.FUTURESCOIN, d.Symbol); ok {
+			t = cached
+		}
+	}
+	t = mergeTicker(t, d)
+	t.Timestamp = time.UnixMilli(ts)
+
+	f.cache.SetTicker(t)
+
+	// 同一推送中的标记价格、指数价格和资金费率
+	info := schema.FundingInfo{Exchange: schema.BYBIT, Market: schema.FUTURESCOIN, Symbol: d.Symbol}
+	if msgType == "delta" {
+		if cached, ok := f.cache.GetFunding(schema.BYBIT, schema.FUTURESCOIN, d.Symbol); ok {
+			info = cached
+		}
+	}
+	info = mergeFunding(info, d)
+	info.Timestamp = time.UnixMilli(ts)
+
+	f.cache.SetFunding(info)
+
+	oi := schema.OpenInterest{Exchange: schema.BYBIT, Market: schema.FUTURESCOIN, Symbol: d.Symbol}
+	if msgType == "delta" {
+		if cached, ok := f.cache.GetOpenInterest(schema.BYBIT, schema.FUTURESCOIN, d.Symbol); ok {
+			oi = cached
+		}
+	}
+	oi = mergeOpenInterest(oi, d)
+	oi.Timestamp = time.UnixMilli(ts)
+
+	f.cache.SetOpenInterest(oi)
+}
+
+// handleLiquidation 处理强平推送，S 为被强平的仓位方向（Buy 表示多头被强平），转换为强平订单方向；反向合约 v 为 USD 张数
+func (f *FuturesCoinWS) handleLiquidation(data json.RawMessage) {
+	var rows []bybitLiquidationData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析allLiquidation失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Price)
+		contracts, _ := decimal.NewFromString(row.Size)
+		quantity := contractsToBase(contracts, price)
+		quoteQty := contracts
+
+		side := schema.OrderSideBuy
+		if row.Side == "Buy" {
+			side = schema.OrderSideSell
+		}
+
+		f.cache.PublishLiquidation(schema.Liquidation{
+			Exchange:  schema.BYBIT,
+			Market:    schema.FUTURESCOIN,
+			Symbol:    row.Symbol,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(row.Ts),
+		})
+	}
+}
+
+func (f *FuturesCoinWS) handleKline(symbol string, interval schema.Interval, data json.RawMessage) {
+	var rows []bybitKlineData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析kline失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		open, _ := decimal.NewFromString(row.Open)
+		high, _ := decimal.NewFromString(row.High)
+		low, _ := decimal.NewFromString(row.Low)
+		close, _ := decimal.NewFromString(row.Close)
+		// 反向合约 volume 为张数（USD），turnover 为基础币数量
+		volume, _ := decimal.NewFromString(row.Turnover)
+		quoteVolume, _ := decimal.NewFromString(row.Volume)
+
+		f.gaps.SetKline(schema.Kline{
+			Exchange:    schema.BYBIT,
+			Market:      schema.FUTURESCOIN,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    time.UnixMilli(row.Start),
+			CloseTime:   time.UnixMilli(row.End),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row.Confirm,
+			EventTime:   time.UnixMilli(row.Timestamp),
+		})
+	}
+}
+
+func (f *FuturesCoinWS) handleDepth(symbol, msgType string, ts int64, data json.RawMessage) {
+	var book bybitBookData
+	if err := json.Unmarshal(data, &book); err != nil {
+		logger.Error("Bybit Futures Coin WS 解析depth失败: %v", err)
+		return
+	}
+
+	switch msgType {
+	case "snapshot":
+		// 收到快照（包括服务重启后 u=1 的快照）时重置本地订单簿
+		f.mu.Lock()
+		ob := &orderBook{
+			updateId: book.U,
+			seq:      book.Seq,
+			bids:     make(map[string]decimal.Decimal),
+			asks:     make(map[string]decimal.Decimal),
+		}
+		applyLevels(ob.bids, book.Bids)
+		applyLevels(ob.asks, book.Asks)
+		f.orderBooks[symbol] = ob
+		f.mu.Unlock()
+		logger.Info("Bybit Futures Coin WS %s 深度快照已加载: u=%d, seq=%d, 买单%d档, 卖单%d档",
+			symbol, book.U, book.Seq, len(book.Bids), len(book.Asks))
+	case "delta":
+		f.mu.Lock()
+		ob := f.orderBooks[symbol]
+		if ob == nil {
+			// 尚未收到快照，等待快照
+			f.mu.Unlock()
+			return
+		}
+		if book.U != ob.updateId+1 || book.Seq < ob.seq {
+			f.mu.Unlock()
+			logger.Warn("Bybit Futures Coin WS %s 更新不连续: u=%d, seq=%d, 本地u=%d, 本地seq=%d，重新订阅",
+				symbol, book.U, book.Seq, ob.updateId, ob.seq)
+			f.resubscribeDepth(symbol)
+			return
+		}
+		applyLevels(ob.bids, book.Bids)
+		applyLevels(ob.asks, book.Asks)
+		ob.updateId = book.U
+		ob.seq = book.Seq
+		f.mu.Unlock()
+	default:
+		logger.Warn("Bybit Futures Coin WS 未知深度类型: %s", msgType)
+		return
+	}
+
+	if depth := f.buildDepthFromOrderBook(symbol, ts); depth != nil {
+		f.cache.SetDepth(*depth)
+	}
+}
+
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]decimal.Decimal, levels [][]string) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
+		}
+		price, err := decimal.NewFromString(lv[0])
+		if err != nil {
+			continue
+		}
+		qty, err := decimal.NewFromString(lv[1])
+		if err != nil {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := price.String()
+		if qty.IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = qty
+		}
+	}
+}
+
+// buildDepthFromOrderBook 将本地订单簿排序裁剪，并把张数换算为基础币数量
+func (f *FuturesCoinWS) buildDepthFromOrderBook(symbol string, ts int64) *schema.Depth {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ob := f.orderBooks[symbol]
+	if ob == nil {
+		return nil
+	}
+
+	bids := make([]schema.PriceLevel, 0, len(ob.bids))
+	for priceStr, qty := range ob.bids {
+		price, _ := decimal.NewFromString(priceStr)
+		bids = append(bids, schema.PriceLevel{Price: price, Quantity: contractsToBase(qty, price)})
+	}
+	asks := make([]schema.PriceLevel, 0, len(ob.asks))
+	for priceStr, qty := range ob.asks {
+		price, _ := decimal.NewFromString(priceStr)
+		asks = append(asks, schema.PriceLevel{Price: price, Quantity: contractsToBase(qty, price)})
+	}
+
+	// 排序：买单降序，卖单升序
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	if len(bids) > maxDepthLevels {
+		bids = bids[:maxDepthLevels]
+	}
+	if len(asks) > maxDepthLevels {
+		asks = asks[:maxDepthLevels]
+	}
+
+	updatedAt := time.Now()
+	if ts > 0 {
+		updatedAt = time.UnixMilli(ts)
+	}
+
+	return &schema.Depth{
+		Exchange:     schema.BYBIT,
+		Market:       schema.FUTURESCOIN,
+		Symbol:       symbol,
+		Bids:         bids,
+		Asks:         asks,
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", ob.updateId),
+	}
+}
+
+// HandlePing 处理服务端的 ping 帧
+func (f *FuturesCoinWS) HandlePing(data []byte) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	f.writeMu.Lock()
+	defer f.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
+
+// SendPing 发送 Bybit 心跳 {"op":"ping"}，服务端回复 op 为 ping 的 pong 响应
+func (f *FuturesCoinWS) SendPing(ctx context.Context) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	data, _ := json.Marshal(&bybitMessage{Op: "ping"})
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	f.healthMu.Lock()
+	f.lastPing = time.Now()
+	f.healthMu.Unlock()
+	return nil
+}
+
+// StartHealthCheck 定时发送心跳，超时未收到消息则重连
+func (f *FuturesCoinWS) StartHealthCheck(ctx context.Context) error {
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Bybit Futures Coin WS 健康检查停止")
+			return nil
+		case <-f.ctx.Done():
+			logger.Info("Bybit Futures Coin WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			f.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (f *FuturesCoinWS) checkConnectionHealth(ctx context.Context) {
+	if !f.isConnected() {
+		return
+	}
+
+	f.healthMu.RLock()
+	sinceMsg := time.Since(f.lastMessage)
+	sincePing := time.Since(f.lastPing)
+	f.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("Bybit Futures Coin WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		f.reconnect(ctx)
+		return
+	}
+
+	// 数据推送不能替代心跳，Bybit 要求定期发送 ping
+	if sincePing > pingInterval {
+		if err := f.SendPing(ctx); err != nil {
+			logger.Warn("Bybit Futures Coin WS ping失败: %v", err)
+			f.reconnect(ctx)
+		}
+	}
+}
+
+func (f *FuturesCoinWS) reconnect(ctx context.Context) {
+	f.reconnectMu.Lock()
+	if f.reconnecting {
+		f.reconnectMu.Unlock()
+		return
+	}
+	f.reconnecting = true
+	f.reconnectMu.Unlock()
+
+	defer func() {
+		f.reconnectMu.Lock()
+		f.reconnecting = false
+		f.reconnectMu.Unlock()
+	}()
+
+	f.mu.Lock()
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+	// 重新订阅后服务端会推送新的全量快照
+	f.orderBooks = make(map[string]*orderBook)
+	f.mu.Unlock()
+
+	for {
+		f.reconnectMu.Lock()
+		f.reconnectCount++
+		reconnectCount := f.reconnectCount
+		f.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("Bybit Futures Coin WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := f.Connect(ctx); err != nil {
+			logger.Error("Bybit Futures Coin WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("Bybit Futures Coin WS 重连成功")
+		// 补齐断线期间缺失的K线
+		f.gaps.Backfill(ctx, f.subs.GetKlineSubscriptions())
+		f.reconnectMu.Lock()
+		f.reconnectCount = 0
+		f.reconnectMu.Unlock()
+		return
+	}
+}
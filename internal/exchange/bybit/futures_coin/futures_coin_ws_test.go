@@ -0,0 +1,93 @@
+package futures_coin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func depthPush(bids, asks [][]string, u, seq int64) json.RawMessage {
+	data, _ := json.Marshal(bybitBookData{Symbol: "BTCUSD", Bids: bids, Asks: asks, U: u, Seq: seq})
+	return data
+}
+
+func TestHandleDepth_SnapshotAndDelta(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesCoinWS(c, cache.NewSubscriptionManager(), NewFuturesCoinREST())
+
+	f.handleDepth("BTCUSD", "snapshot", 1700000000000, depthPush(
+		[][]string{{"100", "100"}, {"80", "200"}}, [][]string{{"125", "500"}}, 5, 50))
+	f.handleDepth("BTCUSD", "delta", 1700000000100, depthPush(
+		[][]string{{"100", "0"}}, [][]string{{"120", "60"}}, 6, 51))
+
+	d, ok := c.GetDepth(schema.BYBIT, schema.FUTURESCOIN, "BTCUSD")
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	// 张数（USD）按价格换算为基础币数量：200 / 80 = 2.5
+	if len(d.Bids) != 1 || d.Bids[0].Price.String() != "80" || d.Bids[0].Quantity.String() != "2.5" {
+		t.Fatalf("unexpected bids: %+v", d.Bids)
+	}
+	if len(d.Asks) != 2 || d.Asks[0].Price.String() != "120" || d.Asks[0].Quantity.String() != "0.5" || d.Asks[1].Quantity.String() != "4" {
+		t.Fatalf("unexpected asks: %+v", d.Asks)
+	}
+
+	// u 不连续时丢弃本地订单簿，等待重新订阅后的快照
+	f.handleDepth("BTCUSD", "delta", 1700000000200, depthPush(nil, [][]string{{"130", "1"}}, 8, 53))
+	if _, ok := f.orderBooks["BTCUSD"]; ok {
+		t.Fatalf("order book should be dropped after update id gap")
+	}
+}
+
+func TestHandleDepth_SeqGoesBackward(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesCoinWS(c, cache.NewSubscriptionManager(), NewFuturesCoinREST())
+
+	f.handleDepth("BTCUSD", "snapshot", 1700000000000, depthPush(
+		[][]string{{"100", "100"}}, [][]string{{"101", "100"}}, 5, 50))
+
+	// u 连续但 seq 回退，同样视为不连续
+	f.handleDepth("BTCUSD", "delta", 1700000000100, depthPush(nil, [][]string{{"101", "200"}}, 6, 49))
+	if _, ok := f.orderBooks["BTCUSD"]; ok {
+		t.Fatalf("order book should be dropped when seq goes backward")
+	}
+}
+
+func TestHandleTrade_ContractsToBase(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesCoinWS(c, cache.NewSubscriptionManager(), NewFuturesCoinREST())
+
+	f.handleRawMessage([]byte(`{"topic":"publicTrade.BTCUSD","type":"snapshot","ts":1672304486868,"data":[{"T":1672304486865,"s":"BTCUSD","S":"Buy","v":"500","p":"20000","L":"PlusTick","i":"20f43950-d8dd-5b31-9112-a178eb6023af","BT":false}]}`))
+
+	trades, ok := c.GetTrades(schema.BYBIT, schema.FUTURESCOIN, "BTCUSD", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	// 500 张 × 1 USD / 20000 = 0.025 BTC，成交额即为张数
+	tr := trades[0]
+	if tr.Side != schema.OrderSideBuy || tr.Quantity.String() != "0.025" || tr.QuoteQty.String() != "500" {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+func TestHandleLiquidation(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesCoinWS(c, cache.NewSubscriptionManager(), NewFuturesCoinREST())
+
+	var got []schema.Liquidation
+	c.OnLiquidation(func(l schema.Liquidation) { got = append(got, l) })
+
+	// S=Sell 表示空头仓位被强平，对应的强平订单方向为买入；v 为 USD 张数
+	f.handleRawMessage([]byte(`{"topic":"allLiquidation.BTCUSD","type":"snapshot","ts":1739502303204,"data":[{"T":1739502302929,"s":"BTCUSD","S":"Sell","v":"1000","p":"25000"}]}`))
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 liquidation, got %d", len(got))
+	}
+	l := got[0]
+	if l.Symbol != "BTCUSD" || l.Side != schema.OrderSideBuy || l.Price.String() != "25000" ||
+		l.Quantity.String() != "0.04" || l.QuoteQty.String() != "1000" || l.Timestamp.UnixMilli() != 1739502302929 {
+		t.Fatalf("unexpected liquidation: %+v", l)
+	}
+}
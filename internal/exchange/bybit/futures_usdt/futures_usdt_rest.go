@@ -3,14 +3,31 @@ package futures_usdt
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 
+	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
 )
 
-const BybitFuturesUSDTBaseURL = "https://api.bybit.com"
+const (
+	BybitFuturesUSDTBaseURL    = "https://api.bybit.com"
+	apiV5MarketTickers         = "/v5/market/tickers"
+	apiV5MarketKline           = "/v5/market/kline"
+	apiV5MarketOrderbook       = "/v5/market/orderbook"
+	apiV5MarketInstrumentsInfo = "/v5/market/instruments-info"
+	apiV5MarketOpenInterest    = "/v5/market/open-interest"
+	apiV5MarketAccountRatio    = "/v5/market/account-ratio"
+	categoryLinear             = "linear"
+	instrumentsInfoPageLimit   = 1000
+	instrumentStatusTrading    = "Trading"
+	settleCoinUSDT             = "USDT"
+)
 
 // FuturesUSDTREST implements RESTClient for Bybit USDT-margined Futures.
 type FuturesUSDTREST struct {
@@ -19,34 +36,507 @@ type FuturesUSDTREST struct {
 
 func NewFuturesUSDTREST() *FuturesUSDTREST {
 	return &FuturesUSDTREST{
-		http: resty.New().SetBaseURL(BybitFuturesUSDTBaseURL),
+		http: resty.New().SetBaseURL(BybitFuturesUSDTBaseURL).SetTimeout(10 * time.Second),
+	}
+}
+
+// GetKline 获取最近 limit 根K线，按时间范围委托给 GetKlines
+func (f *FuturesUSDTREST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
+	return schema.LatestKlines(ctx, f.GetKlines, symbol, interval, limit)
+}
+
+// maxKlinesPerRequest Bybit Futures USDT K线接口单次最多返回的条数
+const maxKlinesPerRequest = 1000
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，接口按时间倒序返回，从 end 向前翻页，end 为零值时取到当前时间，start 不能为零值
+func (f *FuturesUSDTREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	// 从 end 向前翻页直到 start，start 为零值时会一直翻到最早的K线
+	if start.IsZero() {
+		return nil, errors.New("kline start time is required")
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	to := end
+	for !to.Before(start) {
+		rows, err := f.klines(ctx, symbol, interval, start, to)
+		if err != nil {
+			return nil, err
+		}
+		page := convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+		if len(rows) < maxKlinesPerRequest || len(page) == 0 {
+			break
+		}
+
+		// 从本页最早一根的前一毫秒继续向前拉取
+		next := page[len(page)-1].OpenTime.Add(-time.Millisecond)
+		if !next.Before(to) {
+			break
+		}
+		to = next
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// klines 拉取一页K线
+func (f *FuturesUSDTREST) klines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([][]string, error) {
+	var resp schema.BybitKlineResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"category": categoryLinear,
+		"symbol":   symbol,
+		"interval": klineIntervals[interval],
+		"start":    strconv.FormatInt(start.UnixMilli(), 10),
+		"end":      strconv.FormatInt(end.UnixMilli(), 10),
+		"limit":    strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV5MarketKline)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return nil, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+
+	logger.Debug("Bybit Futures USDT Kline 返回 %d 条", len(resp.Result.List))
+	return resp.Result.List, nil
+}
+
+// convertKlines 转换K线数组，成交量为基础币数量，成交额为USDT数量；收盘时间未到的K线标记为未完结
+func convertKlines(rows [][]string, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 7 {
+			continue
+		}
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		open, _ := decimal.NewFromString(row[1])
+		high, _ := decimal.NewFromString(row[2])
+		low, _ := decimal.NewFromString(row[3])
+		close, _ := decimal.NewFromString(row[4])
+		volume, _ := decimal.NewFromString(row[5])
+		quoteVolume, _ := decimal.NewFromString(row[6])
+		openTime := time.UnixMilli(ts)
+		closeTime := openTime.Add(interval.Duration() - time.Millisecond)
+		out = append(out, schema.Kline{
+			Exchange:    schema.BYBIT,
+			Market:      schema.FUTURESUSDT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   closeTime,
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     closeTime.Before(now),
+		})
 	}
+	return out
 }
 
+// GetTicker 获取单个合约的24小时行情
 func (f *FuturesUSDTREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
-	// TODO: Implement Bybit futures USDT ticker
-	return schema.Ticker{}, errors.New("not implemented")
+	resp, err := f.tickers(ctx, symbol)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	if len(resp.Result.List) == 0 {
+		return schema.Ticker{}, errors.New("no ticker data")
+	}
+
+	d := resp.Result.List[0]
+	return mergeTicker(schema.Ticker{Exchange: schema.BYBIT, Market: schema.FUTURESUSDT, Symbol: d.Symbol, Timestamp: time.UnixMilli(resp.Time)}, d), nil
 }
 
-func (f *FuturesUSDTREST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
-	// TODO: Implement Bybit futures USDT kline
-	return nil, errors.New("not implemented")
+// GetTickers 获取全部合约的24小时行情
+func (f *FuturesUSDTREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	resp, err := f.tickers(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.Ticker, 0, len(resp.Result.List))
+	for _, t := range resp.Result.List {
+		// linear 分类同时包含USDC合约，只保留USDT结算的永续合约
+		if !strings.HasSuffix(t.Symbol, "USDT") {
+			continue
+		}
+		out = append(out, mergeTicker(schema.Ticker{Exchange: schema.BYBIT, Market: schema.FUTURESUSDT, Symbol: t.Symbol, Timestamp: time.UnixMilli(resp.Time)}, t))
+	}
+	return out, nil
+}
+
+func (f *FuturesUSDTREST) tickers(ctx context.Context, symbol string) (schema.BybitTickerResponse, error) {
+	var resp schema.BybitTickerResponse
+	req := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("category", categoryLinear)
+	if symbol != "" {
+		req.SetQueryParam("symbol", symbol)
+	}
+	r, err := req.Get(apiV5MarketTickers)
+	if err != nil {
+		return resp, err
+	}
+	if r.IsError() {
+		return resp, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return resp, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+
+	logger.Debug("Bybit Futures USDT Ticker 原始响应: %s", string(r.Body()))
+	return resp, nil
+}
+
+// mergeTicker 用行情数据更新 Ticker，空字段保持原值（WebSocket 增量推送只包含变化的字段）
+func mergeTicker(t schema.Ticker, d schema.BybitTicker) schema.Ticker {
+	set := func(dst *decimal.Decimal, v string) {
+		if v == "" {
+			return
+		}
+		if n, err := decimal.NewFromString(v); err == nil {
+			*dst = n
+		}
+	}
+	set(&t.Price, d.LastPrice)
+	set(&t.Open, d.PrevPrice24h)
+	set(&t.High, d.HighPrice24h)
+	set(&t.Low, d.LowPrice24h)
+	set(&t.Volume, d.Volume24h)
+	set(&t.QuoteVol, d.Turnover24h)
+	return t
+}
+
+// mergeFunding 用行情数据更新资金费信息，空字段保持原值
+func mergeFunding(info schema.FundingInfo, d schema.BybitTicker) schema.FundingInfo {
+	set := func(dst *decimal.Decimal, v string) {
+		if v == "" {
+			return
+		}
+		if n, err := decimal.NewFromString(v); err == nil {
+			*dst = n
+		}
+	}
+	set(&info.MarkPrice, d.MarkPrice)
+	set(&info.IndexPrice, d.IndexPrice)
+	set(&info.FundingRate, d.FundingRate)
+	if ms, err := strconv.ParseInt(d.NextFundingTime, 10, 64); err == nil && ms > 0 {
+		info.NextFundingTime = time.UnixMilli(ms)
+	}
+	return info
+}
+
+// mergeOpenInterest 用行情数据更新持仓量，空字段保持原值，openInterest 为基础币，openInterestValue 为USDT价值
+func mergeOpenInterest(oi schema.OpenInterest, d schema.BybitTicker) schema.OpenInterest {
+	set := func(dst *decimal.Decimal, v string) {
+		if v == "" {
+			return
+		}
+		if n, err := decimal.NewFromString(v); err == nil {
+			*dst = n
+		}
+	}
+	set(&oi.OpenInterest, d.OpenInterest)
+	set(&oi.Value, d.OpenInterestValue)
+	return oi
+}
+
+// GetOpenInterest 获取合约当前持仓量，取自24小时行情
+func (f *FuturesUSDTREST) GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error) {
+	resp, err := f.tickers(ctx, symbol)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	if len(resp.Result.List) == 0 {
+		return schema.OpenInterest{}, errors.New("no ticker data")
+	}
+
+	d := resp.Result.List[0]
+	return mergeOpenInterest(schema.OpenInterest{Exchange: schema.BYBIT, Market: schema.FUTURESUSDT, Symbol: d.Symbol, Timestamp: time.UnixMilli(resp.Time)}, d), nil
+}
+
+// GetOpenInterestHistory 获取合约持仓量历史，openInterest 为基础币数量，接口不提供持仓价值
+func (f *FuturesUSDTREST) GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	var resp schema.BybitOpenInterestResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"category":     categoryLinear,
+		"symbol":       symbol,
+		"intervalTime": periodBybit(period),
+		"limit":        fmt.Sprintf("%d", limit),
+	}).Get(apiV5MarketOpenInterest)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return nil, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+
+	// 按时间倒序返回，转换为升序
+	list := resp.Result.List
+	out := make([]schema.OpenInterest, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		amount, _ := decimal.NewFromString(list[i].OpenInterest)
+		ts, _ := strconv.ParseInt(list[i].Timestamp, 10, 64)
+		out = append(out, schema.OpenInterest{
+			Exchange:     schema.BYBIT,
+			Market:       schema.FUTURESUSDT,
+			Symbol:       symbol,
+			OpenInterest: amount,
+			Timestamp:    time.UnixMilli(ts),
+		})
+	}
+	return out, nil
+}
+
+// GetLongShortRatio 获取多空账户比历史，多空比 = buyRatio / sellRatio
+func (f *FuturesUSDTREST) GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	var resp schema.BybitAccountRatioResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"category": categoryLinear,
+		"symbol":   symbol,
+		"period":   periodBybit(period),
+		"limit":    fmt.Sprintf("%d", limit),
+	}).Get(apiV5MarketAccountRatio)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return nil, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+
+	list := resp.Result.List
+	out := make([]schema.LongShortRatio, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		longAccount, _ := decimal.NewFromString(list[i].BuyRatio)
+		shortAccount, _ := decimal.NewFromString(list[i].SellRatio)
+		ts, _ := strconv.ParseInt(list[i].Timestamp, 10, 64)
+		ratio := decimal.Zero
+		if shortAccount.IsPositive() {
+			ratio = longAccount.Div(shortAccount)
+		}
+		out = append(out, schema.LongShortRatio{
+			Exchange:       schema.BYBIT,
+			Market:         schema.FUTURESUSDT,
+			Symbol:         symbol,
+			LongShortRatio: ratio,
+			LongAccount:    longAccount,
+			ShortAccount:   shortAccount,
+			Timestamp:      time.UnixMilli(ts),
+		})
+	}
+	return out, nil
+}
+
+// periodBybit 统计周期，支持 5min、15min、30min、1h、4h、1d
+func periodBybit(iv schema.Interval) string {
+	m := map[schema.Interval]string{schema.Interval5m: "5min", schema.Interval15m: "15min", schema.Interval30m: "30min", schema.Interval1h: "1h", schema.Interval4h: "4h", schema.Interval1d: "1d"}
+	if v, ok := m[iv]; ok {
+		return v
+	}
+	return "5min"
 }
 
+// GetDepth 获取合约深度，linear 合约数量单位为基础币，无需换算
 func (f *FuturesUSDTREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
-	// TODO: Implement Bybit futures USDT depth
-	return schema.Depth{}, errors.New("not implemented")
+	var resp struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  struct {
+			Symbol string     `json:"s"`
+			Bids   [][]string `json:"b"`
+			Asks   [][]string `json:"a"`
+			Ts     int64      `json:"ts"`
+			U      int64      `json:"u"`
+			Seq    int64      `json:"seq"`
+		} `json:"result"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"category": categoryLinear,
+		"symbol":   symbol,
+		"limit":    fmt.Sprintf("%d", limit),
+	}).Get(apiV5MarketOrderbook)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+	if r.IsError() {
+		return schema.Depth{}, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return schema.Depth{}, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+
+	// 保存原始响应结果用于调试
+	rawResponse := string(r.Body())
+	logger.Debug("Bybit Futures USDT Depth 原始响应: %s", rawResponse)
+
+	convert := func(levels [][]string) []schema.PriceLevel {
+		out := make([]schema.PriceLevel, 0, len(levels))
+		for _, lv := range levels {
+			if len(lv) < 2 {
+				continue
+			}
+			p, _ := decimal.NewFromString(lv[0])
+			q, _ := decimal.NewFromString(lv[1])
+			out = append(out, schema.PriceLevel{Price: p, Quantity: q})
+		}
+		return out
+	}
+
+	return schema.Depth{
+		Exchange:     schema.BYBIT,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       resp.Result.Symbol,
+		Bids:         convert(resp.Result.Bids),
+		Asks:         convert(resp.Result.Asks),
+		UpdatedAt:    time.UnixMilli(resp.Result.Ts),
+		LastUpdateId: strconv.FormatInt(resp.Result.U, 10),
+	}, nil
 }
 
+// GetExchangeInfo 获取 linear 合约交易规则，只保留 USDT 结算且未下架的合约
 func (f *FuturesUSDTREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现Bybit USDT合约交易规则信息获取
+	type instrument struct {
+		Symbol       string `json:"symbol"`
+		ContractType string `json:"contractType"`
+		Status       string `json:"status"`
+		BaseCoin     string `json:"baseCoin"`
+		QuoteCoin    string `json:"quoteCoin"`
+		SettleCoin   string `json:"settleCoin"`
+		PriceScale   string `json:"priceScale"`
+		PriceFilter  struct {
+			TickSize string `json:"tickSize"`
+		} `json:"priceFilter"`
+		LotSizeFilter struct {
+			MaxOrderQty      string `json:"maxOrderQty"`
+			MinOrderQty      string `json:"minOrderQty"`
+			QtyStep          string `json:"qtyStep"`
+			MinNotionalValue string `json:"minNotionalValue"`
+		} `json:"lotSizeFilter"`
+	}
+
+	var (
+		symbols    []schema.Symbol
+		serverTime time.Time
+		cursor     string
+	)
+	// instruments-info 按 cursor 分页，nextPageCursor 为空表示最后一页
+	for {
+		var resp struct {
+			RetCode int    `json:"retCode"`
+			RetMsg  string `json:"retMsg"`
+			Result  struct {
+				List           []instrument `json:"list"`
+				NextPageCursor string       `json:"nextPageCursor"`
+			} `json:"result"`
+			Time int64 `json:"time"`
+		}
+		req := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+			"category": categoryLinear,
+			"limit":    fmt.Sprintf("%d", instrumentsInfoPageLimit),
+		})
+		if cursor != "" {
+			req.SetQueryParam("cursor", cursor)
+		}
+		r, err := req.Get(apiV5MarketInstrumentsInfo)
+		if err != nil {
+			return schema.ExchangeInfo{}, err
+		}
+		if r.IsError() {
+			return schema.ExchangeInfo{}, errors.New(r.Status())
+		}
+		if resp.RetCode != 0 {
+			return schema.ExchangeInfo{}, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+		}
+		logger.Debug("Bybit Futures USDT ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+		serverTime = time.UnixMilli(resp.Time)
+		for _, s := range resp.Result.List {
+			if s.SettleCoin != settleCoinUSDT {
+				continue
+			}
+			status, ok := symbolStatus(s.Status)
+			if !ok {
+				continue
+			}
+			pricePrecision, _ := strconv.Atoi(s.PriceScale)
+			symbols = append(symbols, schema.Symbol{
+				Symbol:       s.Symbol,
+				Base:         s.BaseCoin,
+				Quote:        s.QuoteCoin,
+				Margin:       s.SettleCoin,
+				ExchangeName: schema.BYBIT,
+				MarketType:   schema.FUTURESUSDT,
+
+				QuantityPrecision: decimalPlaces(s.LotSizeFilter.QtyStep),
+				PricePrecision:    pricePrecision,
+				MinQuantity:       s.LotSizeFilter.MinOrderQty,
+				MinNotional:       s.LotSizeFilter.MinNotionalValue,
+				MaxQuantity:       s.LotSizeFilter.MaxOrderQty,
+				TickSize:          s.PriceFilter.TickSize,
+				StepSize:          s.LotSizeFilter.QtyStep,
+
+				Status: status,
+			})
+		}
+
+		if resp.Result.NextPageCursor == "" || len(resp.Result.List) == 0 {
+			break
+		}
+		cursor = resp.Result.NextPageCursor
+	}
+
+	logger.Info("Bybit Futures USDT 交易规则已加载: %d 个合约", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.BYBIT,
 		Market:     schema.FUTURESUSDT,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: serverTime,
 		RateLimits: []schema.RateLimit{},
 		Timezone:   "UTC",
 	}, nil
 }
+
+// symbolStatus 归一化合约状态，已下架（Closed）的合约返回 false
+func symbolStatus(status string) (schema.SymbolStatus, bool) {
+	switch status {
+	case instrumentStatusTrading:
+		return schema.SymbolStatusTrading, true
+	case "PreLaunch":
+		return schema.SymbolStatusPreTrading, true
+	case "Closed":
+		return "", false
+	default:
+		// Delivering 等交割中状态
+		return schema.SymbolStatusBreak, true
+	}
+}
+
+// decimalPlaces 根据步长计算小数位数，如 "0.001" -> 3
+func decimalPlaces(step string) int {
+	d, err := decimal.NewFromString(step)
+	if err != nil || d.Exponent() >= 0 {
+		return 0
+	}
+	return int(-d.Exponent())
+}
@@ -0,0 +1,39 @@
+//go:build integration
+
+package futures_usdt
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestBybitFuturesUSDTREST_Depth(t *testing.T) {
+	r := NewFuturesUSDTREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	d, err := r.GetDepth(ctx, "BTCUSDT", 5)
+	if err != nil {
+		t.Fatalf("depth error: %v", err)
+	}
+	if len(d.Bids) == 0 || len(d.Asks) == 0 {
+		t.Fatalf("empty orderbook")
+	}
+	log.Printf("Bybit Futures USDT REST Depth: Bids=%d, Asks=%d, 最佳买价=%v, 最佳卖价=%v",
+		len(d.Bids), len(d.Asks), d.Bids[0].Price, d.Asks[0].Price)
+}
+
+func TestBybitFuturesUSDTREST_ExchangeInfo(t *testing.T) {
+	r := NewFuturesUSDTREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	info, err := r.GetExchangeInfo(ctx)
+	if err != nil {
+		t.Fatalf("exchange info error: %v", err)
+	}
+	if len(info.Symbols) == 0 {
+		t.Fatalf("no symbols")
+	}
+	log.Printf("Bybit Futures USDT ExchangeInfo: 合约数=%d, 示例=%+v", len(info.Symbols), info.Symbols[0])
+}
@@ -0,0 +1,115 @@
+package futures_usdt
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func depthPush(bids, asks [][]string, u, seq int64) json.RawMessage {
+	data, _ := json.Marshal(bybitBookData{Symbol: "BTCUSDT", Bids: bids, Asks: asks, U: u, Seq: seq})
+	return data
+}
+
+func TestHandleDepth_SnapshotAndDelta(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesUSDTWS(c, cache.NewSubscriptionManager(), NewFuturesUSDTREST())
+
+	f.handleDepth("BTCUSDT", "snapshot", 1700000000000, depthPush(
+		[][]string{{"100", "1"}, {"99", "2"}}, [][]string{{"101", "3"}}, 5, 50))
+	f.handleDepth("BTCUSDT", "delta", 1700000000100, depthPush(
+		[][]string{{"100", "0"}}, [][]string{{"100.5", "1"}}, 6, 51))
+
+	d, ok := c.GetDepth(schema.BYBIT, schema.FUTURESUSDT, "BTCUSDT")
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	// U本位合约数量即为基础币数量，不做换算
+	if len(d.Bids) != 1 || d.Bids[0].Price.String() != "99" || d.Bids[0].Quantity.String() != "2" {
+		t.Fatalf("unexpected bids: %+v", d.Bids)
+	}
+	if len(d.Asks) != 2 || d.Asks[0].Price.String() != "100.5" || d.LastUpdateId != "6" {
+		t.Fatalf("unexpected asks: %+v", d)
+	}
+
+	// u 不连续时丢弃本地订单簿，等待重新订阅后的快照
+	f.handleDepth("BTCUSDT", "delta", 1700000000200, depthPush(nil, [][]string{{"102", "1"}}, 8, 53))
+	if _, ok := f.orderBooks["BTCUSDT"]; ok {
+		t.Fatalf("order book should be dropped after update id gap")
+	}
+
+	// 未收到快照前的增量被忽略
+	f.handleDepth("BTCUSDT", "delta", 1700000000300, depthPush(nil, [][]string{{"102", "1"}}, 9, 54))
+	if _, ok := f.orderBooks["BTCUSDT"]; ok {
+		t.Fatalf("delta without snapshot must not create an order book")
+	}
+
+	// 服务重启后 u=1 的快照重置本地订单簿
+	f.handleDepth("BTCUSDT", "snapshot", 1700000000400, depthPush(
+		[][]string{{"98", "1"}}, [][]string{{"103", "1"}}, 1, 60))
+	d, _ = c.GetDepth(schema.BYBIT, schema.FUTURESUSDT, "BTCUSDT")
+	if d.LastUpdateId != "1" || len(d.Bids) != 1 || d.Bids[0].Price.String() != "98" {
+		t.Fatalf("unexpected depth after restart snapshot: %+v", d)
+	}
+}
+
+func TestHandleDepth_SeqGoesBackward(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesUSDTWS(c, cache.NewSubscriptionManager(), NewFuturesUSDTREST())
+
+	f.handleDepth("BTCUSDT", "snapshot", 1700000000000, depthPush(
+		[][]string{{"100", "1"}}, [][]string{{"101", "1"}}, 5, 50))
+
+	// u 连续但 seq 回退，同样视为不连续
+	f.handleDepth("BTCUSDT", "delta", 1700000000100, depthPush(nil, [][]string{{"101", "2"}}, 6, 49))
+	if _, ok := f.orderBooks["BTCUSDT"]; ok {
+		t.Fatalf("order book should be dropped when seq goes backward")
+	}
+}
+
+func TestHandleTrade(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesUSDTWS(c, cache.NewSubscriptionManager(), NewFuturesUSDTREST())
+
+	f.handleRawMessage([]byte(`{"topic":"publicTrade.BTCUSDT","type":"snapshot","ts":1672304486868,"data":[{"T":1672304486865,"s":"BTCUSDT","S":"Sell","v":"0.001","p":"16578.50","L":"PlusTick","i":"20f43950-d8dd-5b31-9112-a178eb6023af","BT":false}]}`))
+
+	trades, ok := c.GetTrades(schema.BYBIT, schema.FUTURESUSDT, "BTCUSDT", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	tr := trades[0]
+	if tr.Side != schema.OrderSideSell || tr.Quantity.String() != "0.001" || tr.QuoteQty.String() != "16.5785" {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+func TestHandleLiquidation(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewFuturesUSDTWS(c, cache.NewSubscriptionManager(), NewFuturesUSDTREST())
+
+	var got []schema.Liquidation
+	c.OnLiquidation(func(l schema.Liquidation) { got = append(got, l) })
+
+	// S=Buy 表示多头仓位被强平，对应的强平订单方向为卖出
+	f.handleRawMessage([]byte(`{"topic":"allLiquidation.ROSEUSDT","type":"snapshot","ts":1739502303204,"data":[{"T":1739502302929,"s":"ROSEUSDT","S":"Buy","v":"20000","p":"0.04499"}]}`))
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 liquidation, got %d", len(got))
+	}
+	l := got[0]
+	if l.Symbol != "ROSEUSDT" || l.Side != schema.OrderSideSell || l.Price.String() != "0.04499" ||
+		l.Quantity.String() != "20000" || l.QuoteQty.String() != "899.8" || l.Timestamp.UnixMilli() != 1739502302929 {
+		t.Fatalf("unexpected liquidation: %+v", l)
+	}
+}
+
+func TestGetKlines_RequiresStart(t *testing.T) {
+	// 从 end 向前翻页，未指定 start 时拒绝请求，避免翻遍全部历史
+	if _, err := NewFuturesUSDTREST().GetKlines(context.Background(), "BTCUSDT", schema.Interval1m, time.Time{}, time.Now()); err == nil {
+		t.Fatalf("zero start should be rejected")
+	}
+}
@@ -15,10 +15,12 @@ import (
 )
 
 const (
-	bybitBaseURL         = "https://api.bybit.com"
-	apiV5MarketTickers   = "/v5/market/tickers"
-	apiV5MarketKline     = "/v5/market/kline"
-	apiV5MarketOrderbook = "/v5/market/orderbook"
+	bybitBaseURL               = "https://api.bybit.com"
+	apiV5MarketTickers         = "/v5/market/tickers"
+	apiV5MarketKline           = "/v5/market/kline"
+	apiV5MarketOrderbook       = "/v5/market/orderbook"
+	apiV5MarketInstrumentsInfo = "/v5/market/instruments-info"
+	categorySpot               = "spot"
 )
 
 type SpotREST struct{ http *resty.Client }
@@ -27,54 +29,176 @@ func NewSpotREST() *SpotREST {
 	return &SpotREST{http: resty.New().SetBaseURL(bybitBaseURL).SetTimeout(10 * time.Second)}
 }
 
-func (b *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
-	var resp struct {
-		RetCode int    `json:"retCode"`
-		RetMsg  string `json:"retMsg"`
-		Result  struct {
-			List []struct {
-				Symbol        string `json:"symbol"`
-				LastPrice     string `json:"lastPrice"`
-				PrevPrice24h  string `json:"prevPrice24h"`
-				Price24hPcnt  string `json:"price24hPcnt"`
-				HighPrice24h  string `json:"highPrice24h"`
-				LowPrice24h   string `json:"lowPrice24h"`
-				Turnover24h   string `json:"turnover24h"`
-				Volume24h     string `json:"volume24h"`
-				UsdIndexPrice string `json:"usdIndexPrice"`
-			} `json:"list"`
-		} `json:"result"`
+// maxKlinesPerRequest Bybit Spot K线接口单次最多返回的条数
+const maxKlinesPerRequest = 1000
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，接口按时间倒序返回，从 end 向前翻页，end 为零值时取到当前时间，start 不能为零值
+func (b *SpotREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
 	}
-	r, err := b.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("category", "spot").SetQueryParam("symbol", symbol).Get(apiV5MarketTickers)
+	// 从 end 向前翻页直到 start，start 为零值时会一直翻到最早的K线
+	if start.IsZero() {
+		return nil, errors.New("kline start time is required")
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	to := end
+	for !to.Before(start) {
+		rows, err := b.klines(ctx, symbol, interval, start, to)
+		if err != nil {
+			return nil, err
+		}
+		page := convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+		if len(rows) < maxKlinesPerRequest || len(page) == 0 {
+			break
+		}
+
+		// 从本页最早一根的前一毫秒继续向前拉取
+		next := page[len(page)-1].OpenTime.Add(-time.Millisecond)
+		if !next.Before(to) {
+			break
+		}
+		to = next
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// klines 拉取一页K线
+func (b *SpotREST) klines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([][]string, error) {
+	var resp schema.BybitKlineResponse
+	r, err := b.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"category": "spot",
+		"symbol":   symbol,
+		"interval": klineIntervals[interval],
+		"start":    strconv.FormatInt(start.UnixMilli(), 10),
+		"end":      strconv.FormatInt(end.UnixMilli(), 10),
+		"limit":    strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV5MarketKline)
 	if err != nil {
-		return schema.Ticker{}, err
+		return nil, err
 	}
 	if r.IsError() {
-		return schema.Ticker{}, errors.New(r.Status())
+		return nil, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return nil, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
 	}
 
-	// 保存原始响应结果用于调试
-	rawResponse := string(r.Body())
-	logger.Debug("Bybit Spot Ticker 原始响应: %s", rawResponse)
+	logger.Debug("Bybit Spot Kline 返回 %d 条", len(resp.Result.List))
+	return resp.Result.List, nil
+}
+
+// convertKlines 转换K线数组，成交量为基础币数量，成交额为计价币数量；收盘时间未到的K线标记为未完结
+func convertKlines(rows [][]string, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 7 {
+			continue
+		}
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		open, _ := decimal.NewFromString(row[1])
+		high, _ := decimal.NewFromString(row[2])
+		low, _ := decimal.NewFromString(row[3])
+		close, _ := decimal.NewFromString(row[4])
+		volume, _ := decimal.NewFromString(row[5])
+		quoteVolume, _ := decimal.NewFromString(row[6])
+		openTime := time.UnixMilli(ts)
+		closeTime := openTime.Add(interval.Duration() - time.Millisecond)
+		out = append(out, schema.Kline{
+			Exchange:    schema.BYBIT,
+			Market:      schema.SPOT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   closeTime,
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     closeTime.Before(now),
+		})
+	}
+	return out
+}
 
+// GetTicker 获取单个币对的24小时行情
+func (b *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	resp, err := b.tickers(ctx, symbol)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
 	if len(resp.Result.List) == 0 {
 		return schema.Ticker{}, errors.New("no ticker data")
 	}
 
-	data := resp.Result.List[0]
-	price, _ := decimal.NewFromString(data.LastPrice)
-	volume, _ := decimal.NewFromString(data.Volume24h)
-	quoteVolume, _ := decimal.NewFromString(data.Turnover24h)
+	d := resp.Result.List[0]
+	return mergeTicker(schema.Ticker{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: d.Symbol, Timestamp: time.UnixMilli(resp.Time)}, d), nil
+}
 
-	return schema.Ticker{
-		Exchange:  schema.BYBIT,
-		Market:    schema.SPOT,
-		Symbol:    data.Symbol,
-		Price:     price,
-		Volume:    volume,
-		QuoteVol:  quoteVolume,
-		Timestamp: time.Now(),
-	}, nil
+// GetTickers 获取全部币对的24小时行情
+func (b *SpotREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	resp, err := b.tickers(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.Ticker, 0, len(resp.Result.List))
+	for _, t := range resp.Result.List {
+		out = append(out, mergeTicker(schema.Ticker{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: t.Symbol, Timestamp: time.UnixMilli(resp.Time)}, t))
+	}
+	return out, nil
+}
+
+func (b *SpotREST) tickers(ctx context.Context, symbol string) (schema.BybitTickerResponse, error) {
+	var resp schema.BybitTickerResponse
+	req := b.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("category", "spot")
+	if symbol != "" {
+		req.SetQueryParam("symbol", symbol)
+	}
+	r, err := req.Get(apiV5MarketTickers)
+	if err != nil {
+		return resp, err
+	}
+	if r.IsError() {
+		return resp, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return resp, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+
+	logger.Debug("Bybit Spot Ticker 原始响应: %s", string(r.Body()))
+	return resp, nil
+}
+
+// mergeTicker 用行情数据更新 Ticker，空字段保持原值（WebSocket 增量推送只包含变化的字段）
+func mergeTicker(t schema.Ticker, d schema.BybitTicker) schema.Ticker {
+	set := func(dst *decimal.Decimal, v string) {
+		if v == "" {
+			return
+		}
+		if n, err := decimal.NewFromString(v); err == nil {
+			*dst = n
+		}
+	}
+	set(&t.Price, d.LastPrice)
+	set(&t.Open, d.PrevPrice24h)
+	set(&t.High, d.HighPrice24h)
+	set(&t.Low, d.LowPrice24h)
+	set(&t.Volume, d.Volume24h)
+	set(&t.QuoteVol, d.Turnover24h)
+	return t
 }
 
 func intervalBybit(iv schema.Interval) string {
@@ -196,15 +320,98 @@ func (b *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (sche
 	}, nil
 }
 
+// GetExchangeInfo 获取现货交易规则，spot 分类的 instruments-info 不分页，一次返回全部交易对
 func (b *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现Bybit交易规则信息获取
+	var resp struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  struct {
+			List []struct {
+				Symbol      string `json:"symbol"`
+				BaseCoin    string `json:"baseCoin"`
+				QuoteCoin   string `json:"quoteCoin"`
+				Status      string `json:"status"`
+				PriceFilter struct {
+					TickSize string `json:"tickSize"`
+				} `json:"priceFilter"`
+				LotSizeFilter struct {
+					BasePrecision string `json:"basePrecision"`
+					MinOrderQty   string `json:"minOrderQty"`
+					MaxOrderQty   string `json:"maxOrderQty"`
+					MinOrderAmt   string `json:"minOrderAmt"`
+				} `json:"lotSizeFilter"`
+			} `json:"list"`
+		} `json:"result"`
+		Time int64 `json:"time"`
+	}
+	r, err := b.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("category", categorySpot).Get(apiV5MarketInstrumentsInfo)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	if r.IsError() {
+		return schema.ExchangeInfo{}, errors.New(r.Status())
+	}
+	if resp.RetCode != 0 {
+		return schema.ExchangeInfo{}, fmt.Errorf("bybit error %d: %s", resp.RetCode, resp.RetMsg)
+	}
+	logger.Debug("Bybit Spot ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+	symbols := make([]schema.Symbol, 0, len(resp.Result.List))
+	for _, s := range resp.Result.List {
+		status, ok := symbolStatus(s.Status)
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       s.Symbol,
+			Base:         s.BaseCoin,
+			Quote:        s.QuoteCoin,
+			ExchangeName: schema.BYBIT,
+			MarketType:   schema.SPOT,
+
+			QuantityPrecision: decimalPlaces(s.LotSizeFilter.BasePrecision),
+			PricePrecision:    decimalPlaces(s.PriceFilter.TickSize),
+			MinQuantity:       s.LotSizeFilter.MinOrderQty,
+			MinNotional:       s.LotSizeFilter.MinOrderAmt,
+			MaxQuantity:       s.LotSizeFilter.MaxOrderQty,
+			TickSize:          s.PriceFilter.TickSize,
+			StepSize:          s.LotSizeFilter.BasePrecision,
+
+			Status: status,
+		})
+	}
+
+	logger.Info("Bybit Spot 交易规则已加载: %d 个交易对", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.BYBIT,
 		Market:     schema.SPOT,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: time.UnixMilli(resp.Time),
 		RateLimits: []schema.RateLimit{},
 		Timezone:   "UTC",
 	}, nil
 }
+
+// symbolStatus 归一化交易对状态，已下架（Closed）的交易对返回 false
+func symbolStatus(status string) (schema.SymbolStatus, bool) {
+	switch status {
+	case "Trading":
+		return schema.SymbolStatusTrading, true
+	case "PreLaunch":
+		return schema.SymbolStatusPreTrading, true
+	case "Closed":
+		return "", false
+	default:
+		return schema.SymbolStatusHalt, true
+	}
+}
+
+// decimalPlaces 根据步长计算小数位数，如 "0.001" -> 3
+func decimalPlaces(step string) int {
+	d, err := decimal.NewFromString(step)
+	if err != nil || d.Exponent() >= 0 {
+		return 0
+	}
+	return int(-d.Exponent())
+}
@@ -2,127 +2,232 @@ package spot
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
 	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
-
-	"github.com/shopspring/decimal"
 )
 
 const (
 	wsURL = "wss://stream.bybit.com/v5/public/spot"
+
+	topicKlinePrefix  = "kline." // kline.{interval}.{symbol}，周期见 klineIntervals
+	topicTradePrefix  = "publicTrade."
+	topicBBOPrefix    = "orderbook.1."  // 最优一档，每次推送均为快照
+	topicTickerPrefix = "tickers."      // 24小时行情，现货每次推送均为快照
+	topicDepthPrefix  = "orderbook.50." // 首次推送50档快照，之后20ms增量推送
+
+	// Bybit 建议每20秒发送一次 {"op":"ping"} 保持连接
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 单条订阅消息的最大 topic 数
+	maxArgsPerRequest = 10
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
 )
 
+type bybitMessage struct {
+	Op   string   `json:"op"`
+	Args []string `json:"args,omitempty"`
+}
+
+// bybitTradeData publicTrade topic 推送数据
+type bybitTradeData struct {
+	Ts      int64  `json:"T"`
+	Symbol  string `json:"s"`
+	Side    string `json:"S"` // 主动成交方向 Buy/Sell
+	Size    string `json:"v"`
+	Price   string `json:"p"`
+	TradeId string `json:"i"`
+}
+
+// bybitBookData 是 orderbook 频道单条推送数据
+type bybitBookData struct {
+	Symbol string     `json:"s"`
+	Bids   [][]string `json:"b"` // [价格, 数量]
+	Asks   [][]string `json:"a"`
+	U      int64      `json:"u"`   // 更新ID，增量连续递增，u=1 表示服务重启后的快照
+	Seq    int64      `json:"seq"` // 跨序列号，单调递增
+}
+
+// klineIntervals schema.Interval 到 Bybit K线周期的映射，分钟数表示，日线为 D
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1",
+	schema.Interval3m:  "3",
+	schema.Interval5m:  "5",
+	schema.Interval15m: "15",
+	schema.Interval30m: "30",
+	schema.Interval1h:  "60",
+	schema.Interval4h:  "240",
+	schema.Interval1d:  "D",
+}
+
+type bybitKlineData struct {
+	Start     int64  `json:"start"`
+	End       int64  `json:"end"`
+	Interval  string `json:"interval"`
+	Open      string `json:"open"`
+	Close     string `json:"close"`
+	High      string `json:"high"`
+	Low       string `json:"low"`
+	Volume    string `json:"volume"`
+	Turnover  string `json:"turnover"`
+	Confirm   bool   `json:"confirm"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// orderBook 本地订单簿
+type orderBook struct {
+	updateId int64
+	seq      int64
+	bids     map[string]decimal.Decimal // price -> quantity
+	asks     map[string]decimal.Decimal // price -> quantity
+}
+
 type SpotWS struct {
-	conn interfaces.WSConn
-	mu   sync.RWMutex
-	subs interfaces.SubscriptionManager
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+
+	// per-symbol local order books
+	orderBooks map[string]*orderBook
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewSpotWS(subs interfaces.SubscriptionManager) *SpotWS {
+func NewSpotWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *SpotREST) *SpotWS {
+	d := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &SpotWS{
-		subs: subs,
+		dialer:     d,
+		cache:      c,
+		gaps:       cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:       subs,
+		orderBooks: make(map[string]*orderBook),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 }
 
 func (s *SpotWS) Connect(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.conn != nil {
+		s.mu.Unlock()
 		logger.Info("Bybit Spot WS 已连接，跳过连接")
 		return nil
 	}
 
 	logger.Info("Bybit Spot WS 开始连接...")
+	conn, _, err := s.dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		s.mu.Unlock()
+		logger.Error("Bybit Spot WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024)
+	s.conn = conn
+	s.mu.Unlock()
 
-	// 这里应该实现实际的WebSocket连接逻辑
-	// 由于没有具体的连接实现，我们只是记录日志
-	logger.Info("Bybit Spot WS 连接成功")
+	now := time.Now()
+	s.healthMu.Lock()
+	s.lastMessage = now
+	s.lastPing = now
+	s.healthMu.Unlock()
 
-	// 启动消息读取
-	go s.StartReading(ctx)
+	logger.Info("Bybit Spot WS 连接成功")
 
+	// 重连时自动恢复订阅
+	_ = s.applySubscriptions(ctx)
 	return nil
 }
 
 func (s *SpotWS) Close() error {
+	s.cancel()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.conn != nil {
-		return s.conn.Close()
+		err := s.conn.Close()
+		s.conn = nil
+		return err
 	}
 	return nil
 }
 
-func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
 	}
 
-	// 固定订阅1m K线数据
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+	newlyAdded := s.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
 	if len(newlyAdded) == 0 {
 		logger.Info("Bybit Spot WS 所有币对都已订阅 kline，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("Bybit Spot WS 新增订阅 kline: %v (固定1m)", newlyAdded)
+	logger.Info("Bybit Spot WS 新增订阅 kline: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("Bybit Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
-
-	// 构建并发送 kline 订阅消息
-	return s.sendKlineSubscription(conn, newlyAdded)
+	return s.sendTopics(ctx, "subscribe", buildKlineTopics(newlyAdded))
 }
 
-func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
-	}
-
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("Bybit Spot WS 所有币对都已退订 kline，跳过退订请求")
+// UnsubscribeKline 只退订K线 topic 的指定周期，未指定周期时退订全部周期
+func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都未订阅 kline，跳过退订请求")
 		return nil
 	}
 
-	logger.Info("Bybit Spot WS 退订 kline: %v", actuallyRemoved)
+	logger.Info("Bybit Spot WS 退订 kline: %v", removed)
+	s.gaps.Forget(removed)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("Bybit Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendKlineUnsubscription(conn, actuallyRemoved)
+	return s.sendTopics(ctx, "unsubscribe", buildKlineTopics(removed))
 }
 
 func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
-	}
-
-	// Subscribe only depth data
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+	newlyAdded := s.subs.SubscribeDepthSymbols(upperSymbols(symbols))
 	if len(newlyAdded) == 0 {
 		logger.Info("Bybit Spot WS 所有币对都已订阅 depth，跳过订阅请求")
 		return nil
@@ -130,247 +235,759 @@ func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
 
 	logger.Info("Bybit Spot WS 新增订阅 depth: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("Bybit Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
-
-	// 构建并发送 depth 订阅消息
-	return s.sendDepthSubscription(conn, newlyAdded)
+	return s.sendTopics(ctx, "subscribe", buildTopics(topicDepthPrefix, newlyAdded))
 }
 
 func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+	return s.unsubscribe(ctx, symbols, "depth")
+}
+
+func (s *SpotWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
 	}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("Bybit Spot WS 所有币对都已退订 depth，跳过退订请求")
+	logger.Info("Bybit Spot WS 新增订阅 trades: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("Bybit Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendTopics(ctx, "subscribe", buildTopics(topicTradePrefix, newlyAdded))
+}
 
-	logger.Info("Bybit Spot WS 退订 depth: %v", actuallyRemoved)
+func (s *SpotWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	logger.Info("Bybit Spot WS 退订 trades: %v", removed)
+
+	if !s.isConnected() {
 		logger.Warn("Bybit Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendDepthUnsubscription(conn, actuallyRemoved)
+	return s.sendTopics(ctx, "unsubscribe", buildTopics(topicTradePrefix, removed))
 }
 
-func (s *SpotWS) SendMessage(ctx context.Context, message interface{}) error {
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-
-	if conn == nil {
-		return fmt.Errorf("WebSocket connection not established")
+func (s *SpotWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都已订阅 bbo，跳过订阅请求")
+		return nil
 	}
 
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("Bybit Spot WS SendMessage: %s", string(jsonData))
+	logger.Info("Bybit Spot WS 新增订阅 bbo: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("Bybit Spot WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
 	}
-	return conn.WriteJSON(message)
+	return s.sendTopics(ctx, "subscribe", buildTopics(topicBBOPrefix, newlyAdded))
 }
 
-func (s *SpotWS) sendKlineSubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []string
-	for _, sym := range symbols {
-		args = append(args, fmt.Sprintf("kline.1m.%s", sym))
+func (s *SpotWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都未订阅 bbo，跳过退订请求")
+		return nil
 	}
-	if len(args) == 0 {
+
+	logger.Info("Bybit Spot WS 退订 bbo: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("Bybit Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return conn.WriteJSON(map[string]any{"op": "subscribe", "args": args})
+	return s.sendTopics(ctx, "unsubscribe", buildTopics(topicBBOPrefix, removed))
 }
 
-func (s *SpotWS) sendKlineUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []string
-	for _, sym := range symbols {
-		args = append(args, fmt.Sprintf("kline.1.%s", sym))
+func (s *SpotWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都已订阅 tickers，跳过订阅请求")
+		return nil
 	}
-	if len(args) == 0 {
+
+	logger.Info("Bybit Spot WS 新增订阅 tickers: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("Bybit Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
-	return conn.WriteJSON(map[string]any{"op": "unsubscribe", "args": args})
+	return s.sendTopics(ctx, "subscribe", buildTopics(topicTickerPrefix, newlyAdded))
 }
 
-func (s *SpotWS) sendDepthSubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []string
-	for _, sym := range symbols {
-		args = append(args, fmt.Sprintf("orderbook.50.%s", sym))
+func (s *SpotWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都未订阅 tickers，跳过退订请求")
+		return nil
 	}
-	if len(args) == 0 {
+
+	logger.Info("Bybit Spot WS 退订 tickers: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("Bybit Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return conn.WriteJSON(map[string]any{"op": "subscribe", "args": args})
+	return s.sendTopics(ctx, "unsubscribe", buildTopics(topicTickerPrefix, removed))
 }
 
-func (s *SpotWS) sendDepthUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []string
-	for _, sym := range symbols {
-		args = append(args, fmt.Sprintf("orderbook.50.%s", sym))
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 orderbook topic
+func (s *SpotWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(s.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("Bybit Spot WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
 	}
-	if len(args) == 0 {
+
+	logger.Info("Bybit Spot WS 退订 %s: %v", kind, removed)
+
+	s.mu.Lock()
+	for _, symbol := range removed {
+		delete(s.orderBooks, symbol)
+	}
+	s.mu.Unlock()
+
+	if !s.isConnected() {
+		logger.Warn("Bybit Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return conn.WriteJSON(map[string]any{"op": "unsubscribe", "args": args})
+
+	topics := append(buildKlineTopics(klines), buildTopics(topicDepthPrefix, removed)...)
+	return s.sendTopics(ctx, "unsubscribe", topics)
 }
 
-func (s *SpotWS) StartReading(ctx context.Context) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
-		}
-		s.mu.RLock()
-		conn := s.conn
-		s.mu.RUnlock()
-		if conn == nil {
-			time.Sleep(200 * time.Millisecond)
-			continue
+// SendMessage sends a message to WebSocket server
+func (s *SpotWS) SendMessage(ctx context.Context, message interface{}) error {
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		return errors.New("WebSocket connection not established")
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("Bybit Spot WS 序列化消息失败: %v", err)
+		return err
+	}
+
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		logger.Error("Bybit Spot WS 发送消息失败: %v", err)
+		return err
+	}
+
+	logger.Info("Bybit Spot WS SendMessage: %s", string(data))
+	return nil
+}
+
+// sendTopics 按 maxArgsPerRequest 分批发送订阅/退订请求
+func (s *SpotWS) sendTopics(ctx context.Context, op string, topics []string) error {
+	for start := 0; start < len(topics); start += maxArgsPerRequest {
+		end := start + maxArgsPerRequest
+		if end > len(topics) {
+			end = len(topics)
 		}
-		var msg json.RawMessage
-		if err := conn.ReadJSON(&msg); err != nil {
-			time.Sleep(300 * time.Millisecond)
-			continue
+		if err := s.SendMessage(ctx, &bybitMessage{Op: op, Args: topics[start:end]}); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		// 检查是否是 ping 消息
-		if s.handlePingMessage(msg, conn) {
-			continue
-		}
+func (s *SpotWS) applySubscriptions(ctx context.Context) error {
+	topics := append(buildKlineTopics(s.subs.GetKlineSubscriptions()), buildTopics(topicDepthPrefix, s.subs.GetDepthSymbols())...)
+	topics = append(topics, buildTopics(topicTradePrefix, s.subs.GetTradeSymbols())...)
+	topics = append(topics, buildTopics(topicBBOPrefix, s.subs.GetBookTickerSymbols())...)
+	topics = append(topics, buildTopics(topicTickerPrefix, s.subs.GetTickerSymbols())...)
+	if len(topics) == 0 {
+		logger.Info("Bybit Spot WS 无订阅")
+		return nil
+	}
+	return s.sendTopics(ctx, "subscribe", topics)
+}
 
-		var raw map[string]any
-		if err := json.Unmarshal(msg, &raw); err != nil {
-			continue
+// resubscribeDepth 丢弃本地订单簿并重新订阅 orderbook topic，服务端会重新推送全量快照
+func (s *SpotWS) resubscribeDepth(symbol string) {
+	s.mu.Lock()
+	delete(s.orderBooks, symbol)
+	s.mu.Unlock()
+
+	topic := []string{topicDepthPrefix + symbol}
+	if err := s.sendTopics(s.ctx, "unsubscribe", topic); err != nil {
+		logger.Error("Bybit Spot WS 退订深度失败 %s: %v", symbol, err)
+		return
+	}
+	if err := s.sendTopics(s.ctx, "subscribe", topic); err != nil {
+		logger.Error("Bybit Spot WS 重新订阅深度失败 %s: %v", symbol, err)
+	}
+}
+
+// buildKlineTopics 按币对和周期构建K线 topic，如 kline.60.BTCUSDT
+func buildKlineTopics(subs []schema.KlineSubscription) []string {
+	topics := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		topics = append(topics, topicKlinePrefix+klineIntervals[sub.Interval]+"."+sub.Symbol)
+	}
+	return topics
+}
+
+// parseKlineTopic 由K线 topic 解析币对和周期
+func parseKlineTopic(topic string) (string, schema.Interval, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(topic, topicKlinePrefix), ".", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	for interval, v := range klineIntervals {
+		if v == parts[0] {
+			return parts[1], interval, true
 		}
-		topic, _ := raw["topic"].(string)
-		if strings.HasPrefix(topic, "kline.") {
-			var d struct {
-				Data []struct {
-					Start    int64  `json:"start"`
-					End      int64  `json:"end"`
-					Interval string `json:"interval"`
-					Open     string `json:"open"`
-					High     string `json:"high"`
-					Low      string `json:"low"`
-					Close    string `json:"close"`
-					Volume   string `json:"volume"`
-					Symbol   string `json:"symbol"`
-					Confirm  bool   `json:"confirm"`
-				} `json:"data"`
-			}
-			b, _ := json.Marshal(raw)
-			_ = json.Unmarshal(b, &d)
-			for _, it := range d.Data {
-				o := decimal.RequireFromString(it.Open)
-				h := decimal.RequireFromString(it.High)
-				l := decimal.RequireFromString(it.Low)
-				c := decimal.RequireFromString(it.Close)
-				v := decimal.RequireFromString(it.Volume)
-				k := schema.Kline{
-					Exchange:  schema.BYBIT,
-					Market:    schema.SPOT,
-					Symbol:    it.Symbol,
-					Interval:  schema.Interval(it.Interval),
-					OpenTime:  time.UnixMilli(it.Start),
-					CloseTime: time.UnixMilli(it.End),
-					Open:      o,
-					High:      h,
-					Low:       l,
-					Close:     c,
-					Volume:    v,
-					IsFinal:   it.Confirm,
-				}
-				// Note: Since DataReader interface doesn't have AppendKline method,
-				// we'll need to handle this differently or extend the interface
-				logger.Info("Bybit Spot WS 收到Kline数据: %+v", k)
-			}
-		} else if strings.HasPrefix(topic, "orderbook.") {
-			// Handle depth data
-			logger.Info("Bybit Spot WS 收到Depth数据: %s", topic)
+	}
+	return "", "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for bybit", interval)
 		}
 	}
+	return nil
 }
 
-// handlePingMessage 检查并处理 ping 消息
-func (s *SpotWS) handlePingMessage(data json.RawMessage, conn interfaces.WSConn) bool {
-	// Bybit 使用 {"op": "ping"} 作为心跳
-	var msg map[string]interface{}
-	if err := json.Unmarshal(data, &msg); err == nil {
-		if op, hasOp := msg["op"]; hasOp && op == "ping" {
-			logger.Info("Bybit WS 收到 ping 消息")
-			// 发送 {"op": "pong"} 响应
-			pongResponse := map[string]interface{}{
-				"op": "pong",
-			}
-			if err := conn.WriteJSON(pongResponse); err != nil {
-				logger.Error("Bybit WS 发送 pong 失败: %v", err)
-			}
-			return true
+func buildTopics(prefix string, symbols []string) []string {
+	topics := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		topics = append(topics, prefix+symbol)
+	}
+	return topics
+}
+
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
 		}
+		seen[s] = struct{}{}
+		out = append(out, s)
 	}
-	return false
+	return out
 }
 
-// HandlePing 处理接收到的 ping（实现接口）
-func (s *SpotWS) HandlePing(data []byte) error {
-	logger.Info("Bybit WS HandlePing called")
+func (s *SpotWS) isConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
+func (s *SpotWS) StartReading(ctx context.Context) error {
+	logger.Info("Bybit Spot WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Bybit Spot WS 上下文取消")
+				return
+			case <-s.ctx.Done():
+				logger.Info("Bybit Spot WS 已关闭")
+				return
+			default:
+			}
 
-	// 检查是否是 Bybit ping 消息
-	var msg map[string]interface{}
-	if err := json.Unmarshal(data, &msg); err == nil {
-		if op, hasOp := msg["op"]; hasOp && op == "ping" {
-			// 发送 pong 响应
 			s.mu.RLock()
 			conn := s.conn
 			s.mu.RUnlock()
 
 			if conn == nil {
-				return fmt.Errorf("WebSocket not connected")
+				time.Sleep(500 * time.Millisecond)
+				continue
 			}
 
-			pongResponse := map[string]interface{}{
-				"op": "pong",
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if s.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("Bybit Spot WS 读取消息失败: %v", err)
+				s.reconnect(ctx)
+				continue
 			}
-			return conn.WriteJSON(pongResponse)
+
+			s.healthMu.Lock()
+			s.lastMessage = time.Now()
+			s.healthMu.Unlock()
+
+			s.handleRawMessage(message)
 		}
-	}
+	}()
 
 	return nil
 }
 
-// SendPing sends ping to server (if supported by exchange)
+func (s *SpotWS) handleRawMessage(message []byte) {
+	logger.Debug("Bybit Spot WS 收到原始消息: %s", string(message))
+
+	var msg struct {
+		// 操作响应
+		Op      string `json:"op"`
+		Success *bool  `json:"success"`
+		RetMsg  string `json:"ret_msg"`
+		// 数据推送
+		Topic string          `json:"topic"`
+		Type  string          `json:"type"`
+		Ts    int64           `json:"ts"`
+		Data  json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("Bybit Spot WS 解析原始消息失败: %v", err)
+		return
+	}
+
+	if msg.Op != "" {
+		switch {
+		case msg.Op == "ping" || msg.Op == "pong":
+			logger.Debug("Bybit Spot WS 收到 pong")
+		case msg.Success != nil && !*msg.Success:
+			logger.Error("Bybit Spot WS %s 失败: %s", msg.Op, msg.RetMsg)
+		default:
+			logger.Info("Bybit Spot WS %s 成功", msg.Op)
+		}
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(msg.Topic, topicKlinePrefix):
+		symbol, interval, ok := parseKlineTopic(msg.Topic)
+		if !ok {
+			logger.Warn("Bybit Spot WS 未知K线 topic: %s", msg.Topic)
+			return
+		}
+		s.handleKline(symbol, interval, msg.Data)
+	case strings.HasPrefix(msg.Topic, topicTradePrefix):
+		s.handleTrade(msg.Data)
+	case strings.HasPrefix(msg.Topic, topicBBOPrefix):
+		s.handleBBO(msg.Type, msg.Ts, msg.Data)
+	case strings.HasPrefix(msg.Topic, topicTickerPrefix):
+		s.handleTicker(msg.Type, msg.Ts, msg.Data)
+	case strings.HasPrefix(msg.Topic, topicDepthPrefix):
+		s.handleDepth(strings.TrimPrefix(msg.Topic, topicDepthPrefix), msg.Type, msg.Ts, msg.Data)
+	default:
+		logger.Debug("Bybit Spot WS 未知 topic: %s", msg.Topic)
+	}
+}
+
+// handleTrade 处理公共成交，S 为主动成交方向
+func (s *SpotWS) handleTrade(data json.RawMessage) {
+	var rows []bybitTradeData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Bybit Spot WS 解析publicTrade失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Price)
+		size, _ := decimal.NewFromString(row.Size)
+		quantity := size
+		quoteQty := price.Mul(size)
+
+		side := schema.OrderSideBuy
+		if row.Side == "Sell" {
+			side = schema.OrderSideSell
+		}
+
+		s.cache.AddTrade(schema.Trade{
+			Exchange:  schema.BYBIT,
+			Market:    schema.SPOT,
+			Symbol:    row.Symbol,
+			TradeID:   row.TradeId,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(row.Ts),
+		})
+	}
+}
+
+// handleBBO 处理最优一档推送；增量推送只包含变化的一侧，另一侧沿用缓存中的值
+func (s *SpotWS) handleBBO(msgType string, ts int64, data json.RawMessage) {
+	var book bybitBookData
+	if err := json.Unmarshal(data, &book); err != nil {
+		logger.Error("Bybit Spot WS 解析bbo失败: %v", err)
+		return
+	}
+
+	bt := schema.BookTicker{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: book.Symbol}
+	if msgType == "delta" {
+		if cached, ok := s.cache.GetBookTicker(schema.BYBIT, schema.SPOT, book.Symbol); ok {
+			bt = cached
+		}
+	}
+	if len(book.Bids) > 0 && len(book.Bids[0]) >= 2 {
+		price, _ := decimal.NewFromString(book.Bids[0][0])
+		qty, _ := decimal.NewFromString(book.Bids[0][1])
+		bt.BidPrice, bt.BidQty = price, qty
+	}
+	if len(book.Asks) > 0 && len(book.Asks[0]) >= 2 {
+		price, _ := decimal.NewFromString(book.Asks[0][0])
+		qty, _ := decimal.NewFromString(book.Asks[0][1])
+		bt.AskPrice, bt.AskQty = price, qty
+	}
+	bt.UpdatedAt = time.UnixMilli(ts)
+	bt.LastUpdateId = fmt.Sprintf("%d", book.U)
+
+	s.cache.SetBookTicker(bt)
+}
+
+// handleTicker 处理24小时行情推送，增量推送与缓存中的最新值合并
+func (s *SpotWS) handleTicker(msgType string, ts int64, data json.RawMessage) {
+	var d schema.BybitTicker
+	if err := json.Unmarshal(data, &d); err != nil {
+		logger.Error("Bybit Spot WS 解析tickers失败: %v", err)
+		return
+	}
+
+	t := schema.Ticker{Exchange: schema.BYBIT, Market: schema.SPOT, Symbol: d.Symbol}
+	if msgType == "delta" {
+		if cached, ok := s.cache.GetTicker(schema.BYBIT, schema.SPOT, d.Symbol); ok {
+			t = cached
+		}
+	}
+	t = mergeTicker(t, d)
+	t.Timestamp = time.UnixMilli(ts)
+
+	s.cache.SetTicker(t)
+}
+
+func (s *SpotWS) handleKline(symbol string, interval schema.Interval, data json.RawMessage) {
+	var rows []bybitKlineData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Bybit Spot WS 解析kline失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		open, _ := decimal.NewFromString(row.Open)
+		high, _ := decimal.NewFromString(row.High)
+		low, _ := decimal.NewFromString(row.Low)
+		close, _ := decimal.NewFromString(row.Close)
+		volume, _ := decimal.NewFromString(row.Volume)
+		quoteVolume, _ := decimal.NewFromString(row.Turnover)
+
+		s.gaps.SetKline(schema.Kline{
+			Exchange:    schema.BYBIT,
+			Market:      schema.SPOT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    time.UnixMilli(row.Start),
+			CloseTime:   time.UnixMilli(row.End),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row.Confirm,
+			EventTime:   time.UnixMilli(row.Timestamp),
+		})
+	}
+}
+
+func (s *SpotWS) handleDepth(symbol, msgType string, ts int64, data json.RawMessage) {
+	var book bybitBookData
+	if err := json.Unmarshal(data, &book); err != nil {
+		logger.Error("Bybit Spot WS 解析depth失败: %v", err)
+		return
+	}
+
+	switch msgType {
+	case "snapshot":
+		// 收到快照（包括服务重启后 u=1 的快照）时重置本地订单簿
+		s.mu.Lock()
+		ob := &orderBook{
+			updateId: book.U,
+			seq:      book.Seq,
+			bids:     make(map[string]decimal.Decimal),
+			asks:     make(map[string]decimal.Decimal),
+		}
+		applyLevels(ob.bids, book.Bids)
+		applyLevels(ob.asks, book.Asks)
+		s.orderBooks[symbol] = ob
+		s.mu.Unlock()
+		logger.Info("Bybit Spot WS %s 深度快照已加载: u=%d, seq=%d, 买单%d档, 卖单%d档",
+			symbol, book.U, book.Seq, len(book.Bids), len(book.Asks))
+	case "delta":
+		s.mu.Lock()
+		ob := s.orderBooks[symbol]
+		if ob == nil {
+			// 尚未收到快照，等待快照
+			s.mu.Unlock()
+			return
+		}
+		if book.U != ob.updateId+1 || book.Seq < ob.seq {
+			s.mu.Unlock()
+			logger.Warn("Bybit Spot WS %s 更新不连续: u=%d, seq=%d, 本地u=%d, 本地seq=%d，重新订阅",
+				symbol, book.U, book.Seq, ob.updateId, ob.seq)
+			s.resubscribeDepth(symbol)
+			return
+		}
+		applyLevels(ob.bids, book.Bids)
+		applyLevels(ob.asks, book.Asks)
+		ob.updateId = book.U
+		ob.seq = book.Seq
+		s.mu.Unlock()
+	default:
+		logger.Warn("Bybit Spot WS 未知深度类型: %s", msgType)
+		return
+	}
+
+	if depth := s.buildDepthFromOrderBook(symbol, ts); depth != nil {
+		s.cache.SetDepth(*depth)
+	}
+}
+
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]decimal.Decimal, levels [][]string) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
+		}
+		price, err := decimal.NewFromString(lv[0])
+		if err != nil {
+			continue
+		}
+		qty, err := decimal.NewFromString(lv[1])
+		if err != nil {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := price.String()
+		if qty.IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = qty
+		}
+	}
+}
+
+// buildDepthFromOrderBook 将本地订单簿排序裁剪为 schema.Depth
+func (s *SpotWS) buildDepthFromOrderBook(symbol string, ts int64) *schema.Depth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ob := s.orderBooks[symbol]
+	if ob == nil {
+		return nil
+	}
+
+	bids := make([]schema.PriceLevel, 0, len(ob.bids))
+	for priceStr, qty := range ob.bids {
+		price, _ := decimal.NewFromString(priceStr)
+		bids = append(bids, schema.PriceLevel{Price: price, Quantity: qty})
+	}
+	asks := make([]schema.PriceLevel, 0, len(ob.asks))
+	for priceStr, qty := range ob.asks {
+		price, _ := decimal.NewFromString(priceStr)
+		asks = append(asks, schema.PriceLevel{Price: price, Quantity: qty})
+	}
+
+	// 排序：买单降序，卖单升序
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	if len(bids) > maxDepthLevels {
+		bids = bids[:maxDepthLevels]
+	}
+	if len(asks) > maxDepthLevels {
+		asks = asks[:maxDepthLevels]
+	}
+
+	updatedAt := time.Now()
+	if ts > 0 {
+		updatedAt = time.UnixMilli(ts)
+	}
+
+	return &schema.Depth{
+		Exchange:     schema.BYBIT,
+		Market:       schema.SPOT,
+		Symbol:       symbol,
+		Bids:         bids,
+		Asks:         asks,
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", ob.updateId),
+	}
+}
+
+// HandlePing 处理服务端的 ping 帧
+func (s *SpotWS) HandlePing(data []byte) error {
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
+
+// SendPing 发送 Bybit 心跳 {"op":"ping"}，服务端回复 op 为 ping 的 pong 响应
 func (s *SpotWS) SendPing(ctx context.Context) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
 
-	if conn != nil {
-		// Bybit 使用 {"op": "ping"} 作为心跳
-		pingMsg := map[string]interface{}{
-			"op": "ping",
-		}
-		return conn.WriteJSON(pingMsg)
+	data, _ := json.Marshal(&bybitMessage{Op: "ping"})
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("WebSocket connection not established")
+
+	s.healthMu.Lock()
+	s.lastPing = time.Now()
+	s.healthMu.Unlock()
+	return nil
 }
 
-// StartHealthCheck starts connection health monitoring
+// StartHealthCheck 定时发送心跳，超时未收到消息则重连
 func (s *SpotWS) StartHealthCheck(ctx context.Context) error {
-	// Bybit 使用内置的 ping-pong 机制，这里不需要额外的健康检查
-	// 心跳处理已经在 StartReading 中实现
-	return nil
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Bybit Spot WS 健康检查停止")
+			return nil
+		case <-s.ctx.Done():
+			logger.Info("Bybit Spot WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			s.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) checkConnectionHealth(ctx context.Context) {
+	if !s.isConnected() {
+		return
+	}
+
+	s.healthMu.RLock()
+	sinceMsg := time.Since(s.lastMessage)
+	sincePing := time.Since(s.lastPing)
+	s.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("Bybit Spot WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		s.reconnect(ctx)
+		return
+	}
+
+	// 数据推送不能替代心跳，Bybit 要求定期发送 ping
+	if sincePing > pingInterval {
+		if err := s.SendPing(ctx); err != nil {
+			logger.Warn("Bybit Spot WS ping失败: %v", err)
+			s.reconnect(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) reconnect(ctx context.Context) {
+	s.reconnectMu.Lock()
+	if s.reconnecting {
+		s.reconnectMu.Unlock()
+		return
+	}
+	s.reconnecting = true
+	s.reconnectMu.Unlock()
+
+	defer func() {
+		s.reconnectMu.Lock()
+		s.reconnecting = false
+		s.reconnectMu.Unlock()
+	}()
+
+	s.mu.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	// 重新订阅后服务端会推送新的全量快照
+	s.orderBooks = make(map[string]*orderBook)
+	s.mu.Unlock()
+
+	for {
+		s.reconnectMu.Lock()
+		s.reconnectCount++
+		reconnectCount := s.reconnectCount
+		s.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("Bybit Spot WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := s.Connect(ctx); err != nil {
+			logger.Error("Bybit Spot WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("Bybit Spot WS 重连成功")
+		// 补齐断线期间缺失的K线
+		s.gaps.Backfill(ctx, s.subs.GetKlineSubscriptions())
+		s.reconnectMu.Lock()
+		s.reconnectCount = 0
+		s.reconnectMu.Unlock()
+		return
+	}
 }
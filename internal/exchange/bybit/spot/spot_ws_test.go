@@ -0,0 +1,124 @@
+package spot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestHandleDepth_SnapshotAndDelta(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	push := func(bids, asks [][]string, u, seq int64) json.RawMessage {
+		data, _ := json.Marshal(bybitBookData{Symbol: "BTCUSDT", Bids: bids, Asks: asks, U: u, Seq: seq})
+		return data
+	}
+
+	s.handleDepth("BTCUSDT", "snapshot", 1700000000000, push(
+		[][]string{{"100", "1"}, {"99", "2"}}, [][]string{{"101", "3"}}, 5, 50))
+	s.handleDepth("BTCUSDT", "delta", 1700000000100, push(
+		[][]string{{"100", "0"}}, [][]string{{"100.5", "1"}}, 6, 51))
+
+	d, ok := c.GetDepth(schema.BYBIT, schema.SPOT, "BTCUSDT")
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	if len(d.Bids) != 1 || d.Bids[0].Price.String() != "99" {
+		t.Fatalf("unexpected bids: %+v", d.Bids)
+	}
+	if len(d.Asks) != 2 || d.Asks[0].Price.String() != "100.5" {
+		t.Fatalf("unexpected asks: %+v", d.Asks)
+	}
+
+	// u 不连续时丢弃本地订单簿，等待重新订阅后的快照
+	s.handleDepth("BTCUSDT", "delta", 1700000000200, push(nil, [][]string{{"102", "1"}}, 8, 53))
+	if _, ok := s.orderBooks["BTCUSDT"]; ok {
+		t.Fatalf("order book should be dropped after update id gap")
+	}
+}
+
+func TestHandleTrade(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"topic":"publicTrade.BTCUSDT","type":"snapshot","ts":1672304486868,"data":[{"T":1672304486865,"s":"BTCUSDT","S":"Buy","v":"0.001","p":"16578.50","L":"PlusTick","i":"20f43950-d8dd-5b31-9112-a178eb6023af","BT":false}]}`))
+
+	trades, ok := c.GetTrades(schema.BYBIT, schema.SPOT, "BTCUSDT", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	tr := trades[0]
+	if tr.Side != schema.OrderSideBuy || tr.QuoteQty.String() != "16.5785" || tr.TradeID != "20f43950-d8dd-5b31-9112-a178eb6023af" {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+func TestHandleBBO(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"topic":"orderbook.1.BTCUSDT","type":"snapshot","ts":1672304484978,"data":{"s":"BTCUSDT","b":[["16493.50","0.006"]],"a":[["16611.00","0.029"]],"u":18521288,"seq":7961638724}}`))
+
+	bt, ok := c.GetBookTicker(schema.BYBIT, schema.SPOT, "BTCUSDT")
+	if !ok {
+		t.Fatalf("book ticker not cached")
+	}
+	if bt.BidPrice.String() != "16493.5" || bt.AskQty.String() != "0.029" || bt.LastUpdateId != "18521288" {
+		t.Fatalf("unexpected book ticker: %+v", bt)
+	}
+
+	// 增量只包含变化的一侧，另一侧沿用之前的值
+	s.handleRawMessage([]byte(`{"topic":"orderbook.1.BTCUSDT","type":"delta","ts":1672304484990,"data":{"s":"BTCUSDT","b":[],"a":[["16610.00","1.5"]],"u":18521289,"seq":7961638725}}`))
+
+	bt, _ = c.GetBookTicker(schema.BYBIT, schema.SPOT, "BTCUSDT")
+	if bt.BidPrice.String() != "16493.5" || bt.AskPrice.String() != "16610" || bt.AskQty.String() != "1.5" || bt.LastUpdateId != "18521289" {
+		t.Fatalf("unexpected book ticker after delta: %+v", bt)
+	}
+}
+
+func TestHandleTicker(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"topic":"tickers.BTCUSDT","ts":1673853746003,"type":"snapshot","cs":2588407389,"data":{"symbol":"BTCUSDT","lastPrice":"21109.77","highPrice24h":"21426.99","lowPrice24h":"20575","prevPrice24h":"20704.93","volume24h":"6780.866843","turnover24h":"141946527.22907118","price24hPcnt":"0.0196","usdIndexPrice":"21120.2400136"}}`))
+
+	tk, ok := c.GetTicker(schema.BYBIT, schema.SPOT, "BTCUSDT")
+	if !ok {
+		t.Fatalf("ticker not cached")
+	}
+	if tk.Price.String() != "21109.77" || tk.Open.String() != "20704.93" || tk.Low.String() != "20575" ||
+		tk.Volume.String() != "6780.866843" || tk.Timestamp.UnixMilli() != 1673853746003 {
+		t.Fatalf("unexpected ticker: %+v", tk)
+	}
+
+	// 增量只包含变化的字段，其余字段沿用之前的值
+	s.handleRawMessage([]byte(`{"topic":"tickers.BTCUSDT","ts":1673853746103,"type":"delta","cs":2588407390,"data":{"symbol":"BTCUSDT","lastPrice":"21110"}}`))
+
+	tk, _ = c.GetTicker(schema.BYBIT, schema.SPOT, "BTCUSDT")
+	if tk.Price.String() != "21110" || tk.High.String() != "21426.99" || tk.QuoteVol.String() != "141946527.22907118" ||
+		tk.Timestamp.UnixMilli() != 1673853746103 {
+		t.Fatalf("unexpected ticker after delta: %+v", tk)
+	}
+}
+
+// K线 topic 中的周期写入缓存，如 kline.60.BTCUSDT 对应 1h
+func TestHandleKline_Interval(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"topic":"kline.60.BTCUSDT","data":[{"start":1672322400000,"end":1672325999999,"interval":"60","open":"16649.5","close":"16677","high":"16677","low":"16608","volume":"2.081","turnover":"34666.4005","confirm":false,"timestamp":1672324988882}],"ts":1672324988882,"type":"snapshot"}`))
+
+	kl, ok := c.GetKline(schema.BYBIT, schema.SPOT, "BTCUSDT", schema.Interval1h)
+	if !ok || len(kl) != 1 {
+		t.Fatalf("1h kline not cached")
+	}
+	if k := kl[0]; k.Interval != schema.Interval1h || k.OpenTime.UnixMilli() != 1672322400000 || k.Volume.String() != "2.081" {
+		t.Fatalf("unexpected kline: %+v", k)
+	}
+	if _, ok := c.GetKline(schema.BYBIT, schema.SPOT, "BTCUSDT", schema.Interval1m); ok {
+		t.Fatalf("1h kline must not be cached as 1m")
+	}
+}
@@ -3,14 +3,53 @@ package futures_coin
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 
+	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
 )
 
-const GateFuturesCoinBaseURL = "https://api.gateio.ws/api/v4"
+const (
+	GateFuturesCoinBaseURL = "https://api.gateio.ws/api/v4"
+	apiFuturesTickers      = "/futures/btc/tickers"
+	apiFuturesOrderBook    = "/futures/btc/order_book"
+	apiFuturesContracts    = "/futures/btc/contracts"
+	apiFuturesStats        = "/futures/btc/contract_stats"
+	apiFuturesCandlesticks = "/futures/btc/candlesticks"
+	apiSpotTime            = "/spot/time" // 服务器时间，现货与合约共用
+)
+
+// gateLevel 合约深度档位，s 为张数
+type gateLevel struct {
+	P string          `json:"p"`
+	S decimal.Decimal `json:"s"`
+}
+
+// gateOrderBook 是 /futures/btc/order_book?with_id=true 的响应
+type gateOrderBook struct {
+	Id      int64       `json:"id"`
+	Current float64     `json:"current"` // 秒，带毫秒小数
+	Update  float64     `json:"update"`
+	Asks    []gateLevel `json:"asks"`
+	Bids    []gateLevel `json:"bids"`
+}
+
+// gateContract 合约信息
+type gateContract struct {
+	Name            string `json:"name"`
+	Type            string `json:"type"` // direct / inverse
+	OrderPriceRound string `json:"order_price_round"`
+	OrderSizeMin    int64  `json:"order_size_min"`
+	OrderSizeMax    int64  `json:"order_size_max"`
+	InDelisting     bool   `json:"in_delisting"`
+	Status          string `json:"status"` // trading / delisting / delisted / circuit_breaker
+}
 
 // FuturesCoinREST implements RESTClient for Gate Coin-margined Futures.
 type FuturesCoinREST struct {
@@ -19,34 +58,406 @@ type FuturesCoinREST struct {
 
 func NewFuturesCoinREST() *FuturesCoinREST {
 	return &FuturesCoinREST{
-		http: resty.New().SetBaseURL(GateFuturesCoinBaseURL),
+		http: resty.New().SetBaseURL(GateFuturesCoinBaseURL).SetTimeout(10 * time.Second),
 	}
 }
 
+// GetTicker 获取单个合约的24小时行情
 func (f *FuturesCoinREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
-	// TODO: Implement Gate futures coin ticker
-	return schema.Ticker{}, errors.New("not implemented")
+	tickers, err := f.tickers(ctx, symbol)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	if len(tickers) == 0 {
+		return schema.Ticker{}, errors.New("no ticker data")
+	}
+	return tickers[0], nil
+}
+
+// GetTickers 获取全部合约的24小时行情
+func (f *FuturesCoinREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	return f.tickers(ctx, "")
 }
 
+// tickers 请求24小时行情，指定合约时接口同样返回数组；行情不带时间戳，使用本地时间
+func (f *FuturesCoinREST) tickers(ctx context.Context, symbol string) ([]schema.Ticker, error) {
+	var resp []schema.GateFuturesTickerResponse
+	req := f.http.R().SetContext(ctx).SetResult(&resp)
+	if symbol != "" {
+		req.SetQueryParam("contract", symbol)
+	}
+	r, err := req.Get(apiFuturesTickers)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+
+	logger.Debug("Gate Futures Coin Ticker 原始响应: %s", string(r.Body()))
+
+	now := time.Now()
+	out := make([]schema.Ticker, 0, len(resp))
+	for _, t := range resp {
+		out = append(out, convertTicker(t, now))
+	}
+	return out, nil
+}
+
+// convertTicker 合约行情直接提供基础币成交量和计价币成交额，无需按张数换算
+func convertTicker(t schema.GateFuturesTickerResponse, ts time.Time) schema.Ticker {
+	price, _ := decimal.NewFromString(t.Last)
+	high, _ := decimal.NewFromString(t.High24h)
+	low, _ := decimal.NewFromString(t.Low24h)
+	volume, _ := decimal.NewFromString(t.Volume24hBase)
+	quoteVolume, _ := decimal.NewFromString(t.Volume24hQuote)
+
+	return schema.Ticker{
+		Exchange:  schema.GATE,
+		Market:    schema.FUTURESCOIN,
+		Symbol:    t.Contract,
+		Price:     price,
+		High:      high,
+		Low:       low,
+		Volume:    volume,
+		QuoteVol:  quoteVolume,
+		Timestamp: ts,
+	}
+}
+
+// GetKline 获取最近 limit 根K线，按时间范围委托给 GetKlines
 func (f *FuturesCoinREST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
-	// TODO: Implement Gate futures coin kline
-	return nil, errors.New("not implemented")
+	return schema.LatestKlines(ctx, f.GetKlines, symbol, interval, limit)
 }
 
+// maxKlinesPerRequest Gate Futures Coin K线接口单次最多返回的条数，指定 from/to 时按该条数切分时间窗口
+const maxKlinesPerRequest = 2000
+
+// gateCandle 合约K线，v 为张数，sum 为计价币成交额
+type gateCandle struct {
+	T   int64           `json:"t"`
+	V   decimal.Decimal `json:"v"`
+	C   string          `json:"c"`
+	H   string          `json:"h"`
+	L   string          `json:"l"`
+	O   string          `json:"o"`
+	Sum string          `json:"sum"`
+}
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按时间窗口由旧到新分页拉取，end 为零值时取到当前时间
+func (f *FuturesCoinREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	window := time.Duration(maxKlinesPerRequest-1) * interval.Duration()
+	for from := start; !from.After(end); {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+		var resp []gateCandle
+		r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+			"contract": symbol,
+			"interval": klineIntervals[interval],
+			"from":     strconv.FormatInt(from.Unix(), 10),
+			"to":       strconv.FormatInt(to.Unix(), 10),
+		}).Get(apiFuturesCandlesticks)
+		if err != nil {
+			return nil, err
+		}
+		if r.IsError() {
+			return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+		}
+		logger.Debug("Gate Futures Coin Kline 返回 %d 条", len(resp))
+
+		out = append(out, convertKlines(resp, symbol, interval)...)
+		from = to.Add(time.Second)
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// convertKlines 转换K线数组，与 WebSocket 推送一致，成交量按收盘价由张数（USD）换算为基础币数量，成交额为张数；收盘时间未到的K线标记为未完结
+func convertKlines(rows []gateCandle, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		open, _ := decimal.NewFromString(row.O)
+		high, _ := decimal.NewFromString(row.H)
+		low, _ := decimal.NewFromString(row.L)
+		close, _ := decimal.NewFromString(row.C)
+		openTime := time.Unix(row.T, 0)
+		closeTime := openTime.Add(interval.Duration() - time.Millisecond)
+		out = append(out, schema.Kline{
+			Exchange:    schema.GATE,
+			Market:      schema.FUTURESCOIN,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   closeTime,
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      contractsToBase(row.V, close),
+			QuoteVolume: row.V,
+			IsFinal:     closeTime.Before(now),
+		})
+	}
+	return out
+}
+
+// GetDepth 获取合约深度，数量已由张数（1张=1 USD）换算为基础币数量
 func (f *FuturesCoinREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
-	// TODO: Implement Gate futures coin depth
-	return schema.Depth{}, errors.New("not implemented")
+	book, err := f.orderBook(ctx, symbol, limit)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+
+	convert := func(levels []gateLevel) []schema.PriceLevel {
+		out := make([]schema.PriceLevel, 0, len(levels))
+		for _, lv := range levels {
+			p, err := decimal.NewFromString(lv.P)
+			if err != nil {
+				continue
+			}
+			out = append(out, schema.PriceLevel{Price: p, Quantity: contractsToBase(lv.S, p)})
+		}
+		return out
+	}
+
+	return schema.Depth{
+		Exchange:     schema.GATE,
+		Market:       schema.FUTURESCOIN,
+		Symbol:       symbol,
+		Bids:         convert(book.Bids),
+		Asks:         convert(book.Asks),
+		UpdatedAt:    time.UnixMilli(int64(book.Current * 1000)),
+		LastUpdateId: strconv.FormatInt(book.Id, 10),
+	}, nil
+}
+
+// orderBook 获取带 id 的深度快照，数量单位为张，供 WebSocket 增量同步使用
+func (f *FuturesCoinREST) orderBook(ctx context.Context, contract string, limit int) (*gateOrderBook, error) {
+	var resp gateOrderBook
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"contract": contract,
+		"limit":    fmt.Sprintf("%d", limit),
+		"with_id":  "true",
+	}).Get(apiFuturesOrderBook)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	// 保存原始响应结果用于调试
+	rawResponse := string(r.Body())
+	logger.Debug("Gate Futures Coin Depth 原始响应: %s", rawResponse)
+
+	return &resp, nil
+}
+
+// contractsToBase BTC 结算合约1张=1 USD，基础币数量 = 张数 / 价格
+func contractsToBase(sz, price decimal.Decimal) decimal.Decimal {
+	if price.IsZero() {
+		return decimal.Zero
+	}
+	return sz.Div(price)
+}
+
+// serverTime 获取 Gate 服务器时间
+func (f *FuturesCoinREST) serverTime(ctx context.Context) (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"server_time"` // 毫秒
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiSpotTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if r.IsError() {
+		return time.Time{}, errors.New(r.Status())
+	}
+	return time.UnixMilli(resp.ServerTime), nil
 }
 
+// GetExchangeInfo 获取 BTC 结算合约交易规则，合约数量以 USD 张数计，最小下单张数记为最小下单金额
 func (f *FuturesCoinREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现Gate币本位合约交易规则信息获取
+	var resp []gateContract
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiFuturesContracts)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	if r.IsError() {
+		return schema.ExchangeInfo{}, errors.New(r.Status())
+	}
+	logger.Debug("Gate Futures Coin ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+	serverTime, err := f.serverTime(ctx)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+
+	symbols := make([]schema.Symbol, 0, len(resp))
+	for _, c := range resp {
+		status, ok := symbolStatus(c)
+		if !ok {
+			continue
+		}
+		base, quote, ok := splitContract(c.Name)
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       c.Name,
+			Base:         base,
+			Quote:        quote,
+			Margin:       base,
+			ExchangeName: schema.GATE,
+			MarketType:   schema.FUTURESCOIN,
+
+			QuantityPrecision: 0,
+			PricePrecision:    decimalPlaces(c.OrderPriceRound),
+			MinNotional:       fmt.Sprintf("%d", c.OrderSizeMin),
+			TickSize:          c.OrderPriceRound,
+			StepSize:          "1", // 按张下单，一张为 1 USD
+
+			Status: status,
+		})
+	}
+
+	logger.Info("Gate Futures Coin 交易规则已加载: %d 个合约", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.GATE,
 		Market:     schema.FUTURESCOIN,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: serverTime,
 		RateLimits: []schema.RateLimit{},
 		Timezone:   "UTC",
 	}, nil
 }
+
+// symbolStatus 归一化合约状态，已下架（delisted）的合约返回 false
+func symbolStatus(c gateContract) (schema.SymbolStatus, bool) {
+	switch {
+	case c.Status == "delisted":
+		return "", false
+	case c.InDelisting || c.Status == "delisting":
+		return schema.SymbolStatusBreak, true
+	case c.Status == "circuit_breaker":
+		return schema.SymbolStatusHalt, true
+	default:
+		return schema.SymbolStatusTrading, true
+	}
+}
+
+// splitContract 拆分合约名，如 "BTC_USD" -> BTC, USD
+func splitContract(name string) (base, quote string, ok bool) {
+	i := strings.LastIndex(name, "_")
+	if i <= 0 {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// decimalPlaces 根据步长计算小数位数，如 "0.001" -> 3
+func decimalPlaces(step string) int {
+	d, err := decimal.NewFromString(step)
+	if err != nil || d.Exponent() >= 0 {
+		return 0
+	}
+	return int(-d.Exponent())
+}
+
+// GetOpenInterest 获取合约当前持仓量，取自24小时行情的持仓张数
+func (f *FuturesCoinREST) GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error) {
+	var resp []schema.GateFuturesTickerResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("contract", symbol).Get(apiFuturesTickers)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	if r.IsError() {
+		return schema.OpenInterest{}, errors.New(r.Status())
+	}
+	if len(resp) == 0 {
+		return schema.OpenInterest{}, errors.New("no ticker data")
+	}
+	return convertOpenInterest(resp[0], time.Now()), nil
+}
+
+// convertOpenInterest 1张=1 USD，持仓张数即USD价值，按标记价格换算为基础币数量
+func convertOpenInterest(t schema.GateFuturesTickerResponse, ts time.Time) schema.OpenInterest {
+	size, _ := decimal.NewFromString(t.TotalSize)
+	markPrice, _ := decimal.NewFromString(t.MarkPrice)
+
+	return schema.OpenInterest{
+		Exchange:     schema.GATE,
+		Market:       schema.FUTURESCOIN,
+		Symbol:       t.Contract,
+		OpenInterest: contractsToBase(size, markPrice),
+		Value:        size,
+		Timestamp:    ts,
+	}
+}
+
+// GetOpenInterestHistory 获取合约持仓量历史，持仓张数（1张=1 USD）按标记价格换算为基础币数量
+func (f *FuturesCoinREST) GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	stats, err := f.contractStats(ctx, symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.OpenInterest, 0, len(stats))
+	for _, s := range stats {
+		openInterest := contractsToBase(decimal.NewFromInt(s.OpenInterest), decimal.NewFromFloat(s.MarkPrice))
+		out = append(out, schema.OpenInterest{
+			Exchange:     schema.GATE,
+			Market:       schema.FUTURESCOIN,
+			Symbol:       symbol,
+			OpenInterest: openInterest,
+			Value:        decimal.NewFromFloat(s.OpenInterestUsd),
+			Timestamp:    time.Unix(s.Time, 0),
+		})
+	}
+	return out, nil
+}
+
+// GetLongShortRatio 获取多空账户比历史，取自合约统计的 lsr_account
+func (f *FuturesCoinREST) GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	stats, err := f.contractStats(ctx, symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.LongShortRatio, 0, len(stats))
+	for _, s := range stats {
+		out = append(out, schema.NewLongShortRatio(schema.GATE, schema.FUTURESCOIN, symbol, decimal.NewFromFloat(s.LsrAccount), time.Unix(s.Time, 0)))
+	}
+	return out, nil
+}
+
+// contractStats 请求合约统计数据，interval 支持 5m、15m、30m、1h、4h、1d
+func (f *FuturesCoinREST) contractStats(ctx context.Context, contract string, period schema.Interval, limit int) ([]schema.GateContractStat, error) {
+	var resp []schema.GateContractStat
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"contract": contract,
+		"interval": string(period),
+		"limit":    fmt.Sprintf("%d", limit),
+	}).Get(apiFuturesStats)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	logger.Debug("Gate Futures Coin Contract Stats 原始响应: %s", string(r.Body()))
+	return resp, nil
+}
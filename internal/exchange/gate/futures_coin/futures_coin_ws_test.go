@@ -0,0 +1,109 @@
+package futures_coin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+	"github.com/shopspring/decimal"
+)
+
+func newDepthTestWS(t *testing.T) (*FuturesCoinWS, *cache.MemoryCache) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, apiFuturesOrderBook):
+			if r.URL.Query().Get("with_id") != "true" {
+				t.Errorf("snapshot must be requested with_id=true")
+			}
+			_, _ = w.Write([]byte(`{"id":100,"current":1700000000.123,"update":1700000000.1,"asks":[{"p":"100","s":1000}],"bids":[{"p":"99","s":990}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := cache.NewMemoryCache()
+	rest := NewFuturesCoinREST()
+	rest.http.SetBaseURL(srv.URL)
+	return NewFuturesCoinWS(c, cache.NewSubscriptionManager(), rest), c
+}
+
+func depthUpdate(first, last int64, bids, asks []gateLevel) json.RawMessage {
+	data, _ := json.Marshal(gateBookUpdate{T: 1700000000100, Contract: "BTC_USD", FirstId: first, LastId: last, Bids: bids, Asks: asks})
+	return data
+}
+
+func waitDepth(c *cache.MemoryCache) (schema.Depth, bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if d, ok := c.GetDepth(schema.GATE, schema.FUTURESCOIN, "BTC_USD"); ok {
+			return d, true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return schema.Depth{}, false
+}
+
+func TestHandleDepth_SnapshotSync(t *testing.T) {
+	f, c := newDepthTestWS(t)
+
+	// 快照前的增量被缓存，快照加载后丢弃 u <= id 的部分并回放其余增量
+	f.handleDepth(depthUpdate(95, 100, []gateLevel{{P: "98", S: decimal.NewFromInt(5)}}, nil))
+	f.handleDepth(depthUpdate(101, 102, []gateLevel{{P: "99.5", S: decimal.NewFromInt(199)}}, nil))
+
+	d, ok := waitDepth(c)
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	// USD 张数按价格换算为币数量
+	if d.LastUpdateId != "102" || len(d.Bids) != 2 || d.Bids[0].Price.String() != "99.5" || d.Bids[0].Quantity.String() != "2" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+	if d.Asks[0].Quantity.String() != "10" {
+		t.Fatalf("unexpected ask quantity: %s", d.Asks[0].Quantity)
+	}
+
+	// 同步完成后增量直接应用
+	f.handleDepth(depthUpdate(103, 103, nil, []gateLevel{{P: "100", S: decimal.Zero}, {P: "100.5", S: decimal.NewFromInt(201)}}))
+	d, _ = c.GetDepth(schema.GATE, schema.FUTURESCOIN, "BTC_USD")
+	if d.LastUpdateId != "103" || len(d.Asks) != 1 || d.Asks[0].Price.String() != "100.5" || d.Asks[0].Quantity.String() != "2" {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+}
+
+func TestHandleDepth_SnapshotInsideLiveUpdate(t *testing.T) {
+	f, c := newDepthTestWS(t)
+
+	// 缓存的增量全部早于快照，快照加载后没有回放任何增量
+	f.handleDepth(depthUpdate(90, 95, []gateLevel{{P: "98", S: decimal.NewFromInt(5)}}, nil))
+	if _, ok := waitDepth(c); !ok {
+		t.Fatalf("depth not cached")
+	}
+
+	// 同步后的第一条实时增量跨越快照 id（U=98 <= 101 <= u=104），应直接应用而不是重新同步
+	f.handleDepth(depthUpdate(98, 104, []gateLevel{{P: "99.5", S: decimal.NewFromInt(199)}}, nil))
+	d, _ := c.GetDepth(schema.GATE, schema.FUTURESCOIN, "BTC_USD")
+	if d.LastUpdateId != "104" || len(d.Bids) != 2 {
+		t.Fatalf("straddling update not applied: %+v", d)
+	}
+
+	// 之后的增量要求严格连续
+	f.handleDepth(depthUpdate(105, 106, nil, []gateLevel{{P: "100.5", S: decimal.NewFromInt(201)}}))
+	d, _ = c.GetDepth(schema.GATE, schema.FUTURESCOIN, "BTC_USD")
+	if d.LastUpdateId != "106" || len(d.Asks) != 2 {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+	f.handleDepth(depthUpdate(104, 108, nil, nil))
+	f.mu.Lock()
+	_, resyncing := f.syncs["BTC_USD"]
+	f.mu.Unlock()
+	if !resyncing {
+		t.Fatalf("overlapping update after sync should trigger resync")
+	}
+}
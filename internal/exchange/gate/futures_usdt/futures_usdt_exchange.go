@@ -13,9 +13,11 @@ type FuturesUSDTExchange struct {
 }
 
 func NewFuturesUSDTExchange(c *cache.MemoryCache) *FuturesUSDTExchange {
+	subs := cache.NewSubscriptionManager()
+	rest := NewFuturesUSDTREST()
 	return &FuturesUSDTExchange{
-		rest: NewFuturesUSDTREST(),
-		ws:   NewFuturesUSDTWS(c),
+		rest: rest,
+		ws:   NewFuturesUSDTWS(c, subs, rest),
 	}
 }
 
@@ -5,11 +5,16 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
 
 	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
@@ -18,84 +23,464 @@ import (
 )
 
 const (
-	GateFuturesUSDTWSBase = "wss://api.gateio.ws/ws/v4/"
+	GateFuturesUSDTWSBase = "wss://fx-ws.gateio.ws/v4/ws/usdt"
+
+	channelKline      = "futures.candlesticks"
+	channelDepth      = "futures.order_book_update"
+	channelTrade      = "futures.trades"
+	channelBookTicker = "futures.book_ticker"
+	channelTicker     = "futures.tickers" // 同时包含标记价格、指数价格和资金费率
+	channelPing       = "futures.ping"
+	channelPong       = "futures.pong"
+
+	depthFrequency  = "100ms"
+	depthLevel      = "100"
+	depthSnapshotSz = 100
+
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
+
+	// 快照或合约乘数获取失败后的重试间隔
+	syncRetryInterval = 3 * time.Second
 )
 
+type gateMessage struct {
+	Time    int64    `json:"time"`
+	Channel string   `json:"channel"`
+	Event   string   `json:"event,omitempty"`
+	Payload []string `json:"payload,omitempty"`
+}
+
+// gateTrade 是 futures.trades 推送数据，size 为负表示主动卖出
+type gateTrade struct {
+	Id           int64           `json:"id"`
+	Size         decimal.Decimal `json:"size"` // 合约张数
+	CreateTimeMs int64           `json:"create_time_ms"`
+	Price        string          `json:"price"`
+	Contract     string          `json:"contract"`
+}
+
+// gateBookTicker 是 futures.book_ticker 推送数据，数量为合约张数
+type gateBookTicker struct {
+	T        int64           `json:"t"` // 毫秒
+	U        int64           `json:"u"` // 订单簿更新ID
+	Contract string          `json:"s"`
+	BidPrice string          `json:"b"`
+	BidSize  decimal.Decimal `json:"B"`
+	AskPrice string          `json:"a"`
+	AskSize  decimal.Decimal `json:"A"`
+}
+
+// gateBookUpdate 是 futures.order_book_update 推送数据
+type gateBookUpdate struct {
+	T        int64       `json:"t"` // 毫秒
+	Contract string      `json:"s"`
+	FirstId  int64       `json:"U"`
+	LastId   int64       `json:"u"`
+	Bids     []gateLevel `json:"b"`
+	Asks     []gateLevel `json:"a"`
+}
+
+type gateCandlestick struct {
+	T      int64           `json:"t"` // 秒
+	Open   string          `json:"o"`
+	High   string          `json:"h"`
+	Low    string          `json:"l"`
+	Close  string          `json:"c"`
+	Volume decimal.Decimal `json:"v"` // 张数
+	Amount string          `json:"a"` // 基础币数量
+	Name   string          `json:"n"` // 1m_BTC_USDT
+	Closed bool            `json:"w"` // K线是否已完结
+}
+
+// klineIntervals schema.Interval 到 Gate K线周期的映射，Gate 不支持 3m
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1m",
+	schema.Interval5m:  "5m",
+	schema.Interval15m: "15m",
+	schema.Interval30m: "30m",
+	schema.Interval1h:  "1h",
+	schema.Interval4h:  "4h",
+	schema.Interval1d:  "1d",
+}
+
+// orderBook 本地订单簿，数量单位为张
+type orderBook struct {
+	lastId int64
+	synced bool                       // 是否已应用快照之后的第一条增量；之前按 U <= id+1 <= u 衔接，之后要求 U == u+1
+	bids   map[string]decimal.Decimal // price -> 张数
+	asks   map[string]decimal.Decimal // price -> 张数
+}
+
+// bookSync 记录快照同步期间缓存的增量
+type bookSync struct {
+	buffer   []gateBookUpdate
+	syncing  bool
+	failedAt time.Time
+}
+
 // FuturesUSDTWS implements WSConnector for Gate USDT-margined Futures.
 type FuturesUSDTWS struct {
-	dialer *websocket.Dialer
-	conn   interfaces.WSConn
-	mu     sync.RWMutex
-	cache  *cache.MemoryCache
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+	rest    *FuturesUSDTREST
 
-	// subscription state
-	subs struct {
-		tickers map[string]struct{}
-		kline   map[string]schema.Interval
-		depth   map[string]struct{}
-	}
+	// per-contract local order books and sync state
+	orderBooks map[string]*orderBook
+	syncs      map[string]*bookSync
+
+	// 合约乘数开始后台加载或加载失败的时间，用于限制重试频率
+	multiplierPending map[string]time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
 
-	// internal control
-	stopCh chan struct{}
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewFuturesUSDTWS(c *cache.MemoryCache) *FuturesUSDTWS {
+func NewFuturesUSDTWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *FuturesUSDTREST) *FuturesUSDTWS {
 	d := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
 		HandshakeTimeout: 10 * time.Second,
 		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
 	}
-	ws := &FuturesUSDTWS{dialer: d, cache: c}
-	ws.subs.tickers = make(map[string]struct{})
-	ws.subs.kline = make(map[string]schema.Interval)
-	ws.subs.depth = make(map[string]struct{})
-	ws.stopCh = make(chan struct{})
-	return ws
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FuturesUSDTWS{
+		dialer:            d,
+		cache:             c,
+		gaps:              cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:              subs,
+		rest:              rest,
+		orderBooks:        make(map[string]*orderBook),
+		syncs:             make(map[string]*bookSync),
+		multiplierPending: make(map[string]time.Time),
+		ctx:               ctx,
+		cancel:            cancel,
+	}
 }
 
 func (f *FuturesUSDTWS) Connect(ctx context.Context) error {
-	// TODO: Implement Gate futures USDT WS connection
-	return errors.New("not implemented")
+	f.mu.Lock()
+	if f.conn != nil {
+		f.mu.Unlock()
+		logger.Info("Gate Futures USDT WS 已连接，跳过连接")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 开始连接...")
+	conn, _, err := f.dialer.DialContext(ctx, GateFuturesUSDTWSBase, nil)
+	if err != nil {
+		f.mu.Unlock()
+		logger.Error("Gate Futures USDT WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024)
+	f.conn = conn
+	f.mu.Unlock()
+
+	now := time.Now()
+	f.healthMu.Lock()
+	f.lastMessage = now
+	f.lastPing = now
+	f.healthMu.Unlock()
+
+	logger.Info("Gate Futures USDT WS 连接成功")
+
+	// 重连时自动恢复订阅
+	_ = f.applySubscriptions(ctx)
+	return nil
 }
 
 func (f *FuturesUSDTWS) Close() error {
-	// TODO: Implement Gate futures USDT WS close
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn != nil {
+		err := f.conn.Close()
+		f.conn = nil
+		return err
+	}
 	return nil
 }
 
-func (f *FuturesUSDTWS) SubscribeTickers(ctx context.Context, symbols []string) error {
-	// TODO: Implement Gate futures USDT WS ticker subscription
-	return errors.New("not implemented")
-}
+func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
+	}
 
-func (f *FuturesUSDTWS) UnsubscribeTickers(ctx context.Context, symbols []string) error {
-	// TODO: Implement Gate futures USDT WS ticker unsubscription
-	return errors.New("not implemented")
-}
+	newlyAdded := f.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅 kline，跳过订阅请求")
+		return nil
+	}
 
-func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// TODO: Implement Gate futures USDT WS kline subscription
-	return errors.New("not implemented")
+	logger.Info("Gate Futures USDT WS 新增订阅 kline: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约乘数
+	f.loadQuantoMultipliers(ctx, klineContracts(newlyAdded))
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendKline(ctx, "subscribe", newlyAdded)
 }
 
-func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// TODO: Implement Gate futures USDT WS kline unsubscription
-	return errors.New("not implemented")
+// UnsubscribeKline 只退订K线频道的指定周期，未指定周期时退订全部周期
+func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅 kline，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订 kline: %v", removed)
+	f.gaps.Forget(removed)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendKline(ctx, "unsubscribe", removed)
 }
 
 func (f *FuturesUSDTWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// TODO: Implement Gate futures USDT WS depth subscription
-	return errors.New("not implemented")
+	newlyAdded := f.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅 depth，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 新增订阅 depth: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约乘数
+	f.loadQuantoMultipliers(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendDepth(ctx, "subscribe", newlyAdded)
 }
 
 func (f *FuturesUSDTWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// TODO: Implement %!s(MISSING) futures USDT WS depth unsubscription
-	return errors.New("not implemented")
+	return f.unsubscribe(ctx, symbols, "depth")
 }
 
-func (f *FuturesUSDTWS) StartReading(ctx context.Context) error {
-	// TODO: Implement Gate futures USDT WS start
-	return errors.New("not implemented")
+func (f *FuturesUSDTWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 新增订阅 trades: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约乘数
+	f.loadQuantoMultipliers(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTrades(ctx, "subscribe", newlyAdded)
+}
+
+func (f *FuturesUSDTWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订 trades: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendTrades(ctx, "unsubscribe", removed)
+}
+
+func (f *FuturesUSDTWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅 book_ticker，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 新增订阅 book_ticker: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约乘数
+	f.loadQuantoMultipliers(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendBookTicker(ctx, "subscribe", newlyAdded)
+}
+
+func (f *FuturesUSDTWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅 book_ticker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订 book_ticker: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendBookTicker(ctx, "unsubscribe", removed)
+}
+
+func (f *FuturesUSDTWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅 tickers，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 新增订阅 tickers: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 已订阅资金费率或持仓量的合约共用 futures.tickers 频道，无需重复订阅
+	return f.sendTicker(ctx, "subscribe", excludeSymbols(newlyAdded, append(f.subs.GetFundingSymbols(), f.subs.GetOpenInterestSymbols()...)))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅 tickers，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订 tickers: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅资金费率或持仓量的合约保留 futures.tickers 频道
+	return f.sendTicker(ctx, "unsubscribe", excludeSymbols(removed, append(f.subs.GetFundingSymbols(), f.subs.GetOpenInterestSymbols()...)))
+}
+
+func (f *FuturesUSDTWS) SubscribeFunding(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeFundingSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅资金费率，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 新增订阅资金费率: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 资金费率来自 futures.tickers 频道，已订阅行情或持仓量的合约无需重复订阅
+	return f.sendTicker(ctx, "subscribe", excludeSymbols(newlyAdded, append(f.subs.GetTickerSymbols(), f.subs.GetOpenInterestSymbols()...)))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeFunding(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeFundingSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅资金费率，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订资金费率: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅行情或持仓量的合约保留 futures.tickers 频道
+	return f.sendTicker(ctx, "unsubscribe", excludeSymbols(removed, append(f.subs.GetTickerSymbols(), f.subs.GetOpenInterestSymbols()...)))
+}
+
+func (f *FuturesUSDTWS) SubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都已订阅持仓量，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 新增订阅持仓量: %v", newlyAdded)
+	// 持仓量为张数，订阅时预先加载合约乘数
+	f.loadQuantoMultipliers(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 持仓量来自 futures.tickers 频道，已订阅行情或资金费率的合约无需重复订阅
+	return f.sendTicker(ctx, "subscribe", excludeSymbols(newlyAdded, append(f.subs.GetTickerSymbols(), f.subs.GetFundingSymbols()...)))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅持仓量，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订持仓量: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅行情或资金费率的合约保留 futures.tickers 频道
+	return f.sendTicker(ctx, "unsubscribe", excludeSymbols(removed, append(f.subs.GetTickerSymbols(), f.subs.GetFundingSymbols()...)))
+}
+
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 depth 频道
+func (f *FuturesUSDTWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(f.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("Gate Futures USDT WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
+	}
+
+	logger.Info("Gate Futures USDT WS 退订 %s: %v", kind, removed)
+
+	f.mu.Lock()
+	for _, contract := range removed {
+		delete(f.orderBooks, contract)
+		delete(f.syncs, contract)
+	}
+	f.mu.Unlock()
+
+	if !f.isConnected() {
+		logger.Warn("Gate Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	if err := f.sendKline(ctx, "unsubscribe", klines); err != nil {
+		return err
+	}
+	return f.sendDepth(ctx, "unsubscribe", removed)
 }
 
 // SendMessage sends a message to WebSocket server
@@ -106,27 +491,838 @@ func (f *FuturesUSDTWS) SendMessage(ctx context.Context, message interface{}) er
 	if conn == nil {
 		return errors.New("WebSocket connection not established")
 	}
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("Gate Futures USDT WS SendMessage: %s", string(jsonData))
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("Gate Futures USDT WS 序列化消息失败: %v", err)
+		return err
+	}
+
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		logger.Error("Gate Futures USDT WS 发送消息失败: %v", err)
+		return err
+	}
+
+	logger.Info("Gate Futures USDT WS SendMessage: %s", string(data))
+	return nil
+}
+
+// sendKline Gate 每条订阅消息只能携带一个合约
+func (f *FuturesUSDTWS) sendKline(ctx context.Context, event string, subs []schema.KlineSubscription) error {
+	for _, sub := range subs {
+		msg := &gateMessage{
+			Time:    time.Now().Unix(),
+			Channel: channelKline,
+			Event:   event,
+			Payload: []string{klineIntervals[sub.Interval], sub.Symbol},
+		}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FuturesUSDTWS) sendDepth(ctx context.Context, event string, contracts []string) error {
+	for _, contract := range contracts {
+		msg := &gateMessage{
+			Time:    time.Now().Unix(),
+			Channel: channelDepth,
+			Event:   event,
+			Payload: []string{contract, depthFrequency, depthLevel},
+		}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendTrades 成交频道支持在一条消息中携带多个合约
+func (f *FuturesUSDTWS) sendTrades(ctx context.Context, event string, contracts []string) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+	return f.SendMessage(ctx, &gateMessage{
+		Time:    time.Now().Unix(),
+		Channel: channelTrade,
+		Event:   event,
+		Payload: contracts,
+	})
+}
+
+// sendBookTicker 最优买卖价频道支持在一条消息中携带多个合约
+func (f *FuturesUSDTWS) sendBookTicker(ctx context.Context, event string, contracts []string) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+	return f.SendMessage(ctx, &gateMessage{
+		Time:    time.Now().Unix(),
+		Channel: channelBookTicker,
+		Event:   event,
+		Payload: contracts,
+	})
+}
+
+func (f *FuturesUSDTWS) sendTicker(ctx context.Context, event string, contracts []string) error {
+	if len(contracts) == 0 {
+		return nil
 	}
-	return conn.WriteJSON(message)
+	return f.SendMessage(ctx, &gateMessage{
+		Time:    time.Now().Unix(),
+		Channel: channelTicker,
+		Event:   event,
+		Payload: contracts,
+	})
 }
 
-// HandlePing 处理接收到的 ping（空实现）
-func (ws *FuturesUSDTWS) HandlePing(data []byte) error {
-	// TODO: 实现 Gate USDT 期货 的 ping 处理
+func (f *FuturesUSDTWS) applySubscriptions(ctx context.Context) error {
+	klineSubs := f.subs.GetKlineSubscriptions()
+	depthSymbols := f.subs.GetDepthSymbols()
+	tradeSymbols := f.subs.GetTradeSymbols()
+	bookTickerSymbols := f.subs.GetBookTickerSymbols()
+	// 行情、资金费率与持仓量共用 futures.tickers 频道
+	tickerSymbols := dedupe(append(append(f.subs.GetTickerSymbols(), f.subs.GetFundingSymbols()...), f.subs.GetOpenInterestSymbols()...))
+	if len(klineSubs) == 0 && len(depthSymbols) == 0 && len(tradeSymbols) == 0 && len(bookTickerSymbols) == 0 && len(tickerSymbols) == 0 {
+		logger.Info("Gate Futures USDT WS 无订阅")
+		return nil
+	}
+	// 数量以张数推送的频道需要合约乘数，在发送订阅前加载，避免在读协程中请求 REST
+	f.loadQuantoMultipliers(ctx, append(append(append(append(klineContracts(klineSubs), depthSymbols...),
+		tradeSymbols...), bookTickerSymbols...), f.subs.GetOpenInterestSymbols()...))
+	if err := f.sendKline(ctx, "subscribe", klineSubs); err != nil {
+		return err
+	}
+	if err := f.sendDepth(ctx, "subscribe", depthSymbols); err != nil {
+		return err
+	}
+	if err := f.sendTrades(ctx, "subscribe", tradeSymbols); err != nil {
+		return err
+	}
+	if err := f.sendBookTicker(ctx, "subscribe", bookTickerSymbols); err != nil {
+		return err
+	}
+	return f.sendTicker(ctx, "subscribe", tickerSymbols)
+}
+
+// parseKlineName 由K线推送的 n 字段解析周期和币对
+func parseKlineName(name string) (schema.Interval, string, bool) {
+	parts := strings.SplitN(name, "_", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	for interval, v := range klineIntervals {
+		if v == parts[0] {
+			return interval, parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for gate", interval)
+		}
+	}
 	return nil
 }
 
-// SendPing 主动发送 ping（空实现）
-func (ws *FuturesUSDTWS) SendPing(ctx context.Context) error {
-	// TODO: 实现 Gate USDT 期货 的主动 ping
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+// excludeSymbols 返回 symbols 中不在 exclude 里的币对
+func excludeSymbols(symbols, exclude []string) []string {
+	skip := make(map[string]struct{}, len(exclude))
+	for _, s := range exclude {
+		skip[s] = struct{}{}
+	}
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := skip[s]; !ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+// klineContracts 返回K线订阅涉及的合约
+func klineContracts(subs []schema.KlineSubscription) []string {
+	out := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		out = append(out, sub.Symbol)
+	}
+	return out
+}
+
+func (f *FuturesUSDTWS) isConnected() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
+func (f *FuturesUSDTWS) StartReading(ctx context.Context) error {
+	logger.Info("Gate Futures USDT WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Gate Futures USDT WS 上下文取消")
+				return
+			case <-f.ctx.Done():
+				logger.Info("Gate Futures USDT WS 已关闭")
+				return
+			default:
+			}
+
+			f.mu.RLock()
+			conn := f.conn
+			f.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if f.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("Gate Futures USDT WS 读取消息失败: %v", err)
+				f.reconnect(ctx)
+				continue
+			}
+
+			f.healthMu.Lock()
+			f.lastMessage = time.Now()
+			f.healthMu.Unlock()
+
+			f.handleRawMessage(message)
+		}
+	}()
+
 	return nil
 }
 
-// StartHealthCheck 启动连接健康监控（空实现）
-func (ws *FuturesUSDTWS) StartHealthCheck(ctx context.Context) error {
-	// TODO: 实现 Gate USDT 期货 的健康检查
+func (f *FuturesUSDTWS) handleRawMessage(message []byte) {
+	logger.Debug("Gate Futures USDT WS 收到原始消息: %s", string(message))
+
+	var msg struct {
+		Channel string `json:"channel"`
+		Event   string `json:"event"`
+		Error   *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		TimeMs int64           `json:"time_ms"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("Gate Futures USDT WS 解析原始消息失败: %v", err)
+		return
+	}
+
+	if msg.Error != nil {
+		logger.Error("Gate Futures USDT WS %s %s 错误: code=%d, msg=%s", msg.Channel, msg.Event, msg.Error.Code, msg.Error.Message)
+		return
+	}
+
+	switch msg.Event {
+	case "update", "all":
+	case "subscribe", "unsubscribe":
+		logger.Info("Gate Futures USDT WS %s %s 成功", msg.Channel, msg.Event)
+		return
+	default:
+		if msg.Channel == channelPong {
+			logger.Debug("Gate Futures USDT WS 收到 pong")
+			return
+		}
+		logger.Debug("Gate Futures USDT WS 未知事件: %s %s", msg.Channel, msg.Event)
+		return
+	}
+
+	switch msg.Channel {
+	case channelKline:
+		f.handleKline(msg.Result)
+	case channelDepth:
+		f.handleDepth(msg.Result)
+	case channelTrade:
+		f.handleTrade(msg.Result)
+	case channelBookTicker:
+		f.handleBookTicker(msg.Result)
+	case channelTicker:
+		f.handleTicker(msg.TimeMs, msg.Result)
+	default:
+		logger.Debug("Gate Futures USDT WS 未知频道: %s", msg.Channel)
+	}
+}
+
+// handleTrade 处理公共成交，张数按合约乘数换算为基础币数量
+func (f *FuturesUSDTWS) handleTrade(data json.RawMessage) {
+	var rows []gateTrade
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Gate Futures USDT WS 解析trades失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Price)
+		contracts := row.Size.Abs()
+		multiplier, ok := f.quantoMultiplier(row.Contract)
+		if !ok {
+			continue
+		}
+		quantity := contracts.Mul(multiplier)
+		quoteQty := price.Mul(quantity)
+
+		side := schema.OrderSideBuy
+		if row.Size.IsNegative() {
+			side = schema.OrderSideSell
+		}
+
+		f.cache.AddTrade(schema.Trade{
+			Exchange:  schema.GATE,
+			Market:    schema.FUTURESUSDT,
+			Symbol:    row.Contract,
+			TradeID:   strconv.FormatInt(row.Id, 10),
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(row.CreateTimeMs),
+		})
+	}
+}
+
+// handleBookTicker 处理最优买卖价推送，张数按合约乘数换算为基础币数量
+func (f *FuturesUSDTWS) handleBookTicker(data json.RawMessage) {
+	var row gateBookTicker
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("Gate Futures USDT WS 解析book_ticker失败: %v", err)
+		return
+	}
+
+	bidPrice, _ := decimal.NewFromString(row.BidPrice)
+	askPrice, _ := decimal.NewFromString(row.AskPrice)
+	multiplier, ok := f.quantoMultiplier(row.Contract)
+	if !ok {
+		return
+	}
+	bidQty := row.BidSize.Mul(multiplier)
+	askQty := row.AskSize.Mul(multiplier)
+
+	f.cache.SetBookTicker(schema.BookTicker{
+		Exchange:     schema.GATE,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       row.Contract,
+		BidPrice:     bidPrice,
+		BidQty:       bidQty,
+		AskPrice:     askPrice,
+		AskQty:       askQty,
+		UpdatedAt:    time.UnixMilli(row.T),
+		LastUpdateId: strconv.FormatInt(row.U, 10),
+	})
+}
+
+// handleTicker 处理24小时行情推送，futures.tickers 的结果为数组；
+// 推送同时包含标记价格、指数价格和资金费率，一并写入资金费缓存（不含下次结算时间）；持仓张数换算后写入持仓量缓存
+func (f *FuturesUSDTWS) handleTicker(ts int64, data json.RawMessage) {
+	var rows []schema.GateFuturesTickerResponse
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Gate Futures USDT WS 解析tickers失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		f.cache.SetTicker(convertTicker(row, time.UnixMilli(ts)))
+
+		if row.TotalSize != "" {
+			if multiplier, ok := f.quantoMultiplier(row.Contract); ok {
+				f.cache.SetOpenInterest(convertOpenInterest(row, multiplier, time.UnixMilli(ts)))
+			}
+		}
+
+		if row.MarkPrice == "" && row.FundingRate == "" {
+			continue
+		}
+		markPrice, _ := decimal.NewFromString(row.MarkPrice)
+		indexPrice, _ := decimal.NewFromString(row.IndexPrice)
+		fundingRate, _ := decimal.NewFromString(row.FundingRate)
+		f.cache.SetFunding(schema.FundingInfo{
+			Exchange:    schema.GATE,
+			Market:      schema.FUTURESUSDT,
+			Symbol:      row.Contract,
+			MarkPrice:   markPrice,
+			IndexPrice:  indexPrice,
+			FundingRate: fundingRate,
+			Timestamp:   time.UnixMilli(ts),
+		})
+	}
+}
+
+func (f *FuturesUSDTWS) handleKline(data json.RawMessage) {
+	var rows []gateCandlestick
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("Gate Futures USDT WS 解析kline失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		// n 的格式为 "1h_BTC_USDT"
+		interval, contract, ok := parseKlineName(row.Name)
+		if !ok {
+			logger.Warn("Gate Futures USDT WS 未知K线名称: %s", row.Name)
+			continue
+		}
+		open, _ := decimal.NewFromString(row.Open)
+		high, _ := decimal.NewFromString(row.High)
+		low, _ := decimal.NewFromString(row.Low)
+		close, _ := decimal.NewFromString(row.Close)
+		volume, err := decimal.NewFromString(row.Amount)
+		if err != nil {
+			// 未返回基础币成交量时由张数换算
+			multiplier, ok := f.quantoMultiplier(contract)
+			if !ok {
+				continue
+			}
+			volume = row.Volume.Mul(multiplier)
+		}
+		// 推送不含成交额，按收盘价估算
+		quoteVolume := volume.Mul(close)
+
+		openTime := time.Unix(row.T, 0)
+		f.gaps.SetKline(schema.Kline{
+			Exchange:    schema.GATE,
+			Market:      schema.FUTURESUSDT,
+			Symbol:      contract,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row.Closed,
+			EventTime:   time.Now(),
+		})
+	}
+}
+
+// handleDepth 按 Gate 文档维护本地订单簿：
+// 1. 订阅后先缓存增量，再通过 REST 获取带 id 的快照
+// 2. 丢弃 u <= id 的增量，第一条应用的增量需满足 U <= id+1 <= u
+// 3. 之后每条增量的 U 必须等于上一条的 u+1，否则重新同步
+func (f *FuturesUSDTWS) handleDepth(data json.RawMessage) {
+	var update gateBookUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		logger.Error("Gate Futures USDT WS 解析depth失败: %v", err)
+		return
+	}
+	contract := update.Contract
+
+	f.mu.Lock()
+	ob := f.orderBooks[contract]
+	if ob == nil {
+		st := f.syncs[contract]
+		if st == nil {
+			st = &bookSync{}
+			f.syncs[contract] = st
+		}
+		st.buffer = append(st.buffer, update)
+		start := !st.syncing && time.Since(st.failedAt) >= syncRetryInterval
+		if start {
+			st.syncing = true
+		}
+		f.mu.Unlock()
+		if start {
+			go f.syncOrderBook(contract)
+		}
+		return
+	}
+
+	if update.LastId <= ob.lastId {
+		f.mu.Unlock()
+		return
+	}
+	if (ob.synced && update.FirstId != ob.lastId+1) || (!ob.synced && update.FirstId > ob.lastId+1) {
+		logger.Warn("Gate Futures USDT WS %s 更新不连续: U=%d, 本地u=%d，重新同步快照",
+			contract, update.FirstId, ob.lastId)
+		delete(f.orderBooks, contract)
+		f.syncs[contract] = &bookSync{buffer: []gateBookUpdate{update}, syncing: true}
+		f.mu.Unlock()
+		go f.syncOrderBook(contract)
+		return
+	}
+	applyLevels(ob.bids, update.Bids)
+	applyLevels(ob.asks, update.Asks)
+	ob.lastId = update.LastId
+	ob.synced = true
+	f.mu.Unlock()
+
+	f.publishDepth(contract, update.T)
+}
+
+// syncOrderBook 获取 REST 快照并回放缓存的增量
+func (f *FuturesUSDTWS) syncOrderBook(contract string) {
+	ctx, cancel := context.WithTimeout(f.ctx, 10*time.Second)
+	defer cancel()
+
+	// 先加载合约乘数，之后发布深度时直接命中缓存
+	_, err := f.rest.quantoMultiplier(ctx, contract)
+	var snapshot *gateOrderBook
+	if err == nil {
+		snapshot, err = f.rest.orderBook(ctx, contract, depthSnapshotSz)
+	}
+	if err != nil {
+		logger.Error("Gate Futures USDT WS 同步深度快照失败 %s: %v", contract, err)
+		f.mu.Lock()
+		if st := f.syncs[contract]; st != nil {
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
+		}
+		f.mu.Unlock()
+		return
+	}
+
+	f.mu.Lock()
+	st := f.syncs[contract]
+	if st == nil {
+		// 同步期间已退订
+		f.mu.Unlock()
+		return
+	}
+
+	ob := &orderBook{
+		lastId: snapshot.Id,
+		bids:   make(map[string]decimal.Decimal),
+		asks:   make(map[string]decimal.Decimal),
+	}
+	applyLevels(ob.bids, snapshot.Bids)
+	applyLevels(ob.asks, snapshot.Asks)
+
+	var lastTs int64
+	for _, update := range st.buffer {
+		if update.LastId <= ob.lastId {
+			continue
+		}
+		if update.FirstId > ob.lastId+1 {
+			// 快照早于缓存的增量，稍后用新的快照重试
+			logger.Warn("Gate Futures USDT WS %s 快照与增量不衔接: 快照id=%d, U=%d，稍后重试",
+				contract, ob.lastId, update.FirstId)
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
+			f.mu.Unlock()
+			return
+		}
+		applyLevels(ob.bids, update.Bids)
+		applyLevels(ob.asks, update.Asks)
+		ob.lastId = update.LastId
+		ob.synced = true
+		lastTs = update.T
+	}
+
+	f.orderBooks[contract] = ob
+	delete(f.syncs, contract)
+	f.mu.Unlock()
+
+	logger.Info("Gate Futures USDT WS %s 深度快照已加载: id=%d, 买单%d档, 卖单%d档",
+		contract, snapshot.Id, len(snapshot.Bids), len(snapshot.Asks))
+
+	if lastTs == 0 {
+		lastTs = int64(snapshot.Current * 1000)
+	}
+	f.publishDepth(contract, lastTs)
+}
+
+// publishDepth 换算为基础币数量后写入缓存
+func (f *FuturesUSDTWS) publishDepth(contract string, ts int64) {
+	multiplier, ok := f.quantoMultiplier(contract)
+	if !ok {
+		return
+	}
+	if depth := f.buildDepthFromOrderBook(contract, multiplier, ts); depth != nil {
+		f.cache.SetDepth(*depth)
+	}
+}
+
+// quantoMultiplier 从 REST 客户端缓存读取合约乘数，不在读协程中同步请求 REST；
+// 未加载时在后台加载并跳过本条推送，加载失败后 syncRetryInterval 内不再重试
+func (f *FuturesUSDTWS) quantoMultiplier(contract string) (decimal.Decimal, bool) {
+	if multiplier, ok := f.rest.cachedQuantoMultiplier(contract); ok {
+		return multiplier, true
+	}
+
+	f.mu.Lock()
+	pendingAt, pending := f.multiplierPending[contract]
+	start := !pending || time.Since(pendingAt) >= syncRetryInterval
+	if start {
+		f.multiplierPending[contract] = time.Now()
+	}
+	f.mu.Unlock()
+	if start {
+		go f.loadQuantoMultipliers(f.ctx, []string{contract})
+	}
+	return decimal.Zero, false
+}
+
+// loadQuantoMultipliers 在订阅和连接时预先加载合约乘数，已缓存的合约直接跳过
+func (f *FuturesUSDTWS) loadQuantoMultipliers(ctx context.Context, contracts []string) {
+	for _, contract := range dedupe(contracts) {
+		if _, ok := f.rest.cachedQuantoMultiplier(contract); ok {
+			continue
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := f.rest.quantoMultiplier(reqCtx, contract)
+		cancel()
+
+		f.mu.Lock()
+		if err != nil {
+			logger.Error("Gate Futures USDT WS 获取合约乘数失败 %s: %v", contract, err)
+			f.multiplierPending[contract] = time.Now()
+		} else {
+			delete(f.multiplierPending, contract)
+		}
+		f.mu.Unlock()
+	}
+}
+
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]decimal.Decimal, levels []gateLevel) {
+	for _, lv := range levels {
+		price, err := decimal.NewFromString(lv.P)
+		if err != nil {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := price.String()
+		if lv.S.IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = lv.S
+		}
+	}
+}
+
+// buildDepthFromOrderBook 将本地订单簿排序裁剪，并把张数换算为基础币数量
+func (f *FuturesUSDTWS) buildDepthFromOrderBook(contract string, multiplier decimal.Decimal, ts int64) *schema.Depth {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ob := f.orderBooks[contract]
+	if ob == nil {
+		return nil
+	}
+
+	bids := make([]schema.PriceLevel, 0, len(ob.bids))
+	for priceStr, sz := range ob.bids {
+		price, _ := decimal.NewFromString(priceStr)
+		bids = append(bids, schema.PriceLevel{Price: price, Quantity: sz.Mul(multiplier)})
+	}
+	asks := make([]schema.PriceLevel, 0, len(ob.asks))
+	for priceStr, sz := range ob.asks {
+		price, _ := decimal.NewFromString(priceStr)
+		asks = append(asks, schema.PriceLevel{Price: price, Quantity: sz.Mul(multiplier)})
+	}
+
+	// 排序：买单降序，卖单升序
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	if len(bids) > maxDepthLevels {
+		bids = bids[:maxDepthLevels]
+	}
+	if len(asks) > maxDepthLevels {
+		asks = asks[:maxDepthLevels]
+	}
+
+	updatedAt := time.Now()
+	if ts > 0 {
+		updatedAt = time.UnixMilli(ts)
+	}
+
+	return &schema.Depth{
+		Exchange:     schema.GATE,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       contract,
+		Bids:         bids,
+		Asks:         asks,
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", ob.lastId),
+	}
+}
+
+// HandlePing 处理服务端的 ping 帧
+func (f *FuturesUSDTWS) HandlePing(data []byte) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	f.writeMu.Lock()
+	defer f.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
+
+// SendPing 发送应用层心跳 futures.ping，服务端回复 futures.pong
+func (f *FuturesUSDTWS) SendPing(ctx context.Context) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	data, _ := json.Marshal(&gateMessage{Time: time.Now().Unix(), Channel: channelPing})
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	f.healthMu.Lock()
+	f.lastPing = time.Now()
+	f.healthMu.Unlock()
 	return nil
 }
+
+// StartHealthCheck 空闲时发送心跳，超时未收到消息则重连
+func (f *FuturesUSDTWS) StartHealthCheck(ctx context.Context) error {
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Gate Futures USDT WS 健康检查停止")
+			return nil
+		case <-f.ctx.Done():
+			logger.Info("Gate Futures USDT WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			f.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (f *FuturesUSDTWS) checkConnectionHealth(ctx context.Context) {
+	if !f.isConnected() {
+		return
+	}
+
+	f.healthMu.RLock()
+	sinceMsg := time.Since(f.lastMessage)
+	sincePing := time.Since(f.lastPing)
+	f.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("Gate Futures USDT WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		f.reconnect(ctx)
+		return
+	}
+
+	if sinceMsg > pingInterval && sincePing > pingInterval {
+		if err := f.SendPing(ctx); err != nil {
+			logger.Warn("Gate Futures USDT WS ping失败: %v", err)
+			f.reconnect(ctx)
+		}
+	}
+}
+
+func (f *FuturesUSDTWS) reconnect(ctx context.Context) {
+	f.reconnectMu.Lock()
+	if f.reconnecting {
+		f.reconnectMu.Unlock()
+		return
+	}
+	f.reconnecting = true
+	f.reconnectMu.Unlock()
+
+	defer func() {
+		f.reconnectMu.Lock()
+		f.reconnecting = false
+		f.reconnectMu.Unlock()
+	}()
+
+	f.mu.Lock()
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+	// 重连后重新获取快照
+	f.orderBooks = make(map[string]*orderBook)
+	f.syncs = make(map[string]*bookSync)
+	f.mu.Unlock()
+
+	for {
+		f.reconnectMu.Lock()
+		f.reconnectCount++
+		reconnectCount := f.reconnectCount
+		f.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("Gate Futures USDT WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := f.Connect(ctx); err != nil {
+			logger.Error("Gate Futures USDT WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("Gate Futures USDT WS 重连成功")
+		// 补齐断线期间缺失的K线
+		f.gaps.Backfill(ctx, f.subs.GetKlineSubscriptions())
+		f.reconnectMu.Lock()
+		f.reconnectCount = 0
+		f.reconnectMu.Unlock()
+		return
+	}
+}
@@ -0,0 +1,196 @@
+package futures_usdt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+	"github.com/shopspring/decimal"
+)
+
+func newDepthTestWS(t *testing.T) (*FuturesUSDTWS, *cache.MemoryCache) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, apiFuturesContracts+"/BTC_USDT"):
+			_, _ = w.Write([]byte(`{"name":"BTC_USDT","type":"direct","quanto_multiplier":"0.0001","status":"trading"}`))
+		case strings.HasSuffix(r.URL.Path, apiFuturesOrderBook):
+			if r.URL.Query().Get("with_id") != "true" {
+				t.Errorf("snapshot must be requested with_id=true")
+			}
+			_, _ = w.Write([]byte(`{"id":100,"current":1700000000.123,"update":1700000000.1,"asks":[{"p":"101","s":10}],"bids":[{"p":"99","s":10}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := cache.NewMemoryCache()
+	rest := NewFuturesUSDTREST()
+	rest.http.SetBaseURL(srv.URL)
+	return NewFuturesUSDTWS(c, cache.NewSubscriptionManager(), rest), c
+}
+
+func depthUpdate(first, last int64, bids, asks []gateLevel) json.RawMessage {
+	data, _ := json.Marshal(gateBookUpdate{T: 1700000000100, Contract: "BTC_USDT", FirstId: first, LastId: last, Bids: bids, Asks: asks})
+	return data
+}
+
+func waitDepth(c *cache.MemoryCache) (schema.Depth, bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if d, ok := c.GetDepth(schema.GATE, schema.FUTURESUSDT, "BTC_USDT"); ok {
+			return d, true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return schema.Depth{}, false
+}
+
+func TestHandleDepth_SnapshotSync(t *testing.T) {
+	f, c := newDepthTestWS(t)
+
+	// 快照前的增量被缓存，快照加载后丢弃 u <= id 的部分并回放其余增量
+	f.handleDepth(depthUpdate(95, 100, []gateLevel{{P: "98", S: decimal.NewFromInt(5)}}, nil))
+	f.handleDepth(depthUpdate(101, 102, []gateLevel{{P: "99.5", S: decimal.NewFromInt(20)}}, nil))
+
+	d, ok := waitDepth(c)
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	// 张数按 quanto_multiplier 换算为币数量
+	if d.LastUpdateId != "102" || len(d.Bids) != 2 || d.Bids[0].Price.String() != "99.5" || d.Bids[0].Quantity.String() != "0.002" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+	if d.Asks[0].Quantity.String() != "0.001" {
+		t.Fatalf("unexpected ask quantity: %s", d.Asks[0].Quantity)
+	}
+
+	// 同步完成后增量直接应用
+	f.handleDepth(depthUpdate(103, 103, nil, []gateLevel{{P: "101", S: decimal.Zero}, {P: "100.5", S: decimal.NewFromInt(30)}}))
+	d, _ = c.GetDepth(schema.GATE, schema.FUTURESUSDT, "BTC_USDT")
+	if d.LastUpdateId != "103" || len(d.Asks) != 1 || d.Asks[0].Price.String() != "100.5" || d.Asks[0].Quantity.String() != "0.003" {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+}
+
+func TestHandleDepth_SnapshotInsideLiveUpdate(t *testing.T) {
+	f, c := newDepthTestWS(t)
+
+	// 缓存的增量全部早于快照，快照加载后没有回放任何增量
+	f.handleDepth(depthUpdate(90, 95, []gateLevel{{P: "98", S: decimal.NewFromInt(5)}}, nil))
+	if _, ok := waitDepth(c); !ok {
+		t.Fatalf("depth not cached")
+	}
+
+	// 同步后的第一条实时增量跨越快照 id（U=98 <= 101 <= u=104），应直接应用而不是重新同步
+	f.handleDepth(depthUpdate(98, 104, []gateLevel{{P: "99.5", S: decimal.NewFromInt(20)}}, nil))
+	d, _ := c.GetDepth(schema.GATE, schema.FUTURESUSDT, "BTC_USDT")
+	if d.LastUpdateId != "104" || len(d.Bids) != 2 {
+		t.Fatalf("straddling update not applied: %+v", d)
+	}
+
+	// 之后的增量要求严格连续
+	f.handleDepth(depthUpdate(105, 106, nil, []gateLevel{{P: "100.5", S: decimal.NewFromInt(30)}}))
+	d, _ = c.GetDepth(schema.GATE, schema.FUTURESUSDT, "BTC_USDT")
+	if d.LastUpdateId != "106" || len(d.Asks) != 2 {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+	f.handleDepth(depthUpdate(104, 108, nil, nil))
+	f.mu.Lock()
+	_, resyncing := f.syncs["BTC_USDT"]
+	f.mu.Unlock()
+	if !resyncing {
+		t.Fatalf("overlapping update after sync should trigger resync")
+	}
+}
+
+func TestGetKline_DelegatesToGetKlines(t *testing.T) {
+	var from, to int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, apiFuturesContracts+"/BTC_USDT"):
+			_, _ = w.Write([]byte(`{"name":"BTC_USDT","type":"direct","quanto_multiplier":"0.0001","status":"trading"}`))
+		case strings.HasSuffix(r.URL.Path, apiFuturesCandlesticks):
+			from, _ = strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+			to, _ = strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+			var rows []gateCandle
+			for ts := from - from%60; ts <= to; ts += 60 {
+				rows = append(rows, gateCandle{T: ts, V: decimal.NewFromInt(10), O: "1", H: "1", L: "1", C: "1", Sum: "10"})
+			}
+			_ = json.NewEncoder(w).Encode(rows)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	rest := NewFuturesUSDTREST()
+	rest.http.SetBaseURL(srv.URL)
+
+	klines, err := rest.GetKline(context.Background(), "BTC_USDT", schema.Interval1m, 3)
+	if err != nil {
+		t.Fatalf("GetKline: %v", err)
+	}
+	// 请求从当前分钟向前 2 根开始，返回最近 3 根（含当前未收盘的K线）
+	if to-from < 120 || from%60 != 0 {
+		t.Fatalf("unexpected request window: from=%d to=%d", from, to)
+	}
+	if len(klines) != 3 || klines[2].IsFinal || !klines[1].IsFinal || klines[2].Volume.String() != "0.001" {
+		t.Fatalf("unexpected klines: %+v", klines)
+	}
+	if _, err := rest.GetKline(context.Background(), "BTC_USDT", schema.Interval1m, 0); err == nil {
+		t.Fatalf("limit 0 should be rejected")
+	}
+}
+
+func TestHandleTrade_SkipsUntilMultiplierLoaded(t *testing.T) {
+	f, c := newDepthTestWS(t)
+	push := []byte(`{"time":1700000000,"channel":"futures.trades","event":"update","result":[{"id":1,"size":-500,"create_time_ms":1700000000000,"price":"20000","contract":"BTC_USDT"}]}`)
+
+	// 合约乘数未缓存时跳过推送，由后台加载
+	f.handleRawMessage(push)
+	if _, ok := c.GetTrades(schema.GATE, schema.FUTURESUSDT, "BTC_USDT", 10); ok {
+		t.Fatalf("trade must be skipped until the multiplier is cached")
+	}
+
+	// 订阅时预先加载合约乘数，之后的推送直接换算
+	if err := f.SubscribeTrades(context.Background(), []string{"BTC_USDT"}); err != nil {
+		t.Fatalf("SubscribeTrades: %v", err)
+	}
+	f.handleRawMessage(push)
+	trades, ok := c.GetTrades(schema.GATE, schema.FUTURESUSDT, "BTC_USDT", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	// 500 张 × 0.0001 BTC = 0.05 BTC
+	if tr := trades[0]; tr.Side != schema.OrderSideSell || tr.Quantity.String() != "0.05" || tr.QuoteQty.String() != "1000" {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+func TestHandleKline_QuoteVolume(t *testing.T) {
+	f, c := newDepthTestWS(t)
+	if err := f.SubscribeKline(context.Background(), []string{"BTC_USDT"}, schema.Interval1m); err != nil {
+		t.Fatalf("SubscribeKline: %v", err)
+	}
+
+	// 未返回 a 时由张数换算成交量，成交额按收盘价估算
+	f.handleRawMessage([]byte(`{"time":1700000040,"channel":"futures.candlesticks","event":"update","result":[{"t":1700000040,"v":2000,"c":"20000","h":"20100","l":"19900","o":"19950","n":"1m_BTC_USDT","w":false}]}`))
+
+	klines, ok := c.GetKline(schema.GATE, schema.FUTURESUSDT, "BTC_USDT", schema.Interval1m)
+	if !ok || len(klines) != 1 {
+		t.Fatalf("kline not cached")
+	}
+	if k := klines[0]; k.Volume.String() != "0.2" || k.QuoteVolume.String() != "4000" {
+		t.Fatalf("unexpected kline: %+v", k)
+	}
+}
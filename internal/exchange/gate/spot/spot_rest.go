@@ -15,55 +15,89 @@ import (
 )
 
 const (
-	gateBaseURL         = "https://api.gateio.ws/api/v4"
-	apiSpotTickers      = "/spot/tickers"
-	apiSpotCandlesticks = "/spot/candlesticks"
-	apiSpotOrderBook    = "/spot/order_book"
+	gateBaseURL          = "https://api.gateio.ws/api/v4"
+	apiSpotTickers       = "/spot/tickers"
+	apiSpotCandlesticks  = "/spot/candlesticks"
+	apiSpotOrderBook     = "/spot/order_book"
+	apiSpotCurrencyPairs = "/spot/currency_pairs"
+	apiSpotTime          = "/spot/time" // 服务器时间，现货与合约共用
 )
 
+// gateOrderBook 是 /spot/order_book?with_id=true 的响应
+type gateOrderBook struct {
+	Id      int64      `json:"id"`
+	Current int64      `json:"current"` // 毫秒
+	Update  int64      `json:"update"`  // 毫秒
+	Asks    [][]string `json:"asks"`
+	Bids    [][]string `json:"bids"`
+}
+
 type SpotREST struct{ http *resty.Client }
 
 func NewSpotREST() *SpotREST {
 	return &SpotREST{http: resty.New().SetBaseURL(gateBaseURL).SetTimeout(10 * time.Second)}
 }
 
+// GetTicker 获取单个币对的24小时行情
 func (s *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
-	var resp struct {
-		CurrencyPair     string `json:"currency_pair"`
-		Last             string `json:"last"`
-		LowestAsk        string `json:"lowest_ask"`
-		HighestBid       string `json:"highest_bid"`
-		ChangePercentage string `json:"change_percentage"`
-		BaseVolume       string `json:"base_volume"`
-		QuoteVolume      string `json:"quote_volume"`
-		High24h          string `json:"high_24h"`
-		Low24h           string `json:"low_24h"`
-	}
-	r, err := s.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("currency_pair", symbol).Get(apiSpotTickers)
+	tickers, err := s.tickers(ctx, symbol)
 	if err != nil {
 		return schema.Ticker{}, err
 	}
+	if len(tickers) == 0 {
+		return schema.Ticker{}, errors.New("no ticker data")
+	}
+	return tickers[0], nil
+}
+
+// GetTickers 获取全部币对的24小时行情
+func (s *SpotREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	return s.tickers(ctx, "")
+}
+
+// tickers 请求24小时行情，指定币对时接口同样返回数组；行情不带时间戳，使用本地时间
+func (s *SpotREST) tickers(ctx context.Context, symbol string) ([]schema.Ticker, error) {
+	var resp []schema.GateTickerResponse
+	req := s.http.R().SetContext(ctx).SetResult(&resp)
+	if symbol != "" {
+		req.SetQueryParam("currency_pair", symbol)
+	}
+	r, err := req.Get(apiSpotTickers)
+	if err != nil {
+		return nil, err
+	}
 	if r.IsError() {
-		return schema.Ticker{}, errors.New(r.Status())
+		return nil, errors.New(r.Status())
 	}
 
-	// 保存原始响应结果用于调试
-	rawResponse := string(r.Body())
-	logger.Debug("Gate Spot Ticker 原始响应: %s", rawResponse)
+	logger.Debug("Gate Spot Ticker 原始响应: %s", string(r.Body()))
+
+	now := time.Now()
+	out := make([]schema.Ticker, 0, len(resp))
+	for _, t := range resp {
+		out = append(out, convertTicker(t, now))
+	}
+	return out, nil
+}
 
-	price, _ := decimal.NewFromString(resp.Last)
-	volume, _ := decimal.NewFromString(resp.BaseVolume)
-	quoteVolume, _ := decimal.NewFromString(resp.QuoteVolume)
+func convertTicker(t schema.GateTickerResponse, ts time.Time) schema.Ticker {
+	price, _ := decimal.NewFromString(t.Last)
+	high, _ := decimal.NewFromString(t.High24h)
+	low, _ := decimal.NewFromString(t.Low24h)
+	volume, _ := decimal.NewFromString(t.BaseVolume)
+	quoteVolume, _ := decimal.NewFromString(t.QuoteVolume)
 
 	return schema.Ticker{
 		Exchange:  schema.GATE,
 		Market:    schema.SPOT,
-		Symbol:    resp.CurrencyPair,
+		Symbol:    t.CurrencyPair,
 		Price:     price,
+		High:      high,
+		Low:       low,
 		Volume:    volume,
 		QuoteVol:  quoteVolume,
-		Timestamp: time.Now(),
-	}, nil
+		Timestamp: ts,
+	}
 }
 
 func (s *SpotREST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
@@ -75,9 +109,10 @@ func (s *SpotREST) GetKline(ctx context.Context, symbol string, interval schema.
 	if r.IsError() {
 		return nil, errors.New(r.Status())
 	}
+	// [t(秒), 计价币成交额, close, high, low, open, 基础币成交量, 是否完结]
 	out := make([]schema.Kline, 0, len(resp))
 	for _, row := range resp {
-		if len(row) < 6 {
+		if len(row) < 7 {
 			continue
 		}
 		ts, _ := strconv.ParseInt(row[0], 10, 64)
@@ -85,34 +120,102 @@ func (s *SpotREST) GetKline(ctx context.Context, symbol string, interval schema.
 		h, _ := decimal.NewFromString(row[3])
 		l, _ := decimal.NewFromString(row[4])
 		c, _ := decimal.NewFromString(row[2])
-		v, _ := decimal.NewFromString(row[1])
-		out = append(out, schema.Kline{Exchange: schema.GATE, Market: schema.SPOT, Symbol: symbol, Interval: interval, OpenTime: time.Unix(ts, 0), CloseTime: time.Unix(ts, 0), Open: o, High: h, Low: l, Close: c, Volume: v, IsFinal: true})
+		qv, _ := decimal.NewFromString(row[1])
+		v, _ := decimal.NewFromString(row[6])
+		isFinal := true
+		if len(row) >= 8 {
+			isFinal = row[7] == "true"
+		}
+		openTime := time.Unix(ts, 0)
+		closeTime := openTime.Add(interval.Duration() - time.Millisecond)
+		out = append(out, schema.Kline{Exchange: schema.GATE, Market: schema.SPOT, Symbol: symbol, Interval: interval, OpenTime: openTime, CloseTime: closeTime, Open: o, High: h, Low: l, Close: c, Volume: v, QuoteVolume: qv, IsFinal: isFinal})
 	}
 	return out, nil
 }
 
-func (s *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
-	var resp struct {
-		Id      int64      `json:"id"`
-		Current int64      `json:"current"`
-		Update  int64      `json:"update"`
-		Asks    [][]string `json:"asks"`
-		Bids    [][]string `json:"bids"`
+// maxKlinesPerRequest Gate 现货K线接口单次最多返回的条数，指定 from/to 时按该条数切分时间窗口
+const maxKlinesPerRequest = 1000
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按时间窗口由旧到新分页拉取，end 为零值时取到当前时间
+func (s *SpotREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
 	}
-	r, err := s.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
-		"currency_pair": symbol,
-		"limit":         fmt.Sprintf("%d", limit),
-	}).Get(apiSpotOrderBook)
-	if err != nil {
-		return schema.Depth{}, err
+	if end.IsZero() {
+		end = time.Now()
 	}
-	if r.IsError() {
-		return schema.Depth{}, errors.New(r.Status())
+
+	var out []schema.Kline
+	window := time.Duration(maxKlinesPerRequest-1) * interval.Duration()
+	for from := start; !from.After(end); {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+		var resp schema.GateKlineResponse
+		r, err := s.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+			"currency_pair": symbol,
+			"interval":      klineIntervals[interval],
+			"from":          strconv.FormatInt(from.Unix(), 10),
+			"to":            strconv.FormatInt(to.Unix(), 10),
+		}).Get(apiSpotCandlesticks)
+		if err != nil {
+			return nil, err
+		}
+		if r.IsError() {
+			return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+		}
+		logger.Debug("Gate Spot Kline 返回 %d 条", len(resp))
+
+		out = append(out, convertKlines(resp, symbol, interval)...)
+		from = to.Add(time.Second)
 	}
 
-	// 保存原始响应结果用于调试
-	rawResponse := string(r.Body())
-	logger.Debug("Gate Spot Depth 原始响应: %s", rawResponse)
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// convertKlines 转换K线数组：[t(秒), 计价币成交额, close, high, low, open, 基础币成交量, 是否完结]
+func convertKlines(rows schema.GateKlineResponse, symbol string, interval schema.Interval) []schema.Kline {
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 8 {
+			continue
+		}
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		quoteVolume, _ := decimal.NewFromString(row[1])
+		close, _ := decimal.NewFromString(row[2])
+		high, _ := decimal.NewFromString(row[3])
+		low, _ := decimal.NewFromString(row[4])
+		open, _ := decimal.NewFromString(row[5])
+		volume, _ := decimal.NewFromString(row[6])
+		openTime := time.Unix(ts, 0)
+		out = append(out, schema.Kline{
+			Exchange:    schema.GATE,
+			Market:      schema.SPOT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row[7] == "true",
+		})
+	}
+	return out
+}
+
+func (s *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
+	resp, err := s.orderBook(ctx, symbol, limit)
+	if err != nil {
+		return schema.Depth{}, err
+	}
 
 	convert := func(levels [][]string) []schema.PriceLevel {
 		out := make([]schema.PriceLevel, 0, len(levels))
@@ -135,20 +238,119 @@ func (s *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (sche
 		Symbol:       symbol,
 		Bids:         convert(resp.Bids),
 		Asks:         convert(resp.Asks),
-		UpdatedAt:    time.Unix(resp.Update, 0),
+		UpdatedAt:    time.UnixMilli(resp.Update),
 		LastUpdateId: fmt.Sprintf("%d", resp.Id),
 	}, nil
 }
 
+// orderBook 获取带 id 的深度快照，供 WebSocket 增量同步使用
+func (s *SpotREST) orderBook(ctx context.Context, symbol string, limit int) (*gateOrderBook, error) {
+	var resp gateOrderBook
+	r, err := s.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"currency_pair": symbol,
+		"limit":         fmt.Sprintf("%d", limit),
+		"with_id":       "true",
+	}).Get(apiSpotOrderBook)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+	}
+
+	// 保存原始响应结果用于调试
+	rawResponse := string(r.Body())
+	logger.Debug("Gate Spot Depth 原始响应: %s", rawResponse)
+
+	return &resp, nil
+}
+
+// gateCurrencyPair 是 /spot/currency_pairs 的单个币对
+type gateCurrencyPair struct {
+	Id              string `json:"id"`
+	Base            string `json:"base"`
+	Quote           string `json:"quote"`
+	MinBaseAmount   string `json:"min_base_amount"`
+	MinQuoteAmount  string `json:"min_quote_amount"`
+	MaxBaseAmount   string `json:"max_base_amount"`
+	AmountPrecision int    `json:"amount_precision"`
+	Precision       int    `json:"precision"`
+	TradeStatus     string `json:"trade_status"` // untradable / buyable / sellable / tradable
+	BuyStart        int64  `json:"buy_start"`    // 秒
+}
+
+// serverTime 获取 Gate 服务器时间
+func (s *SpotREST) serverTime(ctx context.Context) (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"server_time"` // 毫秒
+	}
+	r, err := s.http.R().SetContext(ctx).SetResult(&resp).Get(apiSpotTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if r.IsError() {
+		return time.Time{}, errors.New(r.Status())
+	}
+	return time.UnixMilli(resp.ServerTime), nil
+}
+
+// GetExchangeInfo 获取现货交易规则，价格和数量步长由精度换算
 func (s *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现Gate交易规则信息获取
+	var resp []gateCurrencyPair
+	r, err := s.http.R().SetContext(ctx).SetResult(&resp).Get(apiSpotCurrencyPairs)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	if r.IsError() {
+		return schema.ExchangeInfo{}, errors.New(r.Status())
+	}
+	logger.Debug("Gate Spot ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+	now, err := s.serverTime(ctx)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	symbols := make([]schema.Symbol, 0, len(resp))
+	for _, p := range resp {
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       p.Id,
+			Base:         p.Base,
+			Quote:        p.Quote,
+			ExchangeName: schema.GATE,
+			MarketType:   schema.SPOT,
+
+			QuantityPrecision: p.AmountPrecision,
+			PricePrecision:    p.Precision,
+			MinQuantity:       p.MinBaseAmount,
+			MinNotional:       p.MinQuoteAmount,
+			MaxQuantity:       p.MaxBaseAmount,
+			TickSize:          decimal.New(1, -int32(p.Precision)).String(),
+			StepSize:          decimal.New(1, -int32(p.AmountPrecision)).String(),
+
+			Status: symbolStatus(p, now),
+		})
+	}
+
+	logger.Info("Gate Spot 交易规则已加载: %d 个交易对", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.GATE,
 		Market:     schema.SPOT,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: now,
 		RateLimits: []schema.RateLimit{},
 		Timezone:   "UTC",
 	}, nil
 }
+
+// symbolStatus 归一化币对状态，只能单向交易（buyable / sellable）的币对视为暂停交易
+func symbolStatus(p gateCurrencyPair, now time.Time) schema.SymbolStatus {
+	switch {
+	case p.TradeStatus == "tradable":
+		return schema.SymbolStatusTrading
+	case p.BuyStart > now.Unix():
+		return schema.SymbolStatusPreTrading
+	default:
+		return schema.SymbolStatusHalt
+	}
+}
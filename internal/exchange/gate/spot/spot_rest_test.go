@@ -58,3 +58,49 @@ func TestGateSpotWS_Kline(t *testing.T) {
 
 	log.Printf("=== Gate WS Kline 集成测试完成 ===")
 }
+
+// 跨度超过单次上限，验证分页、去重与升序
+func TestGateSpotREST_Klines(t *testing.T) {
+	r := NewSpotREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-1500 * time.Minute)
+	klines, err := r.GetKlines(ctx, "BTC_USDT", schema.Interval1m, start, end)
+	if err != nil {
+		t.Fatalf("klines error: %v", err)
+	}
+	if len(klines) < 1350 {
+		t.Fatalf("expected at least 1350 klines, got %d", len(klines))
+	}
+	for i, kl := range klines {
+		if kl.OpenTime.Before(start) || kl.OpenTime.After(end) {
+			t.Fatalf("kline %d out of range: %v", i, kl.OpenTime)
+		}
+		if i > 0 && !kl.OpenTime.After(klines[i-1].OpenTime) {
+			t.Fatalf("klines not ascending at %d: %v <= %v", i, kl.OpenTime, klines[i-1].OpenTime)
+		}
+	}
+	log.Printf("Gate 现货 REST Klines: %d 条, 首条=%v, 末条=%v", len(klines), klines[0].OpenTime, klines[len(klines)-1].OpenTime)
+}
+
+func TestGateSpotREST_ExchangeInfo(t *testing.T) {
+	r := NewSpotREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	info, err := r.GetExchangeInfo(ctx)
+	if err != nil {
+		t.Fatalf("exchange info error: %v", err)
+	}
+	var btc *schema.Symbol
+	for i := range info.Symbols {
+		if info.Symbols[i].Symbol == "BTC_USDT" {
+			btc = &info.Symbols[i]
+		}
+	}
+	if btc == nil || btc.TickSize == "" || btc.StepSize == "" || !btc.IsTrading() {
+		t.Fatalf("unexpected BTC_USDT: %+v", btc)
+	}
+	log.Printf("Gate Spot ExchangeInfo: 交易对数=%d, BTC_USDT=%+v", len(info.Symbols), *btc)
+}
@@ -2,389 +2,1134 @@ package spot
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
 	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
-
-	"github.com/shopspring/decimal"
 )
 
 const (
 	wsURL = "wss://api.gateio.ws/ws/v4/"
+
+	channelKline      = "spot.candlesticks"
+	channelDepth      = "spot.order_book_update"
+	channelTrade      = "spot.trades"
+	channelBookTicker = "spot.book_ticker"
+	channelTicker     = "spot.tickers"
+	channelPing       = "spot.ping"
+	channelPong       = "spot.pong"
+
+	depthFrequency  = "100ms"
+	depthSnapshotSz = 100
+
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
+
+	// 快照获取失败后的重试间隔
+	syncRetryInterval = 3 * time.Second
 )
 
+type gateMessage struct {
+	Time    int64    `json:"time"`
+	Channel string   `json:"channel"`
+	Event   string   `json:"event,omitempty"`
+	Payload []string `json:"payload,omitempty"`
+}
+
+// gateTrade 是 spot.trades 推送数据，side 为主动成交方向
+type gateTrade struct {
+	Id           int64  `json:"id"`
+	CreateTimeMs string `json:"create_time_ms"` // 毫秒，带小数
+	Side         string `json:"side"`
+	Pair         string `json:"currency_pair"`
+	Amount       string `json:"amount"`
+	Price        string `json:"price"`
+}
+
+// gateBookTicker 是 spot.book_ticker 推送数据
+type gateBookTicker struct {
+	T        int64  `json:"t"` // 毫秒
+	U        int64  `json:"u"` // 订单簿更新ID
+	Pair     string `json:"s"`
+	BidPrice string `json:"b"`
+	BidSize  string `json:"B"`
+	AskPrice string `json:"a"`
+	AskSize  string `json:"A"`
+}
+
+// gateBookUpdate 是 spot.order_book_update 推送数据
+type gateBookUpdate struct {
+	T       int64      `json:"t"` // 毫秒
+	Pair    string     `json:"s"`
+	FirstId int64      `json:"U"`
+	LastId  int64      `json:"u"`
+	Bids    [][]string `json:"b"` // [价格, 数量]
+	Asks    [][]string `json:"a"`
+}
+
+// gateCandlestick 是 spot.candlesticks 推送数据，数值均为字符串
+type gateCandlestick struct {
+	T      string `json:"t"` // 秒
+	Open   string `json:"o"`
+	High   string `json:"h"`
+	Low    string `json:"l"`
+	Close  string `json:"c"`
+	Volume string `json:"v"` // 计价币成交额
+	Amount string `json:"a"` // 基础币成交量
+	Name   string `json:"n"` // 1m_BTC_USDT
+	Closed bool   `json:"w"` // K线是否已完结
+}
+
+// klineIntervals schema.Interval 到 Gate K线周期的映射，Gate 不支持 3m
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1m",
+	schema.Interval5m:  "5m",
+	schema.Interval15m: "15m",
+	schema.Interval30m: "30m",
+	schema.Interval1h:  "1h",
+	schema.Interval4h:  "4h",
+	schema.Interval1d:  "1d",
+}
+
+// orderBook 本地订单簿
+type orderBook struct {
+	lastId int64
+	bids   map[string]decimal.Decimal // price -> quantity
+	asks   map[string]decimal.Decimal // price -> quantity
+
+	// 是否已应用快照之后的第一条增量；之前按 U <= id+1 <= u 衔接，之后要求 U == u+1
+	synced bool
+}
+
+// bookSync 记录快照同步期间缓存的增量
+type bookSync struct {
+	buffer   []gateBookUpdate
+	syncing  bool
+	failedAt time.Time
+}
+
 type SpotWS struct {
-	conn interfaces.WSConn
-	mu   sync.RWMutex
-	subs interfaces.SubscriptionManager
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+	rest    *SpotREST
+
+	// per-pair local order books and sync state
+	orderBooks map[string]*orderBook
+	syncs      map[string]*bookSync
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewSpotWS(subs interfaces.SubscriptionManager) *SpotWS {
+func NewSpotWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *SpotREST) *SpotWS {
+	d := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &SpotWS{
-		subs: subs,
+		dialer:     d,
+		cache:      c,
+		gaps:       cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:       subs,
+		rest:       rest,
+		orderBooks: make(map[string]*orderBook),
+		syncs:      make(map[string]*bookSync),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 }
 
 func (s *SpotWS) Connect(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.conn != nil {
+		s.mu.Unlock()
 		logger.Info("Gate Spot WS 已连接，跳过连接")
 		return nil
 	}
 
 	logger.Info("Gate Spot WS 开始连接...")
+	conn, _, err := s.dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		s.mu.Unlock()
+		logger.Error("Gate Spot WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024)
+	s.conn = conn
+	s.mu.Unlock()
 
-	// 这里应该实现实际的WebSocket连接逻辑
-	// 由于没有具体的连接实现，我们只是记录日志
-	logger.Info("Gate Spot WS 连接成功")
+	now := time.Now()
+	s.healthMu.Lock()
+	s.lastMessage = now
+	s.lastPing = now
+	s.healthMu.Unlock()
 
-	// 启动消息读取
-	go s.StartReading(ctx)
+	logger.Info("Gate Spot WS 连接成功")
 
+	// 重连时自动恢复订阅
+	_ = s.applySubscriptions(ctx)
 	return nil
 }
 
 func (s *SpotWS) Close() error {
+	s.cancel()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.conn != nil {
-		return s.conn.Close()
+		err := s.conn.Close()
+		s.conn = nil
+		return err
 	}
 	return nil
 }
 
-func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
 	}
 
-	// 固定订阅1m K线数据
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+	newlyAdded := s.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
 	if len(newlyAdded) == 0 {
 		logger.Info("Gate Spot WS 所有币对都已订阅 kline，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("Gate Spot WS 新增订阅 kline: %v (固定1m)", newlyAdded)
+	logger.Info("Gate Spot WS 新增订阅 kline: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("Gate Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendKline(ctx, "subscribe", newlyAdded)
+}
+
+// UnsubscribeKline 只退订K线频道的指定周期，未指定周期时退订全部周期
+func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("Gate Spot WS 所有币对都未订阅 kline，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Spot WS 退订 kline: %v", removed)
+	s.gaps.Forget(removed)
 
-	// 构建并发送 kline 订阅消息
-	return s.sendKlineSubscription(conn, newlyAdded)
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.sendKline(ctx, "unsubscribe", removed)
 }
 
-func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Spot WS 所有币对都已订阅 depth，跳过订阅请求")
+		return nil
 	}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("Gate Spot WS 所有币对都已退订 kline，跳过退订请求")
+	logger.Info("Gate Spot WS 新增订阅 depth: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendDepth(ctx, "subscribe", newlyAdded)
+}
 
-	logger.Info("Gate Spot WS 退订 kline: %v", actuallyRemoved)
+func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
+	return s.unsubscribe(ctx, symbols, "depth")
+}
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
-		logger.Warn("Gate Spot WS 未连接，退订状态已保存，连接后将自动应用")
+func (s *SpotWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Spot WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("Gate Spot WS 新增订阅 trades: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendKlineUnsubscription(conn, actuallyRemoved)
+	return s.sendTrades(ctx, "subscribe", newlyAdded)
 }
 
-func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Spot WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Spot WS 退订 trades: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
 	}
+	return s.sendTrades(ctx, "unsubscribe", removed)
+}
 
-	// Subscribe only depth data
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+func (s *SpotWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
 	if len(newlyAdded) == 0 {
-		logger.Info("Gate Spot WS 所有币对都已订阅 depth，跳过订阅请求")
+		logger.Info("Gate Spot WS 所有币对都已订阅 book_ticker，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("Gate Spot WS 新增订阅 depth: %v", newlyAdded)
+	logger.Info("Gate Spot WS 新增订阅 book_ticker: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("Gate Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendBookTicker(ctx, "subscribe", newlyAdded)
+}
 
-	// 构建并发送 depth 订阅消息
-	return s.sendDepthSubscription(conn, newlyAdded)
+func (s *SpotWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Spot WS 所有币对都未订阅 book_ticker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("Gate Spot WS 退订 book_ticker: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.sendBookTicker(ctx, "unsubscribe", removed)
 }
 
-func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("Gate Spot WS 所有币对都已订阅 tickers，跳过订阅请求")
+		return nil
 	}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("Gate Spot WS 所有币对都已退订 depth，跳过退订请求")
+	logger.Info("Gate Spot WS 新增订阅 tickers: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendTicker(ctx, "subscribe", newlyAdded)
+}
 
-	logger.Info("Gate Spot WS 退订 depth: %v", actuallyRemoved)
+func (s *SpotWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("Gate Spot WS 所有币对都未订阅 tickers，跳过退订请求")
+		return nil
+	}
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	logger.Info("Gate Spot WS 退订 tickers: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("Gate Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.sendTicker(ctx, "unsubscribe", removed)
+}
+
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 depth 频道
+func (s *SpotWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(s.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("Gate Spot WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
+	}
+
+	logger.Info("Gate Spot WS 退订 %s: %v", kind, removed)
+
+	s.mu.Lock()
+	for _, pair := range removed {
+		delete(s.orderBooks, pair)
+		delete(s.syncs, pair)
+	}
+	s.mu.Unlock()
+
+	if !s.isConnected() {
 		logger.Warn("Gate Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendDepthUnsubscription(conn, actuallyRemoved)
+
+	if err := s.sendKline(ctx, "unsubscribe", klines); err != nil {
+		return err
+	}
+	return s.sendDepth(ctx, "unsubscribe", removed)
 }
 
+// SendMessage sends a message to WebSocket server
 func (s *SpotWS) SendMessage(ctx context.Context, message interface{}) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
-
 	if conn == nil {
-		return fmt.Errorf("WebSocket connection not established")
+		return errors.New("WebSocket connection not established")
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("Gate Spot WS 序列化消息失败: %v", err)
+		return err
 	}
 
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("Gate Spot WS SendMessage: %s", string(jsonData))
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		logger.Error("Gate Spot WS 发送消息失败: %v", err)
+		return err
 	}
-	return conn.WriteJSON(message)
+
+	logger.Info("Gate Spot WS SendMessage: %s", string(data))
+	return nil
 }
 
-func (s *SpotWS) sendKlineSubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"time":    time.Now().Unix(),
-			"channel": "spot.candlesticks",
-			"event":   "subscribe",
-			"payload": []any{"1m", sym},
+// sendKline Gate 每条订阅消息只能携带一个币对
+func (s *SpotWS) sendKline(ctx context.Context, event string, subs []schema.KlineSubscription) error {
+	for _, sub := range subs {
+		msg := &gateMessage{
+			Time:    time.Now().Unix(),
+			Channel: channelKline,
+			Event:   event,
+			Payload: []string{klineIntervals[sub.Interval], sub.Symbol},
 		}
-		if err := conn.WriteJSON(msg); err != nil {
+		if err := s.SendMessage(ctx, msg); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (s *SpotWS) sendKlineUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"time":    time.Now().Unix(),
-			"channel": "spot.candlesticks",
-			"event":   "unsubscribe",
-			"payload": []any{"1m", sym}, // Use default interval for unsubscribe
+func (s *SpotWS) sendDepth(ctx context.Context, event string, pairs []string) error {
+	for _, pair := range pairs {
+		msg := &gateMessage{
+			Time:    time.Now().Unix(),
+			Channel: channelDepth,
+			Event:   event,
+			Payload: []string{pair, depthFrequency},
 		}
-		if err := conn.WriteJSON(msg); err != nil {
+		if err := s.SendMessage(ctx, msg); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (s *SpotWS) sendDepthSubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"time":    time.Now().Unix(),
-			"channel": "spot.order_book",
-			"event":   "subscribe",
-			"payload": []any{sym, "20", "100ms"},
+// sendTrades 成交频道支持在一条消息中携带多个币对
+func (s *SpotWS) sendTrades(ctx context.Context, event string, pairs []string) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	return s.SendMessage(ctx, &gateMessage{
+		Time:    time.Now().Unix(),
+		Channel: channelTrade,
+		Event:   event,
+		Payload: pairs,
+	})
+}
+
+// sendBookTicker 最优买卖价频道支持在一条消息中携带多个币对
+func (s *SpotWS) sendBookTicker(ctx context.Context, event string, pairs []string) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	return s.SendMessage(ctx, &gateMessage{
+		Time:    time.Now().Unix(),
+		Channel: channelBookTicker,
+		Event:   event,
+		Payload: pairs,
+	})
+}
+
+func (s *SpotWS) sendTicker(ctx context.Context, event string, pairs []string) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	return s.SendMessage(ctx, &gateMessage{
+		Time:    time.Now().Unix(),
+		Channel: channelTicker,
+		Event:   event,
+		Payload: pairs,
+	})
+}
+
+func (s *SpotWS) applySubscriptions(ctx context.Context) error {
+	klineSubs := s.subs.GetKlineSubscriptions()
+	depthSymbols := s.subs.GetDepthSymbols()
+	tradeSymbols := s.subs.GetTradeSymbols()
+	bookTickerSymbols := s.subs.GetBookTickerSymbols()
+	tickerSymbols := s.subs.GetTickerSymbols()
+	if len(klineSubs) == 0 && len(depthSymbols) == 0 && len(tradeSymbols) == 0 && len(bookTickerSymbols) == 0 && len(tickerSymbols) == 0 {
+		logger.Info("Gate Spot WS 无订阅")
+		return nil
+	}
+	if err := s.sendKline(ctx, "subscribe", klineSubs); err != nil {
+		return err
+	}
+	if err := s.sendDepth(ctx, "subscribe", depthSymbols); err != nil {
+		return err
+	}
+	if err := s.sendTrades(ctx, "subscribe", tradeSymbols); err != nil {
+		return err
+	}
+	if err := s.sendBookTicker(ctx, "subscribe", bookTickerSymbols); err != nil {
+		return err
+	}
+	return s.sendTicker(ctx, "subscribe", tickerSymbols)
+}
+
+// parseKlineName 由K线推送的 n 字段解析周期和币对
+func parseKlineName(name string) (schema.Interval, string, bool) {
+	parts := strings.SplitN(name, "_", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	for interval, v := range klineIntervals {
+		if v == parts[0] {
+			return interval, parts[1], true
 		}
-		if err := conn.WriteJSON(msg); err != nil {
-			return err
+	}
+	return "", "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for gate", interval)
 		}
 	}
 	return nil
 }
 
-func (s *SpotWS) sendDepthUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"time":    time.Now().Unix(),
-			"channel": "spot.order_book",
-			"event":   "unsubscribe",
-			"payload": []any{sym, "20", "100ms"},
-		}
-		if err := conn.WriteJSON(msg); err != nil {
-			return err
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
 		}
+		seen[s] = struct{}{}
+		out = append(out, s)
 	}
-	return nil
+	return out
+}
+
+func (s *SpotWS) isConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conn != nil
 }
 
+// StartReading starts read loop, handling heartbeats & reconnection internally
 func (s *SpotWS) StartReading(ctx context.Context) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
+	logger.Info("Gate Spot WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Gate Spot WS 上下文取消")
+				return
+			case <-s.ctx.Done():
+				logger.Info("Gate Spot WS 已关闭")
+				return
+			default:
+			}
+
+			s.mu.RLock()
+			conn := s.conn
+			s.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if s.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("Gate Spot WS 读取消息失败: %v", err)
+				s.reconnect(ctx)
+				continue
+			}
+
+			s.healthMu.Lock()
+			s.lastMessage = time.Now()
+			s.healthMu.Unlock()
+
+			s.handleRawMessage(message)
 		}
-		s.mu.RLock()
-		conn := s.conn
-		s.mu.RUnlock()
-		if conn == nil {
-			time.Sleep(200 * time.Millisecond)
-			continue
+	}()
+
+	return nil
+}
+
+func (s *SpotWS) handleRawMessage(message []byte) {
+	logger.Debug("Gate Spot WS 收到原始消息: %s", string(message))
+
+	var msg struct {
+		Channel string `json:"channel"`
+		Event   string `json:"event"`
+		Error   *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		TimeMs int64           `json:"time_ms"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("Gate Spot WS 解析原始消息失败: %v", err)
+		return
+	}
+
+	if msg.Error != nil {
+		logger.Error("Gate Spot WS %s %s 错误: code=%d, msg=%s", msg.Channel, msg.Event, msg.Error.Code, msg.Error.Message)
+		return
+	}
+
+	switch msg.Event {
+	case "update", "all":
+	case "subscribe", "unsubscribe":
+		logger.Info("Gate Spot WS %s %s 成功", msg.Channel, msg.Event)
+		return
+	default:
+		if msg.Channel == channelPong {
+			logger.Debug("Gate Spot WS 收到 pong")
+			return
 		}
-		var msg json.RawMessage
-		if err := conn.ReadJSON(&msg); err != nil {
-			time.Sleep(300 * time.Millisecond)
-			continue
+		logger.Debug("Gate Spot WS 未知事件: %s %s", msg.Channel, msg.Event)
+		return
+	}
+
+	switch msg.Channel {
+	case channelKline:
+		s.handleKline(msg.Result)
+	case channelDepth:
+		s.handleDepth(msg.Result)
+	case channelTrade:
+		s.handleTrade(msg.Result)
+	case channelBookTicker:
+		s.handleBookTicker(msg.Result)
+	case channelTicker:
+		s.handleTicker(msg.TimeMs, msg.Result)
+	default:
+		logger.Debug("Gate Spot WS 未知频道: %s", msg.Channel)
+	}
+}
+
+// handleTrade 处理公共成交，现货推送的 result 为单个对象
+func (s *SpotWS) handleTrade(data json.RawMessage) {
+	var row gateTrade
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("Gate Spot WS 解析trades失败: %v", err)
+		return
+	}
+
+	price, _ := decimal.NewFromString(row.Price)
+	quantity, _ := decimal.NewFromString(row.Amount)
+	ms, _ := decimal.NewFromString(row.CreateTimeMs)
+
+	side := schema.OrderSideBuy
+	if row.Side == "sell" {
+		side = schema.OrderSideSell
+	}
+
+	s.cache.AddTrade(schema.Trade{
+		Exchange:  schema.GATE,
+		Market:    schema.SPOT,
+		Symbol:    row.Pair,
+		TradeID:   strconv.FormatInt(row.Id, 10),
+		Side:      side,
+		Price:     price,
+		Quantity:  quantity,
+		QuoteQty:  price.Mul(quantity),
+		Timestamp: time.UnixMilli(ms.IntPart()),
+	})
+}
+
+// handleBookTicker 处理最优买卖价推送
+func (s *SpotWS) handleBookTicker(data json.RawMessage) {
+	var row gateBookTicker
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("Gate Spot WS 解析book_ticker失败: %v", err)
+		return
+	}
+
+	bidPrice, _ := decimal.NewFromString(row.BidPrice)
+	askPrice, _ := decimal.NewFromString(row.AskPrice)
+	bidQty, _ := decimal.NewFromString(row.BidSize)
+	askQty, _ := decimal.NewFromString(row.AskSize)
+
+	s.cache.SetBookTicker(schema.BookTicker{
+		Exchange:     schema.GATE,
+		Market:       schema.SPOT,
+		Symbol:       row.Pair,
+		BidPrice:     bidPrice,
+		BidQty:       bidQty,
+		AskPrice:     askPrice,
+		AskQty:       askQty,
+		UpdatedAt:    time.UnixMilli(row.T),
+		LastUpdateId: strconv.FormatInt(row.U, 10),
+	})
+}
+
+// handleTicker 处理24小时行情推送，spot.tickers 每次推送一个币对
+func (s *SpotWS) handleTicker(ts int64, data json.RawMessage) {
+	var row schema.GateTickerResponse
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("Gate Spot WS 解析tickers失败: %v", err)
+		return
+	}
+
+	s.cache.SetTicker(convertTicker(row, time.UnixMilli(ts)))
+}
+
+func (s *SpotWS) handleKline(data json.RawMessage) {
+	// 现货推送的 result 为单个对象
+	var row gateCandlestick
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("Gate Spot WS 解析kline失败: %v", err)
+		return
+	}
+
+	// n 的格式为 "1h_BTC_USDT"
+	interval, pair, ok := parseKlineName(row.Name)
+	if !ok {
+		logger.Warn("Gate Spot WS 未知K线名称: %s", row.Name)
+		return
+	}
+	ts, err := strconv.ParseInt(row.T, 10, 64)
+	if err != nil {
+		logger.Error("Gate Spot WS 解析kline时间失败: %v", err)
+		return
+	}
+	open, _ := decimal.NewFromString(row.Open)
+	high, _ := decimal.NewFromString(row.High)
+	low, _ := decimal.NewFromString(row.Low)
+	close, _ := decimal.NewFromString(row.Close)
+	volume, _ := decimal.NewFromString(row.Amount)
+	quoteVolume, _ := decimal.NewFromString(row.Volume)
+
+	openTime := time.Unix(ts, 0)
+	s.gaps.SetKline(schema.Kline{
+		Exchange:    schema.GATE,
+		Market:      schema.SPOT,
+		Symbol:      pair,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       close,
+		Volume:      volume,
+		QuoteVolume: quoteVolume,
+		IsFinal:     row.Closed,
+		EventTime:   time.Now(),
+	})
+}
+
+// handleDepth 按 Gate 文档维护本地订单簿：
+// 1. 订阅后先缓存增量，再通过 REST 获取带 id 的快照
+// 2. 丢弃 u <= id 的增量，第一条应用的增量需满足 U <= id+1 <= u
+// 3. 之后每条增量的 U 必须等于上一条的 u+1，否则重新同步
+func (s *SpotWS) handleDepth(data json.RawMessage) {
+	var update gateBookUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		logger.Error("Gate Spot WS 解析depth失败: %v", err)
+		return
+	}
+	pair := update.Pair
+
+	s.mu.Lock()
+	ob := s.orderBooks[pair]
+	if ob == nil {
+		st := s.syncs[pair]
+		if st == nil {
+			st = &bookSync{}
+			s.syncs[pair] = st
+		}
+		st.buffer = append(st.buffer, update)
+		start := !st.syncing && time.Since(st.failedAt) >= syncRetryInterval
+		if start {
+			st.syncing = true
+		}
+		s.mu.Unlock()
+		if start {
+			go s.syncOrderBook(pair)
 		}
+		return
+	}
 
-		// 检查是否是 ping 消息
-		if s.handlePingMessage(msg, conn) {
-			continue
+	if update.LastId <= ob.lastId {
+		s.mu.Unlock()
+		return
+	}
+	// 快照之后的第一条增量可能跨越快照 id（U <= id+1 <= u），之后的增量必须严格连续
+	if (ob.synced && update.FirstId != ob.lastId+1) || (!ob.synced && update.FirstId > ob.lastId+1) {
+		logger.Warn("Gate Spot WS %s 更新不连续: U=%d, 本地u=%d，重新同步快照",
+			pair, update.FirstId, ob.lastId)
+		delete(s.orderBooks, pair)
+		s.syncs[pair] = &bookSync{buffer: []gateBookUpdate{update}, syncing: true}
+		s.mu.Unlock()
+		go s.syncOrderBook(pair)
+		return
+	}
+	applyLevels(ob.bids, update.Bids)
+	applyLevels(ob.asks, update.Asks)
+	ob.lastId = update.LastId
+	ob.synced = true
+	s.mu.Unlock()
+
+	s.publishDepth(pair, update.T)
+}
+
+// syncOrderBook 获取 REST 快照并回放缓存的增量
+func (s *SpotWS) syncOrderBook(pair string) {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Second)
+	defer cancel()
+
+	snapshot, err := s.rest.orderBook(ctx, pair, depthSnapshotSz)
+	if err != nil {
+		logger.Error("Gate Spot WS 同步深度快照失败 %s: %v", pair, err)
+		s.mu.Lock()
+		if st := s.syncs[pair]; st != nil {
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
 		}
+		s.mu.Unlock()
+		return
+	}
+
+	s.mu.Lock()
+	st := s.syncs[pair]
+	if st == nil {
+		// 同步期间已退订
+		s.mu.Unlock()
+		return
+	}
 
-		var raw map[string]any
-		if err := json.Unmarshal(msg, &raw); err != nil {
+	ob := &orderBook{
+		lastId: snapshot.Id,
+		bids:   make(map[string]decimal.Decimal),
+		asks:   make(map[string]decimal.Decimal),
+	}
+	applyLevels(ob.bids, snapshot.Bids)
+	applyLevels(ob.asks, snapshot.Asks)
+
+	var lastTs int64
+	for _, update := range st.buffer {
+		if update.LastId <= ob.lastId {
 			continue
 		}
-		channel, _ := raw["channel"].(string)
-		if channel == "spot.candlesticks" {
-			var d struct {
-				Result map[string]string `json:"result"`
-			}
-			b, _ := json.Marshal(raw)
-			_ = json.Unmarshal(b, &d)
-			sym := d.Result["currency_pair"]
-			iv := d.Result["interval"]
-			o := decimal.RequireFromString(d.Result["open"])
-			h := decimal.RequireFromString(d.Result["high"])
-			l := decimal.RequireFromString(d.Result["low"])
-			c := decimal.RequireFromString(d.Result["close"])
-			v := decimal.RequireFromString(d.Result["base_volume"])
-			ts, _ := strconv.ParseInt(d.Result["t"], 10, 64)
-			k := schema.Kline{
-				Exchange:  schema.GATE,
-				Market:    schema.SPOT,
-				Symbol:    sym,
-				Interval:  schema.Interval(iv),
-				OpenTime:  time.Unix(ts, 0),
-				CloseTime: time.Unix(ts, 0),
-				Open:      o,
-				High:      h,
-				Low:       l,
-				Close:     c,
-				Volume:    v,
-				IsFinal:   true,
-			}
-			// Note: Since DataReader interface doesn't have AppendKline method,
-			// we'll need to handle this differently or extend the interface
-			logger.Info("Gate Spot WS 收到Kline数据: %+v", k)
-		} else if channel == "spot.order_book" {
-			// Handle depth data
-			logger.Info("Gate Spot WS 收到Depth数据: %s", channel)
+		if update.FirstId > ob.lastId+1 {
+			// 快照早于缓存的增量，稍后用新的快照重试
+			logger.Warn("Gate Spot WS %s 快照与增量不衔接: 快照id=%d, U=%d，稍后重试",
+				pair, ob.lastId, update.FirstId)
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
+			s.mu.Unlock()
+			return
 		}
+		applyLevels(ob.bids, update.Bids)
+		applyLevels(ob.asks, update.Asks)
+		ob.lastId = update.LastId
+		ob.synced = true
+		lastTs = update.T
+	}
+
+	s.orderBooks[pair] = ob
+	delete(s.syncs, pair)
+	s.mu.Unlock()
+
+	logger.Info("Gate Spot WS %s 深度快照已加载: id=%d, 买单%d档, 卖单%d档",
+		pair, snapshot.Id, len(snapshot.Bids), len(snapshot.Asks))
+
+	if lastTs == 0 {
+		lastTs = snapshot.Current
 	}
+	s.publishDepth(pair, lastTs)
 }
 
-// handlePingMessage 检查并处理 ping 消息
-func (s *SpotWS) handlePingMessage(data json.RawMessage, conn interfaces.WSConn) bool {
-	// Gate.io 使用 {"method": "server.ping", "params": [], "id": null} 作为心跳
-	var msg map[string]interface{}
-	if err := json.Unmarshal(data, &msg); err == nil {
-		if method, hasMethod := msg["method"]; hasMethod && method == "server.ping" {
-			logger.Info("Gate WS 收到 ping 消息")
-			// 发送 {"method": "server.pong", "params": [], "id": null} 响应
-			pongResponse := map[string]interface{}{
-				"method": "server.pong",
-				"params": []interface{}{},
-				"id":     nil,
-			}
-			if err := conn.WriteJSON(pongResponse); err != nil {
-				logger.Error("Gate WS 发送 pong 失败: %v", err)
-			}
-			return true
+// publishDepth 将本地订单簿写入缓存
+func (s *SpotWS) publishDepth(pair string, ts int64) {
+	if depth := s.buildDepthFromOrderBook(pair, ts); depth != nil {
+		s.cache.SetDepth(*depth)
+	}
+}
+
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]decimal.Decimal, levels [][]string) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
+		}
+		price, err := decimal.NewFromString(lv[0])
+		if err != nil {
+			continue
+		}
+		qty, err := decimal.NewFromString(lv[1])
+		if err != nil {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := price.String()
+		if qty.IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = qty
 		}
 	}
-	return false
 }
 
-// HandlePing 处理接收到的 ping（实现接口）
-func (s *SpotWS) HandlePing(data []byte) error {
-	logger.Info("Gate WS HandlePing called")
+// buildDepthFromOrderBook 将本地订单簿排序裁剪为 schema.Depth
+func (s *SpotWS) buildDepthFromOrderBook(pair string, ts int64) *schema.Depth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// 检查是否是 Gate.io ping 消息
-	var msg map[string]interface{}
-	if err := json.Unmarshal(data, &msg); err == nil {
-		if method, hasMethod := msg["method"]; hasMethod && method == "server.ping" {
-			// 发送 pong 响应
-			s.mu.RLock()
-			conn := s.conn
-			s.mu.RUnlock()
+	ob := s.orderBooks[pair]
+	if ob == nil {
+		return nil
+	}
 
-			if conn == nil {
-				return fmt.Errorf("WebSocket not connected")
-			}
+	bids := make([]schema.PriceLevel, 0, len(ob.bids))
+	for priceStr, qty := range ob.bids {
+		price, _ := decimal.NewFromString(priceStr)
+		bids = append(bids, schema.PriceLevel{Price: price, Quantity: qty})
+	}
+	asks := make([]schema.PriceLevel, 0, len(ob.asks))
+	for priceStr, qty := range ob.asks {
+		price, _ := decimal.NewFromString(priceStr)
+		asks = append(asks, schema.PriceLevel{Price: price, Quantity: qty})
+	}
 
-			pongResponse := map[string]interface{}{
-				"method": "server.pong",
-				"params": []interface{}{},
-				"id":     nil,
-			}
-			return conn.WriteJSON(pongResponse)
-		}
+	// 排序：买单降序，卖单升序
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	if len(bids) > maxDepthLevels {
+		bids = bids[:maxDepthLevels]
+	}
+	if len(asks) > maxDepthLevels {
+		asks = asks[:maxDepthLevels]
 	}
 
-	return nil
+	updatedAt := time.Now()
+	if ts > 0 {
+		updatedAt = time.UnixMilli(ts)
+	}
+
+	return &schema.Depth{
+		Exchange:     schema.GATE,
+		Market:       schema.SPOT,
+		Symbol:       pair,
+		Bids:         bids,
+		Asks:         asks,
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", ob.lastId),
+	}
 }
 
-// SendPing sends ping to server (if supported by exchange)
-func (s *SpotWS) SendPing(ctx context.Context) error {
+// HandlePing 处理服务端的 ping 帧
+func (s *SpotWS) HandlePing(data []byte) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
 
+// SendPing 发送应用层心跳 spot.ping，服务端回复 spot.pong
+func (s *SpotWS) SendPing(ctx context.Context) error {
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
 	if conn == nil {
-		return fmt.Errorf("WebSocket connection not established")
+		return errors.New("connection not established")
 	}
 
-	// Gate.io 使用 {"method": "server.ping", "params": [], "id": null} 作为心跳
-	pingMsg := map[string]interface{}{
-		"method": "server.ping",
-		"params": []interface{}{},
-		"id":     nil,
+	data, _ := json.Marshal(&gateMessage{Time: time.Now().Unix(), Channel: channelPing})
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		return err
 	}
-	return conn.WriteJSON(pingMsg)
+
+	s.healthMu.Lock()
+	s.lastPing = time.Now()
+	s.healthMu.Unlock()
+	return nil
 }
 
-// StartHealthCheck starts connection health monitoring
+// StartHealthCheck 空闲时发送心跳，超时未收到消息则重连
 func (s *SpotWS) StartHealthCheck(ctx context.Context) error {
-	// Gate.io 使用内置的 ping-pong 机制，这里不需要额外的健康检查
-	// 心跳处理已经在 StartReading 中实现
-	return nil
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Gate Spot WS 健康检查停止")
+			return nil
+		case <-s.ctx.Done():
+			logger.Info("Gate Spot WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			s.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) checkConnectionHealth(ctx context.Context) {
+	if !s.isConnected() {
+		return
+	}
+
+	s.healthMu.RLock()
+	sinceMsg := time.Since(s.lastMessage)
+	sincePing := time.Since(s.lastPing)
+	s.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("Gate Spot WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		s.reconnect(ctx)
+		return
+	}
+
+	if sinceMsg > pingInterval && sincePing > pingInterval {
+		if err := s.SendPing(ctx); err != nil {
+			logger.Warn("Gate Spot WS ping失败: %v", err)
+			s.reconnect(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) reconnect(ctx context.Context) {
+	s.reconnectMu.Lock()
+	if s.reconnecting {
+		s.reconnectMu.Unlock()
+		return
+	}
+	s.reconnecting = true
+	s.reconnectMu.Unlock()
+
+	defer func() {
+		s.reconnectMu.Lock()
+		s.reconnecting = false
+		s.reconnectMu.Unlock()
+	}()
+
+	s.mu.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	// 重连后重新获取快照
+	s.orderBooks = make(map[string]*orderBook)
+	s.syncs = make(map[string]*bookSync)
+	s.mu.Unlock()
+
+	for {
+		s.reconnectMu.Lock()
+		s.reconnectCount++
+		reconnectCount := s.reconnectCount
+		s.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("Gate Spot WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := s.Connect(ctx); err != nil {
+			logger.Error("Gate Spot WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("Gate Spot WS 重连成功")
+		// 补齐断线期间缺失的K线
+		s.gaps.Backfill(ctx, s.subs.GetKlineSubscriptions())
+		s.reconnectMu.Lock()
+		s.reconnectCount = 0
+		s.reconnectMu.Unlock()
+		return
+	}
 }
@@ -0,0 +1,170 @@
+package spot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestHandleKline(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleKline(json.RawMessage(`{"t":"1606292580","v":"2362.32035","c":"19128.1","h":"19128.1","l":"19128.1","o":"19128.1","n":"1m_BTC_USDT","a":"3.8283","w":true}`))
+
+	kl, ok := c.GetKline(schema.GATE, schema.SPOT, "BTC_USDT", schema.Interval1m)
+	if !ok || len(kl) != 1 {
+		t.Fatalf("kline not cached")
+	}
+	k := kl[0]
+	if !k.OpenTime.Equal(time.Unix(1606292580, 0)) || k.Volume.String() != "3.8283" || k.QuoteVolume.String() != "2362.32035" || !k.IsFinal {
+		t.Fatalf("unexpected kline: %+v", k)
+	}
+}
+
+func TestHandleDepth_SnapshotSync(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("with_id") != "true" {
+			t.Errorf("snapshot must be requested with_id=true")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":100,"current":1700000000000,"update":1700000000000,"asks":[["101","1"]],"bids":[["99","1"]]}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	rest := NewSpotREST()
+	rest.http.SetBaseURL(srv.URL)
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), rest)
+
+	update := func(first, last int64, bids, asks [][]string) json.RawMessage {
+		data, _ := json.Marshal(gateBookUpdate{T: 1700000000100, Pair: "BTC_USDT", FirstId: first, LastId: last, Bids: bids, Asks: asks})
+		return data
+	}
+
+	// 快照前的增量被缓存，快照加载后丢弃 u <= id 的部分并回放其余增量
+	s.handleDepth(update(95, 100, [][]string{{"98", "5"}}, nil))
+	s.handleDepth(update(101, 102, [][]string{{"99.5", "2"}}, nil))
+
+	deadline := time.Now().Add(2 * time.Second)
+	var d schema.Depth
+	for time.Now().Before(deadline) {
+		var ok bool
+		if d, ok = c.GetDepth(schema.GATE, schema.SPOT, "BTC_USDT"); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if d.LastUpdateId != "102" || len(d.Bids) != 2 || d.Bids[0].Price.String() != "99.5" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+
+	// 同步完成后增量直接应用
+	s.handleDepth(update(103, 103, nil, [][]string{{"101", "0"}, {"100.5", "3"}}))
+	d, _ = c.GetDepth(schema.GATE, schema.SPOT, "BTC_USDT")
+	if d.LastUpdateId != "103" || len(d.Asks) != 1 || d.Asks[0].Price.String() != "100.5" {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+}
+
+func TestHandleDepth_SnapshotInsideLiveUpdate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":100,"current":1700000000000,"update":1700000000000,"asks":[["101","1"]],"bids":[["99","1"]]}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	rest := NewSpotREST()
+	rest.http.SetBaseURL(srv.URL)
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), rest)
+
+	update := func(first, last int64, bids, asks [][]string) json.RawMessage {
+		data, _ := json.Marshal(gateBookUpdate{T: 1700000000100, Pair: "BTC_USDT", FirstId: first, LastId: last, Bids: bids, Asks: asks})
+		return data
+	}
+
+	// 缓存的增量全部早于快照，快照加载后没有回放任何增量
+	s.handleDepth(update(90, 95, [][]string{{"98", "5"}}, nil))
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := c.GetDepth(schema.GATE, schema.SPOT, "BTC_USDT"); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 同步后的第一条实时增量跨越快照 id（U=98 <= 101 <= u=104），应直接应用而不是重新同步
+	s.handleDepth(update(98, 104, [][]string{{"99.5", "2"}}, nil))
+	d, _ := c.GetDepth(schema.GATE, schema.SPOT, "BTC_USDT")
+	if d.LastUpdateId != "104" || len(d.Bids) != 2 {
+		t.Fatalf("straddling update not applied: %+v", d)
+	}
+
+	// 之后的增量要求严格连续
+	s.handleDepth(update(105, 106, nil, [][]string{{"100.5", "3"}}))
+	d, _ = c.GetDepth(schema.GATE, schema.SPOT, "BTC_USDT")
+	if d.LastUpdateId != "106" || len(d.Asks) != 2 {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+	s.handleDepth(update(104, 108, nil, nil))
+	s.mu.Lock()
+	_, resyncing := s.syncs["BTC_USDT"]
+	s.mu.Unlock()
+	if !resyncing {
+		t.Fatalf("overlapping update after sync should trigger resync")
+	}
+}
+
+func TestHandleTrade(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleTrade(json.RawMessage(`{"id":309143071,"create_time":1606292218,"create_time_ms":"1606292218213.4578","side":"sell","currency_pair":"GT_USDT","amount":"16.47","price":"0.4705","range":"2390902-2390902"}`))
+
+	trades, ok := c.GetTrades(schema.GATE, schema.SPOT, "GT_USDT", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	tr := trades[0]
+	if tr.TradeID != "309143071" || tr.Side != schema.OrderSideSell || tr.Timestamp.UnixMilli() != 1606292218213 {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+func TestHandleBookTicker(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleBookTicker(json.RawMessage(`{"t":1606293275123,"u":48733182,"s":"BTC_USDT","b":"19177.79","B":"0.0003341504","a":"19179.38","A":"0.09"}`))
+
+	bt, ok := c.GetBookTicker(schema.GATE, schema.SPOT, "BTC_USDT")
+	if !ok {
+		t.Fatalf("book ticker not cached")
+	}
+	if bt.BidPrice.String() != "19177.79" || bt.BidQty.String() != "0.0003341504" || bt.AskPrice.String() != "19179.38" ||
+		bt.LastUpdateId != "48733182" || bt.UpdatedAt.UnixMilli() != 1606293275123 {
+		t.Fatalf("unexpected book ticker: %+v", bt)
+	}
+}
+
+func TestHandleTicker(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"time":1669107766,"time_ms":1669107766406,"channel":"spot.tickers","event":"update","result":{"currency_pair":"BTC_USDT","last":"19106.55","lowest_ask":"19108.71","highest_bid":"19106.55","change_percentage":"3.66","base_volume":"2811.3042155865","quote_volume":"53441606.52411221454","high_24h":"19417.74","low_24h":"18434.21"}}`))
+
+	tk, ok := c.GetTicker(schema.GATE, schema.SPOT, "BTC_USDT")
+	if !ok {
+		t.Fatalf("ticker not cached")
+	}
+	if tk.Price.String() != "19106.55" || tk.High.String() != "19417.74" || tk.Low.String() != "18434.21" ||
+		tk.Volume.String() != "2811.3042155865" || tk.QuoteVol.String() != "53441606.52411221454" || tk.Timestamp.UnixMilli() != 1669107766406 {
+		t.Fatalf("unexpected ticker: %+v", tk)
+	}
+}
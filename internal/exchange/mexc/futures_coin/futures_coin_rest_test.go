@@ -0,0 +1,39 @@
+//go:build integration
+
+package futures_coin
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestMEXCFuturesCoinREST_Depth(t *testing.T) {
+	r := NewFuturesCoinREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	d, err := r.GetDepth(ctx, "BTC_USD", 5)
+	if err != nil {
+		t.Fatalf("depth error: %v", err)
+	}
+	if len(d.Bids) == 0 || len(d.Asks) == 0 {
+		t.Fatalf("empty orderbook")
+	}
+	log.Printf("MEXC Futures Coin REST Depth: Bids=%d, Asks=%d, 最佳买价=%v(%v BTC), 最佳卖价=%v(%v BTC)",
+		len(d.Bids), len(d.Asks), d.Bids[0].Price, d.Bids[0].Quantity, d.Asks[0].Price, d.Asks[0].Quantity)
+}
+
+func TestMEXCFuturesCoinREST_ExchangeInfo(t *testing.T) {
+	r := NewFuturesCoinREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	info, err := r.GetExchangeInfo(ctx)
+	if err != nil {
+		t.Fatalf("exchange info error: %v", err)
+	}
+	if len(info.Symbols) == 0 {
+		t.Fatalf("no symbols")
+	}
+	log.Printf("MEXC Futures Coin ExchangeInfo: 合约数=%d, 示例=%+v", len(info.Symbols), info.Symbols[0])
+}
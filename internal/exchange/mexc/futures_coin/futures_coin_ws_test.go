@@ -0,0 +1,106 @@
+package futures_coin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func newTestWS(t *testing.T) (*FuturesCoinWS, *cache.MemoryCache) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == apiV1ContractDetail:
+			_, _ = w.Write([]byte(`{"success":true,"code":0,"data":{"symbol":"BTC_USD","baseCoin":"BTC","settleCoin":"BTC","contractSize":100}}`))
+		case strings.HasPrefix(r.URL.Path, apiV1ContractDepth):
+			_, _ = w.Write([]byte(`{"success":true,"code":0,"data":{"asks":[[125,10,1]],"bids":[[100,10,1]],"version":100,"timestamp":1700000000000}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := cache.NewMemoryCache()
+	rest := NewFuturesCoinREST()
+	rest.http.SetBaseURL(srv.URL)
+	return NewFuturesCoinWS(c, cache.NewSubscriptionManager(), rest), c
+}
+
+func waitDepth(c *cache.MemoryCache) (schema.Depth, bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if d, ok := c.GetDepth(schema.MEXC, schema.FUTURESCOIN, "BTC_USD"); ok {
+			return d, true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return schema.Depth{}, false
+}
+
+func TestHandleDepth_VersionSync(t *testing.T) {
+	f, c := newTestWS(t)
+
+	// 快照前的增量被缓存，快照加载后丢弃 version <= 快照 version 的部分并回放其余增量
+	f.handleRawMessage([]byte(`{"channel":"push.depth","symbol":"BTC_USD","ts":1700000000050,"data":{"asks":[],"bids":[[98,5,1]],"version":100}}`))
+	f.handleRawMessage([]byte(`{"channel":"push.depth","symbol":"BTC_USD","ts":1700000000100,"data":{"asks":[],"bids":[[80,20,1]],"version":101}}`))
+
+	d, ok := waitDepth(c)
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	// 反向合约按 张数 × 面值 / 价格 换算为基础币数量：20 × 100 / 80 = 25
+	if d.LastUpdateId != "101" || len(d.Bids) != 2 || d.Bids[1].Price.String() != "80" || d.Bids[1].Quantity.String() != "25" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+	if d.Asks[0].Quantity.String() != "8" {
+		t.Fatalf("unexpected ask quantity: %s", d.Asks[0].Quantity)
+	}
+
+	// 同步完成后连续的增量直接应用，数量为0时删除价位
+	f.handleRawMessage([]byte(`{"channel":"push.depth","symbol":"BTC_USD","ts":1700000000200,"data":{"asks":[[125,0,0],[200,30,1]],"bids":[],"version":102}}`))
+	d, _ = c.GetDepth(schema.MEXC, schema.FUTURESCOIN, "BTC_USD")
+	if d.LastUpdateId != "102" || len(d.Asks) != 1 || d.Asks[0].Price.String() != "200" || d.Asks[0].Quantity.String() != "15" {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+
+	// version 跳跃时丢弃本地订单簿并重新同步
+	f.handleRawMessage([]byte(`{"channel":"push.depth","symbol":"BTC_USD","ts":1700000000300,"data":{"asks":[],"bids":[[97,1,1]],"version":104}}`))
+	f.mu.Lock()
+	_, hasBook := f.orderBooks["BTC_USD"]
+	f.mu.Unlock()
+	if hasBook {
+		t.Fatalf("order book should be dropped after version gap")
+	}
+}
+
+func TestHandleDeal_ContractsToBase(t *testing.T) {
+	f, c := newTestWS(t)
+	push := []byte(`{"channel":"push.deal","symbol":"BTC_USD","ts":1700000000000,"data":{"p":20000,"v":500,"T":2,"O":3,"M":2,"t":1700000000000}}`)
+
+	// 合约面值未缓存时跳过推送，由后台加载
+	f.handleRawMessage(push)
+	if _, ok := c.GetTrades(schema.MEXC, schema.FUTURESCOIN, "BTC_USD", 10); ok {
+		t.Fatalf("trade must be skipped until the contract size is cached")
+	}
+
+	// 订阅时预先加载合约面值，之后的推送直接换算
+	if err := f.SubscribeTrades(context.Background(), []string{"BTC_USD"}); err != nil {
+		t.Fatalf("SubscribeTrades: %v", err)
+	}
+	f.handleRawMessage(push)
+	trades, ok := c.GetTrades(schema.MEXC, schema.FUTURESCOIN, "BTC_USD", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	// 500 张 × 100 USD / 20000 = 2.5 BTC，成交额为 张数 × 面值
+	tr := trades[0]
+	if tr.Side != schema.OrderSideSell || tr.Quantity.String() != "2.5" || tr.QuoteQty.String() != "50000" {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
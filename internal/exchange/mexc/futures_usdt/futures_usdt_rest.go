@@ -2,51 +2,511 @@ package futures_usdt
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 
+	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
 )
 
-const MexcFuturesUSDTBaseURL = "https://www.mexc.com"
+const (
+	MexcFuturesUSDTBaseURL  = "https://contract.mexc.com"
+	apiV1ContractTicker     = "/api/v1/contract/ticker"
+	apiV1ContractDepth      = "/api/v1/contract/depth/"
+	apiV1ContractDetail     = "/api/v1/contract/detail"
+	apiV1ContractKline      = "/api/v1/contract/kline/"
+	apiV1ContractPing       = "/api/v1/contract/ping" // 服务器时间
+	settleCoinUSDT          = "USDT"
+	contractStateEnabled    = 0
+	contractStateDelivering = 1
+	contractStatePaused     = 4
+)
+
+// mexcDepth 合约深度，档位为 [价格, 张数, 订单数]
+type mexcDepth struct {
+	Asks      [][]decimal.Decimal `json:"asks"`
+	Bids      [][]decimal.Decimal `json:"bids"`
+	Version   int64               `json:"version"`
+	Timestamp int64               `json:"timestamp"`
+}
+
+// mexcContract 合约信息
+type mexcContract struct {
+	Symbol       string          `json:"symbol"`
+	BaseCoin     string          `json:"baseCoin"`
+	QuoteCoin    string          `json:"quoteCoin"`
+	SettleCoin   string          `json:"settleCoin"`
+	ContractSize decimal.Decimal `json:"contractSize"`
+	MinVol       decimal.Decimal `json:"minVol"`
+	MaxVol       decimal.Decimal `json:"maxVol"`
+	PriceScale   int             `json:"priceScale"`
+	PriceUnit    decimal.Decimal `json:"priceUnit"`
+	VolUnit      decimal.Decimal `json:"volUnit"`
+	State        int             `json:"state"` // 0 启用 1 交割中 2 交割完成 3 下线 4 暂停
+}
 
 // FuturesUSDTREST implements RESTClient for Mexc USDT-margined Futures.
 type FuturesUSDTREST struct {
 	http *resty.Client
+
+	// 合约面值缓存 symbol -> contractSize（一张合约对应的基础币数量）
+	contractSizeMu sync.RWMutex
+	contractSizes  map[string]decimal.Decimal
 }
 
 func NewFuturesUSDTREST() *FuturesUSDTREST {
 	return &FuturesUSDTREST{
-		http: resty.New().SetBaseURL(MexcFuturesUSDTBaseURL),
+		http:          resty.New().SetBaseURL(MexcFuturesUSDTBaseURL).SetTimeout(10 * time.Second),
+		contractSizes: make(map[string]decimal.Decimal),
 	}
 }
 
+// GetTicker 获取单个合约的24小时行情
 func (f *FuturesUSDTREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
-	// TODO: Implement Mexc futures USDT ticker
-	return schema.Ticker{}, errors.New("not implemented")
+	var t schema.MEXCFuturesTicker
+	if err := f.tickers(ctx, symbol, &t); err != nil {
+		return schema.Ticker{}, err
+	}
+	return f.convertTicker(ctx, t)
+}
+
+// GetTickers 获取全部合约的24小时行情
+func (f *FuturesUSDTREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	var rows []schema.MEXCFuturesTicker
+	if err := f.tickers(ctx, "", &rows); err != nil {
+		return nil, err
+	}
+
+	// 一次性刷新全部合约面值，避免逐个请求合约详情
+	if _, err := f.GetExchangeInfo(ctx); err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.Ticker, 0, len(rows))
+	for _, row := range rows {
+		// 行情接口返回全部合约，只保留 USDT 结算合约
+		if !strings.HasSuffix(row.Symbol, "_USDT") {
+			continue
+		}
+		t, err := f.convertTicker(ctx, row)
+		if err != nil {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
 }
 
+// tickers 请求合约行情，指定合约时 data 为对象，否则为数组
+func (f *FuturesUSDTREST) tickers(ctx context.Context, symbol string, out interface{}) error {
+	var resp struct {
+		Success bool            `json:"success"`
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	req := f.http.R().SetContext(ctx).SetResult(&resp)
+	if symbol != "" {
+		req.SetQueryParam("symbol", symbol)
+	}
+	r, err := req.Get(apiV1ContractTicker)
+	if err != nil {
+		return err
+	}
+	if r.IsError() {
+		return errors.New(r.Status())
+	}
+	if !resp.Success {
+		return fmt.Errorf("mexc error %d: %s", resp.Code, resp.Message)
+	}
+
+	logger.Debug("MEXC Futures USDT Ticker 原始响应: %s", string(r.Body()))
+	return json.Unmarshal(resp.Data, out)
+}
+
+// convertTicker 张数按合约面值换算为基础币成交量，amount24 即 USDT 成交额
+func (f *FuturesUSDTREST) convertTicker(ctx context.Context, t schema.MEXCFuturesTicker) (schema.Ticker, error) {
+	contractSize, err := f.contractSize(ctx, t.Symbol)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+
+	return schema.Ticker{
+		Exchange:  schema.MEXC,
+		Market:    schema.FUTURESUSDT,
+		Symbol:    t.Symbol,
+		Price:     t.LastPrice,
+		High:      t.High24Price,
+		Low:       t.Lower24Price,
+		Volume:    t.Volume24.Mul(contractSize),
+		QuoteVol:  t.Amount24,
+		Timestamp: time.UnixMilli(t.Timestamp),
+	}, nil
+}
+
+// GetOpenInterest 获取合约当前持仓量，取自合约行情的 holdVol
+func (f *FuturesUSDTREST) GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error) {
+	var t schema.MEXCFuturesTicker
+	if err := f.tickers(ctx, symbol, &t); err != nil {
+		return schema.OpenInterest{}, err
+	}
+	return f.convertOpenInterest(ctx, t)
+}
+
+// convertOpenInterest 持仓张数按合约面值换算为基础币数量，再按合理价格计算USDT价值
+func (f *FuturesUSDTREST) convertOpenInterest(ctx context.Context, t schema.MEXCFuturesTicker) (schema.OpenInterest, error) {
+	contractSize, err := f.contractSize(ctx, t.Symbol)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+
+	openInterest := t.HoldVol.Mul(contractSize)
+	return schema.OpenInterest{
+		Exchange:     schema.MEXC,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       t.Symbol,
+		OpenInterest: openInterest,
+		Value:        openInterest.Mul(t.FairPrice),
+		Timestamp:    time.UnixMilli(t.Timestamp),
+	}, nil
+}
+
+// GetOpenInterestHistory MEXC 合约未提供持仓量历史接口
+func (f *FuturesUSDTREST) GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	return nil, errors.New("open interest history not supported by MEXC futures")
+}
+
+// GetLongShortRatio MEXC 合约未提供多空账户比接口
+func (f *FuturesUSDTREST) GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	return nil, errors.New("long/short ratio not supported by MEXC futures")
+}
+
+// GetKline 获取最近 limit 根K线，按时间范围委托给 GetKlines
 func (f *FuturesUSDTREST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
-	// TODO: Implement Mexc futures USDT kline
-	return nil, errors.New("not implemented")
+	return schema.LatestKlines(ctx, f.GetKlines, symbol, interval, limit)
+}
+
+// maxKlinesPerRequest MEXC Futures USDT K线接口单次最多返回的条数，按该条数切分时间窗口
+const maxKlinesPerRequest = 2000
+
+// mexcKline 合约K线，各字段为按时间升序排列的数组，time 为秒，vol 为张数
+type mexcKline struct {
+	Time   []int64           `json:"time"`
+	Open   []decimal.Decimal `json:"open"`
+	Close  []decimal.Decimal `json:"close"`
+	High   []decimal.Decimal `json:"high"`
+	Low    []decimal.Decimal `json:"low"`
+	Vol    []decimal.Decimal `json:"vol"`
+	Amount []decimal.Decimal `json:"amount"`
 }
 
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按时间窗口由旧到新分页拉取，end 为零值时取到当前时间
+func (f *FuturesUSDTREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	// 张数换算为基础币数量需要合约面值
+	contractSize, err := f.contractSize(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []schema.Kline
+	window := time.Duration(maxKlinesPerRequest-1) * interval.Duration()
+	for from := start; !from.After(end); {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+		var resp struct {
+			Success bool      `json:"success"`
+			Code    int       `json:"code"`
+			Message string    `json:"message"`
+			Data    mexcKline `json:"data"`
+		}
+		r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+			"interval": klineIntervals[interval],
+			"start":    strconv.FormatInt(from.Unix(), 10),
+			"end":      strconv.FormatInt(to.Unix(), 10),
+		}).Get(apiV1ContractKline + symbol)
+		if err != nil {
+			return nil, err
+		}
+		if r.IsError() {
+			return nil, errors.New(r.Status())
+		}
+		if !resp.Success {
+			return nil, fmt.Errorf("mexc error %d: %s", resp.Code, resp.Message)
+		}
+		logger.Debug("MEXC Futures USDT Kline 返回 %d 条", len(resp.Data.Time))
+
+		out = append(out, convertKlines(resp.Data, contractSize, symbol, interval)...)
+		from = to.Add(time.Second)
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// convertKlines 转换K线数组，与 WebSocket 推送一致，成交量由张数按合约面值换算为基础币数量，amount 为USDT成交额；收盘时间未到的K线标记为未完结
+func convertKlines(kline mexcKline, contractSize decimal.Decimal, symbol string, interval schema.Interval) []schema.Kline {
+	n := len(kline.Time)
+	if len(kline.Open) < n || len(kline.Close) < n || len(kline.High) < n || len(kline.Low) < n || len(kline.Vol) < n || len(kline.Amount) < n {
+		return nil
+	}
+
+	now := time.Now()
+	out := make([]schema.Kline, 0, n)
+	for i, ts := range kline.Time {
+		close := kline.Close[i]
+		openTime := time.Unix(ts, 0)
+		closeTime := openTime.Add(interval.Duration() - time.Millisecond)
+		out = append(out, schema.Kline{
+			Exchange:    schema.MEXC,
+			Market:      schema.FUTURESUSDT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   closeTime,
+			Open:        kline.Open[i],
+			High:        kline.High[i],
+			Low:         kline.Low[i],
+			Close:       close,
+			Volume:      kline.Vol[i].Mul(contractSize),
+			QuoteVolume: kline.Amount[i],
+			IsFinal:     closeTime.Before(now),
+		})
+	}
+	return out
+}
+
+// GetDepth 获取合约深度，数量已由张数换算为基础币数量
 func (f *FuturesUSDTREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
-	// TODO: Implement Mexc futures USDT depth
-	return schema.Depth{}, errors.New("not implemented")
+	contractSize, err := f.contractSize(ctx, symbol)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+
+	book, err := f.depth(ctx, symbol, limit)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+
+	convert := func(levels [][]decimal.Decimal) []schema.PriceLevel {
+		out := make([]schema.PriceLevel, 0, len(levels))
+		for _, lv := range levels {
+			if len(lv) < 2 {
+				continue
+			}
+			out = append(out, schema.PriceLevel{Price: lv[0], Quantity: lv[1].Mul(contractSize)})
+		}
+		return out
+	}
+
+	return schema.Depth{
+		Exchange:     schema.MEXC,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       symbol,
+		Bids:         convert(book.Bids),
+		Asks:         convert(book.Asks),
+		UpdatedAt:    time.UnixMilli(book.Timestamp),
+		LastUpdateId: strconv.FormatInt(book.Version, 10),
+	}, nil
+}
+
+// depth 获取带 version 的深度快照，数量单位为张，供 WebSocket 增量同步使用
+func (f *FuturesUSDTREST) depth(ctx context.Context, symbol string, limit int) (*mexcDepth, error) {
+	var resp struct {
+		Success bool      `json:"success"`
+		Code    int       `json:"code"`
+		Message string    `json:"message"`
+		Data    mexcDepth `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).
+		SetQueryParam("limit", fmt.Sprintf("%d", limit)).
+		Get(apiV1ContractDepth + symbol)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("mexc error %d: %s", resp.Code, resp.Message)
+	}
+
+	// 保存原始响应结果用于调试
+	rawResponse := string(r.Body())
+	logger.Debug("MEXC Futures USDT Depth 原始响应: %s", rawResponse)
+
+	return &resp.Data, nil
+}
+
+// cachedContractSize 只读取已缓存的合约面值，不发起请求
+func (f *FuturesUSDTREST) cachedContractSize(symbol string) (decimal.Decimal, bool) {
+	f.contractSizeMu.RLock()
+	defer f.contractSizeMu.RUnlock()
+	v, ok := f.contractSizes[symbol]
+	return v, ok
+}
+
+// contractSize 获取合约面值（张 -> 基础币），结果按合约缓存
+func (f *FuturesUSDTREST) contractSize(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	f.contractSizeMu.RLock()
+	v, ok := f.contractSizes[symbol]
+	f.contractSizeMu.RUnlock()
+	if ok {
+		return v, nil
+	}
+
+	var resp struct {
+		Success bool         `json:"success"`
+		Code    int          `json:"code"`
+		Message string       `json:"message"`
+		Data    mexcContract `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("symbol", symbol).Get(apiV1ContractDetail)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if r.IsError() {
+		return decimal.Zero, errors.New(r.Status())
+	}
+	if !resp.Success {
+		return decimal.Zero, fmt.Errorf("mexc error %d: %s", resp.Code, resp.Message)
+	}
+	if resp.Data.ContractSize.IsZero() {
+		return decimal.Zero, fmt.Errorf("invalid contractSize for %s", symbol)
+	}
+
+	f.contractSizeMu.Lock()
+	f.contractSizes[symbol] = resp.Data.ContractSize
+	f.contractSizeMu.Unlock()
+
+	logger.Info("MEXC Futures USDT 合约面值已加载: %s contractSize=%s", symbol, resp.Data.ContractSize)
+	return resp.Data.ContractSize, nil
+}
+
+// serverTime 获取 MEXC 合约服务器时间
+func (f *FuturesUSDTREST) serverTime(ctx context.Context) (time.Time, error) {
+	var resp struct {
+		Success bool   `json:"success"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    int64  `json:"data"` // 毫秒
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiV1ContractPing)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if r.IsError() {
+		return time.Time{}, errors.New(r.Status())
+	}
+	if !resp.Success {
+		return time.Time{}, fmt.Errorf("mexc error %d: %s", resp.Code, resp.Message)
+	}
+	return time.UnixMilli(resp.Data), nil
 }
 
+// GetExchangeInfo 获取 USDT 结算合约交易规则，下单数量换算为基础币数量
 func (f *FuturesUSDTREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现MEXC USDT合约交易规则信息获取
+	var resp struct {
+		Success bool           `json:"success"`
+		Code    int            `json:"code"`
+		Message string         `json:"message"`
+		Data    []mexcContract `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiV1ContractDetail)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	if r.IsError() {
+		return schema.ExchangeInfo{}, errors.New(r.Status())
+	}
+	if !resp.Success {
+		return schema.ExchangeInfo{}, fmt.Errorf("mexc error %d: %s", resp.Code, resp.Message)
+	}
+	logger.Debug("MEXC Futures USDT ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+	serverTime, err := f.serverTime(ctx)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+
+	symbols := make([]schema.Symbol, 0, len(resp.Data))
+	f.contractSizeMu.Lock()
+	for _, c := range resp.Data {
+		if c.SettleCoin != settleCoinUSDT || c.ContractSize.IsZero() {
+			continue
+		}
+		// 顺便刷新合约面值缓存
+		f.contractSizes[c.Symbol] = c.ContractSize
+
+		status, ok := symbolStatus(c.State)
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       c.Symbol,
+			Base:         strings.ToUpper(c.BaseCoin),
+			Quote:        strings.ToUpper(c.QuoteCoin),
+			Margin:       strings.ToUpper(c.SettleCoin),
+			ExchangeName: schema.MEXC,
+			MarketType:   schema.FUTURESUSDT,
+
+			QuantityPrecision: decimalPlaces(c.ContractSize),
+			PricePrecision:    c.PriceScale,
+			MinQuantity:       c.MinVol.Mul(c.ContractSize).String(),
+			MaxQuantity:       c.MaxVol.Mul(c.ContractSize).String(),
+			TickSize:          c.PriceUnit.String(),
+			StepSize:          c.VolUnit.Mul(c.ContractSize).String(),
+
+			Status: status,
+		})
+	}
+	f.contractSizeMu.Unlock()
+
+	logger.Info("MEXC Futures USDT 交易规则已加载: %d 个合约", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.MEXC,
 		Market:     schema.FUTURESUSDT,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: serverTime,
 		RateLimits: []schema.RateLimit{},
 		Timezone:   "UTC",
 	}, nil
 }
+
+// symbolStatus 归一化合约状态，已完成交割（2）和已下线（3）的合约返回 false
+func symbolStatus(state int) (schema.SymbolStatus, bool) {
+	switch state {
+	case contractStateEnabled:
+		return schema.SymbolStatusTrading, true
+	case contractStateDelivering:
+		return schema.SymbolStatusBreak, true
+	case contractStatePaused:
+		return schema.SymbolStatusHalt, true
+	default:
+		return "", false
+	}
+}
+
+// decimalPlaces 计算小数位数，如 0.0001 -> 4
+func decimalPlaces(d decimal.Decimal) int {
+	if d.Exponent() >= 0 {
+		return 0
+	}
+	return int(-d.Exponent())
+}
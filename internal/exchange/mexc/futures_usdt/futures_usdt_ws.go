@@ -5,11 +5,15 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
 
 	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
@@ -18,84 +22,492 @@ import (
 )
 
 const (
-	MexcFuturesUSDTWSBase = "wss://wbs.mexc.com/ws"
+	MexcFuturesUSDTWSBase = "wss://contract.mexc.com/edge"
+
+	methodSubKline       = "sub.kline"
+	methodUnsubKline     = "unsub.kline"
+	methodSubDepth       = "sub.depth"
+	methodUnsubDepth     = "unsub.depth"
+	methodSubDeal        = "sub.deal"
+	methodUnsubDeal      = "unsub.deal"
+	methodSubDepthFull   = "sub.depth.full"
+	methodUnsubDepthFull = "unsub.depth.full"
+	methodSubTicker      = "sub.ticker"
+	methodUnsubTicker    = "unsub.ticker"
+	// 资金费相关频道，订阅时三个频道一起订阅并合并写入缓存
+	methodSubFundingRate   = "sub.funding.rate"
+	methodUnsubFundingRate = "unsub.funding.rate"
+	methodSubFairPrice     = "sub.fair.price"
+	methodUnsubFairPrice   = "unsub.fair.price"
+	methodSubIndexPrice    = "sub.index.price"
+	methodUnsubIndexPrice  = "unsub.index.price"
+	methodPing             = "ping"
+
+	channelKline     = "push.kline"
+	channelDepth     = "push.depth"
+	channelDeal      = "push.deal"
+	channelDepthFull = "push.depth.full"
+	channelTicker    = "push.ticker"
+	channelFunding   = "push.funding.rate"
+	channelFairPrice = "push.fair.price" // 合理价格，即标记价格
+	channelIndex     = "push.index.price"
+	channelPong      = "pong"
+
+	depthSnapshotSz = 100
+	// 合约没有单独的最优买卖价频道，使用最小档位的全量深度推送
+	bboDepthLimit = 5
+
+	// MEXC 1分钟内未收到 ping 会断开连接
+	pingInterval = 15 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
+
+	// 快照或合约面值获取失败后的重试间隔
+	syncRetryInterval = 3 * time.Second
 )
 
+type mexcRequest struct {
+	Method string      `json:"method"`
+	Param  interface{} `json:"param,omitempty"`
+}
+
+type mexcKlineParam struct {
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+}
+
+type mexcSymbolParam struct {
+	Symbol string `json:"symbol"`
+}
+
+type mexcDepthFullParam struct {
+	Symbol string `json:"symbol"`
+	Limit  int    `json:"limit"`
+}
+
+// mexcDealData push.deal 推送数据，T 为主动成交方向（1买 2卖），v 为成交张数
+type mexcDealData struct {
+	Price  decimal.Decimal `json:"p"`
+	Volume decimal.Decimal `json:"v"`
+	Side   int             `json:"T"`
+	Ts     int64           `json:"t"` // 毫秒
+}
+
+type mexcKlineData struct {
+	Symbol   string          `json:"symbol"`
+	Interval string          `json:"interval"`
+	T        int64           `json:"t"` // 秒
+	Open     decimal.Decimal `json:"o"`
+	Close    decimal.Decimal `json:"c"`
+	High     decimal.Decimal `json:"h"`
+	Low      decimal.Decimal `json:"l"`
+	Amount   decimal.Decimal `json:"a"` // 成交额
+	Volume   decimal.Decimal `json:"q"` // 成交张数
+}
+
+// klineIntervals schema.Interval 到 MEXC K线周期的映射，MEXC 不支持 3m
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "Min1",
+	schema.Interval5m:  "Min5",
+	schema.Interval15m: "Min15",
+	schema.Interval30m: "Min30",
+	schema.Interval1h:  "Min60",
+	schema.Interval4h:  "Hour4",
+	schema.Interval1d:  "Day1",
+}
+
+// orderBook 本地订单簿，数量单位为张
+type orderBook struct {
+	version int64
+	bids    map[string]decimal.Decimal // price -> 张数
+	asks    map[string]decimal.Decimal // price -> 张数
+}
+
+// bookSync 记录快照同步期间缓存的增量
+type bookSync struct {
+	buffer   []*mexcDepth
+	syncing  bool
+	failedAt time.Time
+}
+
 // FuturesUSDTWS implements WSConnector for Mexc USDT-margined Futures.
 type FuturesUSDTWS struct {
-	dialer *websocket.Dialer
-	conn   interfaces.WSConn
-	mu     sync.RWMutex
-	cache  *cache.MemoryCache
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+	rest    *FuturesUSDTREST
 
-	// subscription state
-	subs struct {
-		tickers map[string]struct{}
-		kline   map[string]schema.Interval
-		depth   map[string]struct{}
-	}
+	// per-symbol local order books and sync state
+	orderBooks map[string]*orderBook
+	syncs      map[string]*bookSync
+
+	// 合约面值开始后台加载或加载失败的时间，用于限制重试频率
+	sizePending map[string]time.Time
+
+	// 最近一根K线，用于在新K线开始时标记上一根已完结
+	klineMu    sync.Mutex
+	lastKlines map[schema.KlineSubscription]schema.Kline
+
+	ctx    context.Context
+	cancel context.CancelFunc
 
-	// internal control
-	stopCh chan struct{}
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewFuturesUSDTWS(c *cache.MemoryCache) *FuturesUSDTWS {
+func NewFuturesUSDTWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *FuturesUSDTREST) *FuturesUSDTWS {
 	d := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
 		HandshakeTimeout: 10 * time.Second,
 		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
 	}
-	ws := &FuturesUSDTWS{dialer: d, cache: c}
-	ws.subs.tickers = make(map[string]struct{})
-	ws.subs.kline = make(map[string]schema.Interval)
-	ws.subs.depth = make(map[string]struct{})
-	ws.stopCh = make(chan struct{})
-	return ws
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FuturesUSDTWS{
+		dialer:      d,
+		cache:       c,
+		gaps:        cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:        subs,
+		rest:        rest,
+		orderBooks:  make(map[string]*orderBook),
+		syncs:       make(map[string]*bookSync),
+		sizePending: make(map[string]time.Time),
+		lastKlines:  make(map[schema.KlineSubscription]schema.Kline),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
 }
 
 func (f *FuturesUSDTWS) Connect(ctx context.Context) error {
-	// TODO: Implement Mexc futures USDT WS connection
-	return errors.New("not implemented")
+	f.mu.Lock()
+	if f.conn != nil {
+		f.mu.Unlock()
+		logger.Info("MEXC Futures USDT WS 已连接，跳过连接")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 开始连接...")
+	conn, _, err := f.dialer.DialContext(ctx, MexcFuturesUSDTWSBase, nil)
+	if err != nil {
+		f.mu.Unlock()
+		logger.Error("MEXC Futures USDT WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024)
+	f.conn = conn
+	f.mu.Unlock()
+
+	now := time.Now()
+	f.healthMu.Lock()
+	f.lastMessage = now
+	f.lastPing = now
+	f.healthMu.Unlock()
+
+	logger.Info("MEXC Futures USDT WS 连接成功")
+
+	// 重连时自动恢复订阅
+	_ = f.applySubscriptions(ctx)
+	return nil
 }
 
 func (f *FuturesUSDTWS) Close() error {
-	// TODO: Implement Mexc futures USDT WS close
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn != nil {
+		err := f.conn.Close()
+		f.conn = nil
+		return err
+	}
 	return nil
 }
 
-func (f *FuturesUSDTWS) SubscribeTickers(ctx context.Context, symbols []string) error {
-	// TODO: Implement Mexc futures USDT WS ticker subscription
-	return errors.New("not implemented")
-}
+func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
+	}
 
-func (f *FuturesUSDTWS) UnsubscribeTickers(ctx context.Context, symbols []string) error {
-	// TODO: Implement Mexc futures USDT WS ticker unsubscription
-	return errors.New("not implemented")
-}
+	newlyAdded := f.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅 kline，跳过订阅请求")
+		return nil
+	}
 
-func (f *FuturesUSDTWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// TODO: Implement Mexc futures USDT WS kline subscription
-	return errors.New("not implemented")
+	logger.Info("MEXC Futures USDT WS 新增订阅 kline: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractSizes(ctx, klineSymbols(newlyAdded))
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendKline(ctx, methodSubKline, newlyAdded)
 }
 
-func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// TODO: Implement Mexc futures USDT WS kline unsubscription
-	return errors.New("not implemented")
+// UnsubscribeKline 只退订K线频道的指定周期，未指定周期时退订全部周期
+func (f *FuturesUSDTWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅 kline，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订 kline: %v", removed)
+	f.gaps.Forget(removed)
+
+	f.klineMu.Lock()
+	for _, sub := range removed {
+		delete(f.lastKlines, sub)
+	}
+	f.klineMu.Unlock()
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendKline(ctx, methodUnsubKline, removed)
 }
 
 func (f *FuturesUSDTWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// TODO: Implement Mexc futures USDT WS depth subscription
-	return errors.New("not implemented")
+	newlyAdded := f.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅 depth，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 新增订阅 depth: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractSizes(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendDepth(ctx, methodSubDepth, newlyAdded)
 }
 
 func (f *FuturesUSDTWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// TODO: Implement %!s(MISSING) futures USDT WS depth unsubscription
-	return errors.New("not implemented")
+	return f.unsubscribe(ctx, symbols, "depth")
 }
 
-func (f *FuturesUSDTWS) StartReading(ctx context.Context) error {
-	// TODO: Implement Mexc futures USDT WS start
-	return errors.New("not implemented")
+func (f *FuturesUSDTWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 新增订阅 trades: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractSizes(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendDeal(ctx, methodSubDeal, newlyAdded)
+}
+
+func (f *FuturesUSDTWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订 trades: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendDeal(ctx, methodUnsubDeal, removed)
+}
+
+func (f *FuturesUSDTWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅 bbo，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 新增订阅 bbo: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractSizes(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendDepthFull(ctx, methodSubDepthFull, newlyAdded)
+}
+
+func (f *FuturesUSDTWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅 bbo，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订 bbo: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendDepthFull(ctx, methodUnsubDepthFull, removed)
+}
+
+func (f *FuturesUSDTWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅 ticker，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 新增订阅 ticker: %v", newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractSizes(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 已订阅持仓量的合约共用 push.ticker 频道，无需重复订阅
+	return f.sendTicker(ctx, methodSubTicker, excludeSymbols(newlyAdded, f.subs.GetOpenInterestSymbols()))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅 ticker，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订 ticker: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅持仓量的合约保留 push.ticker 频道
+	return f.sendTicker(ctx, methodUnsubTicker, excludeSymbols(removed, f.subs.GetOpenInterestSymbols()))
+}
+
+func (f *FuturesUSDTWS) SubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅持仓量，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 新增订阅持仓量: %v", newlyAdded)
+	// 持仓量为张数，订阅时预先加载合约面值
+	f.loadContractSizes(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 持仓量来自 push.ticker 频道，已订阅行情的合约无需重复订阅
+	return f.sendTicker(ctx, methodSubTicker, excludeSymbols(newlyAdded, f.subs.GetTickerSymbols()))
+}
+
+func (f *FuturesUSDTWS) UnsubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅持仓量，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订持仓量: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	// 仍订阅行情的合约保留 push.ticker 频道
+	return f.sendTicker(ctx, methodUnsubTicker, excludeSymbols(removed, f.subs.GetTickerSymbols()))
+}
+
+func (f *FuturesUSDTWS) SubscribeFunding(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeFundingSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都已订阅资金费率，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 新增订阅资金费率: %v", newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendFunding(ctx, true, newlyAdded)
+}
+
+func (f *FuturesUSDTWS) UnsubscribeFunding(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeFundingSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅资金费率，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订资金费率: %v", removed)
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return f.sendFunding(ctx, false, removed)
+}
+
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 depth 频道
+func (f *FuturesUSDTWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(f.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("MEXC Futures USDT WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
+	}
+
+	logger.Info("MEXC Futures USDT WS 退订 %s: %v", kind, removed)
+
+	f.mu.Lock()
+	for _, symbol := range removed {
+		delete(f.orderBooks, symbol)
+		delete(f.syncs, symbol)
+	}
+	f.mu.Unlock()
+
+	f.klineMu.Lock()
+	for _, sub := range klines {
+		delete(f.lastKlines, sub)
+	}
+	f.klineMu.Unlock()
+
+	if !f.isConnected() {
+		logger.Warn("MEXC Futures USDT WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	if err := f.sendKline(ctx, methodUnsubKline, klines); err != nil {
+		return err
+	}
+	return f.sendDepth(ctx, methodUnsubDepth, removed)
 }
 
 // SendMessage sends a message to WebSocket server
@@ -106,27 +518,856 @@ func (f *FuturesUSDTWS) SendMessage(ctx context.Context, message interface{}) er
 	if conn == nil {
 		return errors.New("WebSocket connection not established")
 	}
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("MEXC Futures USDT WS SendMessage: %s", string(jsonData))
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("MEXC Futures USDT WS 序列化消息失败: %v", err)
+		return err
 	}
-	return conn.WriteJSON(message)
+
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		logger.Error("MEXC Futures USDT WS 发送消息失败: %v", err)
+		return err
+	}
+
+	logger.Info("MEXC Futures USDT WS SendMessage: %s", string(data))
+	return nil
+}
+
+// sendKline MEXC 每条订阅消息只能携带一个合约
+func (f *FuturesUSDTWS) sendKline(ctx context.Context, method string, subs []schema.KlineSubscription) error {
+	for _, sub := range subs {
+		msg := &mexcRequest{Method: method, Param: mexcKlineParam{Symbol: sub.Symbol, Interval: klineIntervals[sub.Interval]}}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FuturesUSDTWS) sendDepth(ctx context.Context, method string, symbols []string) error {
+	for _, symbol := range symbols {
+		msg := &mexcRequest{Method: method, Param: mexcSymbolParam{Symbol: symbol}}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FuturesUSDTWS) sendDeal(ctx context.Context, method string, symbols []string) error {
+	for _, symbol := range symbols {
+		msg := &mexcRequest{Method: method, Param: mexcSymbolParam{Symbol: symbol}}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FuturesUSDTWS) sendTicker(ctx context.Context, method string, symbols []string) error {
+	for _, symbol := range symbols {
+		msg := &mexcRequest{Method: method, Param: mexcSymbolParam{Symbol: symbol}}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendFunding 为每个合约发送资金费率、合理价格和指数价格三个频道的订阅/退订
+func (f *FuturesUSDTWS) sendFunding(ctx context.Context, subscribe bool, symbols []string) error {
+	methods := []string{methodSubFundingRate, methodSubFairPrice, methodSubIndexPrice}
+	if !subscribe {
+		methods = []string{methodUnsubFundingRate, methodUnsubFairPrice, methodUnsubIndexPrice}
+	}
+	for _, symbol := range symbols {
+		for _, method := range methods {
+			msg := &mexcRequest{Method: method, Param: mexcSymbolParam{Symbol: symbol}}
+			if err := f.SendMessage(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-// HandlePing 处理接收到的 ping（空实现）
-func (ws *FuturesUSDTWS) HandlePing(data []byte) error {
-	// TODO: 实现 MEXC USDT 期货 的 ping 处理
+func (f *FuturesUSDTWS) sendDepthFull(ctx context.Context, method string, symbols []string) error {
+	for _, symbol := range symbols {
+		msg := &mexcRequest{Method: method, Param: mexcDepthFullParam{Symbol: symbol, Limit: bboDepthLimit}}
+		if err := f.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// SendPing 主动发送 ping（空实现）
-func (ws *FuturesUSDTWS) SendPing(ctx context.Context) error {
-	// TODO: 实现 MEXC USDT 期货 的主动 ping
+func (f *FuturesUSDTWS) applySubscriptions(ctx context.Context) error {
+	klineSubs := f.subs.GetKlineSubscriptions()
+	depthSymbols := f.subs.GetDepthSymbols()
+	tradeSymbols := f.subs.GetTradeSymbols()
+	bookTickerSymbols := f.subs.GetBookTickerSymbols()
+	// 行情与持仓量共用 push.ticker 频道
+	tickerSymbols := dedupe(append(f.subs.GetTickerSymbols(), f.subs.GetOpenInterestSymbols()...))
+	fundingSymbols := f.subs.GetFundingSymbols()
+	if len(klineSubs) == 0 && len(depthSymbols) == 0 && len(tradeSymbols) == 0 && len(bookTickerSymbols) == 0 && len(tickerSymbols) == 0 && len(fundingSymbols) == 0 {
+		logger.Info("MEXC Futures USDT WS 无订阅")
+		return nil
+	}
+	// 数量以张数推送的频道需要合约面值，在发送订阅前加载，避免在读协程中请求 REST
+	f.loadContractSizes(ctx, append(append(append(append(klineSymbols(klineSubs), depthSymbols...),
+		tradeSymbols...), bookTickerSymbols...), tickerSymbols...))
+	if err := f.sendKline(ctx, methodSubKline, klineSubs); err != nil {
+		return err
+	}
+	if err := f.sendDepth(ctx, methodSubDepth, depthSymbols); err != nil {
+		return err
+	}
+	if err := f.sendDeal(ctx, methodSubDeal, tradeSymbols); err != nil {
+		return err
+	}
+	if err := f.sendDepthFull(ctx, methodSubDepthFull, bookTickerSymbols); err != nil {
+		return err
+	}
+	if err := f.sendTicker(ctx, methodSubTicker, tickerSymbols); err != nil {
+		return err
+	}
+	return f.sendFunding(ctx, true, fundingSymbols)
+}
+
+// klineIntervalOf 由 MEXC K线周期解析 schema.Interval，如 Min60 -> 1h
+func klineIntervalOf(v string) (schema.Interval, bool) {
+	for interval, name := range klineIntervals {
+		if name == v {
+			return interval, true
+		}
+	}
+	return "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for mexc", interval)
+		}
+	}
+	return nil
+}
+
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+// excludeSymbols 返回 symbols 中不在 exclude 里的币对
+func excludeSymbols(symbols, exclude []string) []string {
+	skip := make(map[string]struct{}, len(exclude))
+	for _, s := range exclude {
+		skip[s] = struct{}{}
+	}
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := skip[s]; !ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+// klineSymbols 返回K线订阅涉及的合约
+func klineSymbols(subs []schema.KlineSubscription) []string {
+	out := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		out = append(out, sub.Symbol)
+	}
+	return out
+}
+
+func (f *FuturesUSDTWS) isConnected() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
+func (f *FuturesUSDTWS) StartReading(ctx context.Context) error {
+	logger.Info("MEXC Futures USDT WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("MEXC Futures USDT WS 上下文取消")
+				return
+			case <-f.ctx.Done():
+				logger.Info("MEXC Futures USDT WS 已关闭")
+				return
+			default:
+			}
+
+			f.mu.RLock()
+			conn := f.conn
+			f.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if f.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("MEXC Futures USDT WS 读取消息失败: %v", err)
+				f.reconnect(ctx)
+				continue
+			}
+
+			f.healthMu.Lock()
+			f.lastMessage = time.Now()
+			f.healthMu.Unlock()
+
+			f.handleRawMessage(message)
+		}
+	}()
+
 	return nil
 }
 
-// StartHealthCheck 启动连接健康监控（空实现）
-func (ws *FuturesUSDTWS) StartHealthCheck(ctx context.Context) error {
-	// TODO: 实现 MEXC USDT 期货 的健康检查
+func (f *FuturesUSDTWS) handleRawMessage(message []byte) {
+	logger.Debug("MEXC Futures USDT WS 收到原始消息: %s", string(message))
+
+	var msg struct {
+		Channel string          `json:"channel"`
+		Symbol  string          `json:"symbol"`
+		Ts      int64           `json:"ts"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("MEXC Futures USDT WS 解析原始消息失败: %v", err)
+		return
+	}
+
+	switch {
+	case msg.Channel == channelKline:
+		f.handleKline(msg.Data)
+	case msg.Channel == channelDepth:
+		f.handleDepth(msg.Symbol, msg.Ts, msg.Data)
+	case msg.Channel == channelDeal:
+		f.handleDeal(msg.Symbol, msg.Data)
+	case msg.Channel == channelDepthFull:
+		f.handleBBO(msg.Symbol, msg.Ts, msg.Data)
+	case msg.Channel == channelTicker:
+		f.handleTicker(msg.Data)
+	case msg.Channel == channelFunding, msg.Channel == channelFairPrice, msg.Channel == channelIndex:
+		f.handleFunding(msg.Channel, msg.Symbol, msg.Ts, msg.Data)
+	case msg.Channel == channelPong:
+		logger.Debug("MEXC Futures USDT WS 收到 pong")
+	case msg.Channel == "rs.error":
+		logger.Error("MEXC Futures USDT WS 请求错误: %s", string(msg.Data))
+	case strings.HasPrefix(msg.Channel, "rs."):
+		// 订阅响应，如 rs.sub.depth
+		logger.Info("MEXC Futures USDT WS %s: %s", msg.Channel, string(msg.Data))
+	default:
+		logger.Debug("MEXC Futures USDT WS 未知频道: %s", msg.Channel)
+	}
+}
+
+// handleDeal 处理公共成交，张数按合约面值换算为基础币数量
+func (f *FuturesUSDTWS) handleDeal(symbol string, data json.RawMessage) {
+	// data 可能为单个对象或数组
+	var rows []mexcDealData
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &rows); err != nil {
+			logger.Error("MEXC Futures USDT WS 解析deal失败: %v", err)
+			return
+		}
+	} else {
+		var row mexcDealData
+		if err := json.Unmarshal(data, &row); err != nil {
+			logger.Error("MEXC Futures USDT WS 解析deal失败: %v", err)
+			return
+		}
+		rows = append(rows, row)
+	}
+
+	contractSize, ok := f.contractSize(symbol)
+	if !ok {
+		return
+	}
+
+	for _, row := range rows {
+		quantity := row.Volume.Mul(contractSize)
+		quoteQty := row.Price.Mul(quantity)
+
+		side := schema.OrderSideBuy
+		if row.Side == 2 {
+			side = schema.OrderSideSell
+		}
+
+		f.cache.AddTrade(schema.Trade{
+			Exchange:  schema.MEXC,
+			Market:    schema.FUTURESUSDT,
+			Symbol:    symbol,
+			Side:      side,
+			Price:     row.Price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(row.Ts),
+		})
+	}
+}
+
+// handleBBO 从全量深度推送中取最优一档，张数按合约面值换算为基础币数量
+func (f *FuturesUSDTWS) handleBBO(symbol string, ts int64, data json.RawMessage) {
+	var book mexcDepth
+	if err := json.Unmarshal(data, &book); err != nil {
+		logger.Error("MEXC Futures USDT WS 解析depth.full失败: %v", err)
+		return
+	}
+	if len(book.Bids) == 0 || len(book.Asks) == 0 || len(book.Bids[0]) < 2 || len(book.Asks[0]) < 2 {
+		return
+	}
+
+	contractSize, ok := f.contractSize(symbol)
+	if !ok {
+		return
+	}
+	bidQty := book.Bids[0][1].Mul(contractSize)
+	askQty := book.Asks[0][1].Mul(contractSize)
+
+	f.cache.SetBookTicker(schema.BookTicker{
+		Exchange:     schema.MEXC,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       symbol,
+		BidPrice:     book.Bids[0][0],
+		BidQty:       bidQty,
+		AskPrice:     book.Asks[0][0],
+		AskQty:       askQty,
+		UpdatedAt:    time.UnixMilli(ts),
+		LastUpdateId: fmt.Sprintf("%d", book.Version),
+	})
+}
+
+// handleTicker 处理24小时行情推送，张数换算与 REST 行情一致；推送中的持仓张数换算后写入持仓量缓存
+func (f *FuturesUSDTWS) handleTicker(data json.RawMessage) {
+	var row schema.MEXCFuturesTicker
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("MEXC Futures USDT WS 解析ticker失败: %v", err)
+		return
+	}
+
+	// 合约面值已缓存时换算不再请求 REST
+	if _, ok := f.contractSize(row.Symbol); !ok {
+		return
+	}
+	t, err := f.rest.convertTicker(f.ctx, row)
+	if err != nil {
+		logger.Error("MEXC Futures USDT WS 获取合约面值失败 %s: %v", row.Symbol, err)
+		return
+	}
+	f.cache.SetTicker(t)
+
+	oi, err := f.rest.convertOpenInterest(f.ctx, row)
+	if err != nil {
+		logger.Error("MEXC Futures USDT WS 获取合约面值失败 %s: %v", row.Symbol, err)
+		return
+	}
+	f.cache.SetOpenInterest(oi)
+}
+
+// handleFunding 处理资金费率、合理价格和指数价格推送，三个频道各自只更新对应字段
+func (f *FuturesUSDTWS) handleFunding(channel, symbol string, ts int64, data json.RawMessage) {
+	var row struct {
+		Symbol         string          `json:"symbol"`
+		Rate           decimal.Decimal `json:"rate"`           // push.funding.rate
+		NextSettleTime int64           `json:"nextSettleTime"` // push.funding.rate，毫秒
+		Price          decimal.Decimal `json:"price"`          // push.fair.price / push.index.price
+	}
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("MEXC Futures USDT WS 解析%s失败: %v", channel, err)
+		return
+	}
+	if row.Symbol != "" {
+		symbol = row.Symbol
+	}
+
+	info, ok := f.cache.GetFunding(schema.MEXC, schema.FUTURESUSDT, symbol)
+	if !ok {
+		info = schema.FundingInfo{Exchange: schema.MEXC, Market: schema.FUTURESUSDT, Symbol: symbol}
+	}
+	switch channel {
+	case channelFunding:
+		info.FundingRate = row.Rate
+		if row.NextSettleTime > 0 {
+			info.NextFundingTime = time.UnixMilli(row.NextSettleTime)
+		}
+	case channelFairPrice:
+		info.MarkPrice = row.Price
+	case channelIndex:
+		info.IndexPrice = row.Price
+	}
+	if ts > 0 {
+		info.Timestamp = time.UnixMilli(ts)
+	} else {
+		info.Timestamp = time.Now()
+	}
+
+	f.cache.SetFunding(info)
+}
+
+func (f *FuturesUSDTWS) handleKline(data json.RawMessage) {
+	var row mexcKlineData
+	if err := json.Unmarshal(data, &row); err != nil {
+		logger.Error("MEXC Futures USDT WS 解析kline失败: %v", err)
+		return
+	}
+	interval, ok := klineIntervalOf(row.Interval)
+	if !ok {
+		logger.Warn("MEXC Futures USDT WS 未知K线周期: %s", row.Interval)
+		return
+	}
+
+	contractSize, ok := f.contractSize(row.Symbol)
+	if !ok {
+		return
+	}
+
+	openTime := time.Unix(row.T, 0)
+	kline := schema.Kline{
+		Exchange:    schema.MEXC,
+		Market:      schema.FUTURESUSDT,
+		Symbol:      row.Symbol,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+		Open:        row.Open,
+		High:        row.High,
+		Low:         row.Low,
+		Close:       row.Close,
+		Volume:      row.Volume.Mul(contractSize),
+		QuoteVolume: row.Amount,
+		EventTime:   time.Now(),
+	}
+
+	// MEXC 不推送K线完结标记，收到新K线时将上一根标记为已完结
+	f.klineMu.Lock()
+	key := schema.KlineSubscription{Symbol: row.Symbol, Interval: interval}
+	prev, ok := f.lastKlines[key]
+	f.lastKlines[key] = kline
+	f.klineMu.Unlock()
+	if ok && prev.OpenTime.Before(openTime) {
+		prev.IsFinal = true
+		f.gaps.SetKline(prev)
+	}
+
+	f.gaps.SetKline(kline)
+}
+
+// handleDepth 按 MEXC 文档维护本地订单簿：
+// 1. 订阅后先缓存增量，再通过 REST 获取带 version 的快照
+// 2. 丢弃 version <= 快照 version 的增量
+// 3. 之后每条增量的 version 必须等于上一条 version+1，否则重新同步
+func (f *FuturesUSDTWS) handleDepth(symbol string, ts int64, data json.RawMessage) {
+	var update mexcDepth
+	if err := json.Unmarshal(data, &update); err != nil {
+		logger.Error("MEXC Futures USDT WS 解析depth失败: %v", err)
+		return
+	}
+	update.Timestamp = ts
+
+	f.mu.Lock()
+	ob := f.orderBooks[symbol]
+	if ob == nil {
+		st := f.syncs[symbol]
+		if st == nil {
+			st = &bookSync{}
+			f.syncs[symbol] = st
+		}
+		st.buffer = append(st.buffer, &update)
+		start := !st.syncing && time.Since(st.failedAt) >= syncRetryInterval
+		if start {
+			st.syncing = true
+		}
+		f.mu.Unlock()
+		if start {
+			go f.syncOrderBook(symbol)
+		}
+		return
+	}
+
+	if update.Version <= ob.version {
+		f.mu.Unlock()
+		return
+	}
+	if update.Version != ob.version+1 {
+		logger.Warn("MEXC Futures USDT WS %s 版本不连续: version=%d, 本地version=%d，重新同步快照",
+			symbol, update.Version, ob.version)
+		delete(f.orderBooks, symbol)
+		f.syncs[symbol] = &bookSync{buffer: []*mexcDepth{&update}, syncing: true}
+		f.mu.Unlock()
+		go f.syncOrderBook(symbol)
+		return
+	}
+	applyLevels(ob.bids, update.Bids)
+	applyLevels(ob.asks, update.Asks)
+	ob.version = update.Version
+	f.mu.Unlock()
+
+	f.publishDepth(symbol, ts)
+}
+
+// syncOrderBook 获取 REST 快照并回放缓存的增量
+func (f *FuturesUSDTWS) syncOrderBook(symbol string) {
+	ctx, cancel := context.WithTimeout(f.ctx, 10*time.Second)
+	defer cancel()
+
+	// 先加载合约面值，之后发布深度时直接命中缓存
+	_, err := f.rest.contractSize(ctx, symbol)
+	var snapshot *mexcDepth
+	if err == nil {
+		snapshot, err = f.rest.depth(ctx, symbol, depthSnapshotSz)
+	}
+	if err != nil {
+		logger.Error("MEXC Futures USDT WS 同步深度快照失败 %s: %v", symbol, err)
+		f.mu.Lock()
+		if st := f.syncs[symbol]; st != nil {
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
+		}
+		f.mu.Unlock()
+		return
+	}
+
+	f.mu.Lock()
+	st := f.syncs[symbol]
+	if st == nil {
+		// 同步期间已退订
+		f.mu.Unlock()
+		return
+	}
+
+	ob := &orderBook{
+		version: snapshot.Version,
+		bids:    make(map[string]decimal.Decimal),
+		asks:    make(map[string]decimal.Decimal),
+	}
+	applyLevels(ob.bids, snapshot.Bids)
+	applyLevels(ob.asks, snapshot.Asks)
+
+	lastTs := snapshot.Timestamp
+	for _, update := range st.buffer {
+		if update.Version <= ob.version {
+			continue
+		}
+		if update.Version != ob.version+1 {
+			// 快照早于缓存的增量，稍后用新的快照重试
+			logger.Warn("MEXC Futures USDT WS %s 快照与增量不衔接: 快照version=%d, version=%d，稍后重试",
+				symbol, ob.version, update.Version)
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
+			f.mu.Unlock()
+			return
+		}
+		applyLevels(ob.bids, update.Bids)
+		applyLevels(ob.asks, update.Asks)
+		ob.version = update.Version
+		lastTs = update.Timestamp
+	}
+
+	f.orderBooks[symbol] = ob
+	delete(f.syncs, symbol)
+	f.mu.Unlock()
+
+	logger.Info("MEXC Futures USDT WS %s 深度快照已加载: version=%d, 买单%d档, 卖单%d档",
+		symbol, snapshot.Version, len(snapshot.Bids), len(snapshot.Asks))
+
+	f.publishDepth(symbol, lastTs)
+}
+
+// publishDepth 换算为基础币数量后写入缓存
+func (f *FuturesUSDTWS) publishDepth(symbol string, ts int64) {
+	contractSize, ok := f.contractSize(symbol)
+	if !ok {
+		return
+	}
+	if depth := f.buildDepthFromOrderBook(symbol, contractSize, ts); depth != nil {
+		f.cache.SetDepth(*depth)
+	}
+}
+
+// contractSize 从 REST 客户端缓存读取合约面值，不在读协程中同步请求 REST；
+// 未加载时在后台加载并跳过本条推送，加载失败后 syncRetryInterval 内不再重试
+func (f *FuturesUSDTWS) contractSize(symbol string) (decimal.Decimal, bool) {
+	if size, ok := f.rest.cachedContractSize(symbol); ok {
+		return size, true
+	}
+
+	f.mu.Lock()
+	pendingAt, pending := f.sizePending[symbol]
+	start := !pending || time.Since(pendingAt) >= syncRetryInterval
+	if start {
+		f.sizePending[symbol] = time.Now()
+	}
+	f.mu.Unlock()
+	if start {
+		go f.loadContractSizes(f.ctx, []string{symbol})
+	}
+	return decimal.Zero, false
+}
+
+// loadContractSizes 在订阅和连接时预先加载合约面值，已缓存的合约直接跳过
+func (f *FuturesUSDTWS) loadContractSizes(ctx context.Context, symbols []string) {
+	for _, symbol := range dedupe(symbols) {
+		if _, ok := f.rest.cachedContractSize(symbol); ok {
+			continue
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := f.rest.contractSize(reqCtx, symbol)
+		cancel()
+
+		f.mu.Lock()
+		if err != nil {
+			logger.Error("MEXC Futures USDT WS 获取合约面值失败 %s: %v", symbol, err)
+			f.sizePending[symbol] = time.Now()
+		} else {
+			delete(f.sizePending, symbol)
+		}
+		f.mu.Unlock()
+	}
+}
+
+// applyLevels 应用档位更新 [价格, 张数, 订单数]，数量为0时删除该价位
+func applyLevels(side map[string]decimal.Decimal, levels [][]decimal.Decimal) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := lv[0].String()
+		if lv[1].IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = lv[1]
+		}
+	}
+}
+
+// buildDepthFromOrderBook 将本地订单簿排序裁剪，并把张数换算为基础币数量
+func (f *FuturesUSDTWS) buildDepthFromOrderBook(symbol string, contractSize decimal.Decimal, ts int64) *schema.Depth {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ob := f.orderBooks[symbol]
+	if ob == nil {
+		return nil
+	}
+
+	bids := make([]schema.PriceLevel, 0, len(ob.bids))
+	for priceStr, sz := range ob.bids {
+		price, _ := decimal.NewFromString(priceStr)
+		bids = append(bids, schema.PriceLevel{Price: price, Quantity: sz.Mul(contractSize)})
+	}
+	asks := make([]schema.PriceLevel, 0, len(ob.asks))
+	for priceStr, sz := range ob.asks {
+		price, _ := decimal.NewFromString(priceStr)
+		asks = append(asks, schema.PriceLevel{Price: price, Quantity: sz.Mul(contractSize)})
+	}
+
+	// 排序：买单降序，卖单升序
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	if len(bids) > maxDepthLevels {
+		bids = bids[:maxDepthLevels]
+	}
+	if len(asks) > maxDepthLevels {
+		asks = asks[:maxDepthLevels]
+	}
+
+	updatedAt := time.Now()
+	if ts > 0 {
+		updatedAt = time.UnixMilli(ts)
+	}
+
+	return &schema.Depth{
+		Exchange:     schema.MEXC,
+		Market:       schema.FUTURESUSDT,
+		Symbol:       symbol,
+		Bids:         bids,
+		Asks:         asks,
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", ob.version),
+	}
+}
+
+// HandlePing 处理服务端的 ping 帧
+func (f *FuturesUSDTWS) HandlePing(data []byte) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	f.writeMu.Lock()
+	defer f.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
+
+// SendPing 发送 MEXC 心跳 {"method":"ping"}，服务端回复 channel 为 pong 的消息
+func (f *FuturesUSDTWS) SendPing(ctx context.Context) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	data, _ := json.Marshal(&mexcRequest{Method: methodPing})
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	f.healthMu.Lock()
+	f.lastPing = time.Now()
+	f.healthMu.Unlock()
 	return nil
 }
+
+// StartHealthCheck 定时发送心跳，超时未收到消息则重连
+func (f *FuturesUSDTWS) StartHealthCheck(ctx context.Context) error {
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("MEXC Futures USDT WS 健康检查停止")
+			return nil
+		case <-f.ctx.Done():
+			logger.Info("MEXC Futures USDT WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			f.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (f *FuturesUSDTWS) checkConnectionHealth(ctx context.Context) {
+	if !f.isConnected() {
+		return
+	}
+
+	f.healthMu.RLock()
+	sinceMsg := time.Since(f.lastMessage)
+	sincePing := time.Since(f.lastPing)
+	f.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("MEXC Futures USDT WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		f.reconnect(ctx)
+		return
+	}
+
+	// 数据推送不能替代心跳，MEXC 要求定期发送 ping
+	if sincePing > pingInterval {
+		if err := f.SendPing(ctx); err != nil {
+			logger.Warn("MEXC Futures USDT WS ping失败: %v", err)
+			f.reconnect(ctx)
+		}
+	}
+}
+
+func (f *FuturesUSDTWS) reconnect(ctx context.Context) {
+	f.reconnectMu.Lock()
+	if f.reconnecting {
+		f.reconnectMu.Unlock()
+		return
+	}
+	f.reconnecting = true
+	f.reconnectMu.Unlock()
+
+	defer func() {
+		f.reconnectMu.Lock()
+		f.reconnecting = false
+		f.reconnectMu.Unlock()
+	}()
+
+	f.mu.Lock()
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+	// 重连后重新获取快照
+	f.orderBooks = make(map[string]*orderBook)
+	f.syncs = make(map[string]*bookSync)
+	f.mu.Unlock()
+
+	for {
+		f.reconnectMu.Lock()
+		f.reconnectCount++
+		reconnectCount := f.reconnectCount
+		f.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("MEXC Futures USDT WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := f.Connect(ctx); err != nil {
+			logger.Error("MEXC Futures USDT WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("MEXC Futures USDT WS 重连成功")
+		// 补齐断线期间缺失的K线
+		f.gaps.Backfill(ctx, f.subs.GetKlineSubscriptions())
+		f.reconnectMu.Lock()
+		f.reconnectCount = 0
+		f.reconnectMu.Unlock()
+		return
+	}
+}
@@ -0,0 +1,376 @@
+package spot
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// MEXC v3 WebSocket 的二进制推送使用 protobuf 编码，外层为 PushDataV3ApiWrapper。
+// 这里只解码用到的字段，字段编号取自 MEXC 官方 proto 定义
+// (https://github.com/mexcdevelop/websocket-proto)，其余字段直接跳过。
+
+// PushDataV3ApiWrapper 字段编号
+const (
+	wrapperChannel           protowire.Number = 1
+	wrapperSymbol            protowire.Number = 3
+	wrapperSymbolId          protowire.Number = 4
+	wrapperCreateTime        protowire.Number = 5
+	wrapperSendTime          protowire.Number = 6
+	wrapperPublicSpotKline   protowire.Number = 308
+	wrapperPublicMiniTicker  protowire.Number = 309
+	wrapperPublicAggreDepths protowire.Number = 313
+	wrapperPublicAggreDeals  protowire.Number = 314
+	wrapperPublicBookTicker  protowire.Number = 315
+)
+
+// pbPushData 对应 PushDataV3ApiWrapper，body 为 oneof，只保留 K线、聚合深度、聚合成交、最优挂单与迷你行情
+type pbPushData struct {
+	Channel    string
+	Symbol     string
+	SymbolId   string
+	CreateTime int64
+	SendTime   int64
+
+	Kline      *pbKline
+	Depth      *pbAggreDepth
+	Deals      *pbAggreDeals
+	BookTicker *pbBookTicker
+	MiniTicker *pbMiniTicker
+}
+
+// pbKline 对应 PublicSpotKlineV3Api
+type pbKline struct {
+	Interval     string // Min1
+	WindowStart  int64  // 秒
+	OpeningPrice string
+	ClosingPrice string
+	HighestPrice string
+	LowestPrice  string
+	Volume       string // 基础币成交量
+	Amount       string // 计价币成交额
+	WindowEnd    int64  // 秒
+}
+
+// pbAggreDepth 对应 PublicAggreDepthsV3Api
+type pbAggreDepth struct {
+	Asks        []pbDepthItem
+	Bids        []pbDepthItem
+	EventType   string
+	FromVersion string
+	ToVersion   string
+}
+
+// pbAggreDeals 对应 PublicAggreDealsV3Api
+type pbAggreDeals struct {
+	Deals     []pbDealItem
+	EventType string
+}
+
+// pbDealItem 对应 PublicAggreDealsV3ApiItem
+type pbDealItem struct {
+	Price     string
+	Quantity  string
+	TradeType int32 // 主动成交方向：1买 2卖
+	Time      int64 // 毫秒
+}
+
+// pbBookTicker 对应 PublicAggreBookTickerV3Api
+type pbBookTicker struct {
+	BidPrice    string
+	BidQuantity string
+	AskPrice    string
+	AskQuantity string
+}
+
+// pbMiniTicker 对应 PublicMiniTickerV3Api，按所选时区滚动统计
+type pbMiniTicker struct {
+	Symbol   string
+	Price    string // 最新价
+	Rate     string // 涨跌幅
+	High     string
+	Low      string
+	Volume   string // 计价币成交额
+	Quantity string // 基础币成交量
+}
+
+// pbDepthItem 对应 PublicAggreDepthV3ApiItem
+type pbDepthItem struct {
+	Price    string
+	Quantity string
+}
+
+// decodePushData 解码一帧二进制推送
+func decodePushData(b []byte) (*pbPushData, error) {
+	out := &pbPushData{}
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch {
+		case num == wrapperChannel && typ == protowire.BytesType:
+			out.Channel = string(v)
+		case num == wrapperSymbol && typ == protowire.BytesType:
+			out.Symbol = string(v)
+		case num == wrapperSymbolId && typ == protowire.BytesType:
+			out.SymbolId = string(v)
+		case num == wrapperCreateTime && typ == protowire.VarintType:
+			out.CreateTime = int64(n)
+		case num == wrapperSendTime && typ == protowire.VarintType:
+			out.SendTime = int64(n)
+		case num == wrapperPublicSpotKline && typ == protowire.BytesType:
+			k, err := decodeKline(v)
+			if err != nil {
+				return fmt.Errorf("publicSpotKline: %w", err)
+			}
+			out.Kline = k
+		case num == wrapperPublicAggreDepths && typ == protowire.BytesType:
+			d, err := decodeAggreDepth(v)
+			if err != nil {
+				return fmt.Errorf("publicAggreDepths: %w", err)
+			}
+			out.Depth = d
+		case num == wrapperPublicAggreDeals && typ == protowire.BytesType:
+			d, err := decodeAggreDeals(v)
+			if err != nil {
+				return fmt.Errorf("publicAggreDeals: %w", err)
+			}
+			out.Deals = d
+		case num == wrapperPublicBookTicker && typ == protowire.BytesType:
+			bt, err := decodeBookTicker(v)
+			if err != nil {
+				return fmt.Errorf("publicAggreBookTicker: %w", err)
+			}
+			out.BookTicker = bt
+		case num == wrapperPublicMiniTicker && typ == protowire.BytesType:
+			t, err := decodeMiniTicker(v)
+			if err != nil {
+				return fmt.Errorf("publicMiniTicker: %w", err)
+			}
+			out.MiniTicker = t
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func decodeKline(b []byte) (*pbKline, error) {
+	k := &pbKline{}
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		if typ == protowire.VarintType {
+			switch num {
+			case 2:
+				k.WindowStart = int64(n)
+			case 9:
+				k.WindowEnd = int64(n)
+			}
+			return nil
+		}
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			k.Interval = string(v)
+		case 3:
+			k.OpeningPrice = string(v)
+		case 4:
+			k.ClosingPrice = string(v)
+		case 5:
+			k.HighestPrice = string(v)
+		case 6:
+			k.LowestPrice = string(v)
+		case 7:
+			k.Volume = string(v)
+		case 8:
+			k.Amount = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func decodeAggreDepth(b []byte) (*pbAggreDepth, error) {
+	d := &pbAggreDepth{}
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1, 2:
+			item, err := decodeDepthItem(v)
+			if err != nil {
+				return err
+			}
+			if num == 1 {
+				d.Asks = append(d.Asks, item)
+			} else {
+				d.Bids = append(d.Bids, item)
+			}
+		case 3:
+			d.EventType = string(v)
+		case 4:
+			d.FromVersion = string(v)
+		case 5:
+			d.ToVersion = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func decodeAggreDeals(b []byte) (*pbAggreDeals, error) {
+	d := &pbAggreDeals{}
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			item, err := decodeDealItem(v)
+			if err != nil {
+				return err
+			}
+			d.Deals = append(d.Deals, item)
+		case 2:
+			d.EventType = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func decodeDealItem(b []byte) (pbDealItem, error) {
+	var item pbDealItem
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			item.Price = string(v)
+		case num == 2 && typ == protowire.BytesType:
+			item.Quantity = string(v)
+		case num == 3 && typ == protowire.VarintType:
+			item.TradeType = int32(n)
+		case num == 4 && typ == protowire.VarintType:
+			item.Time = int64(n)
+		}
+		return nil
+	})
+	return item, err
+}
+
+func decodeBookTicker(b []byte) (*pbBookTicker, error) {
+	bt := &pbBookTicker{}
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			bt.BidPrice = string(v)
+		case 2:
+			bt.BidQuantity = string(v)
+		case 3:
+			bt.AskPrice = string(v)
+		case 4:
+			bt.AskQuantity = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bt, nil
+}
+
+func decodeMiniTicker(b []byte) (*pbMiniTicker, error) {
+	t := &pbMiniTicker{}
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			t.Symbol = string(v)
+		case 2:
+			t.Price = string(v)
+		case 3:
+			t.Rate = string(v)
+		case 5:
+			t.High = string(v)
+		case 6:
+			t.Low = string(v)
+		case 7:
+			t.Volume = string(v)
+		case 8:
+			t.Quantity = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func decodeDepthItem(b []byte) (pbDepthItem, error) {
+	var item pbDepthItem
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			item.Price = string(v)
+		case 2:
+			item.Quantity = string(v)
+		}
+		return nil
+	})
+	return item, err
+}
+
+// walkFields 依次遍历消息中的字段：varint 字段通过 n 返回，length-delimited 字段通过 v 返回，
+// 其它类型的字段跳过
+func walkFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
+			return protowire.ParseError(tagLen)
+		}
+		b = b[tagLen:]
+
+		var (
+			v        []byte
+			n        uint64
+			fieldLen int
+		)
+		switch typ {
+		case protowire.VarintType:
+			n, fieldLen = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			v, fieldLen = protowire.ConsumeBytes(b)
+		default:
+			fieldLen = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if fieldLen < 0 {
+			return protowire.ParseError(fieldLen)
+		}
+		b = b[fieldLen:]
+
+		if typ == protowire.VarintType || typ == protowire.BytesType {
+			if err := fn(num, typ, v, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -14,10 +15,11 @@ import (
 )
 
 const (
-	mexcBaseURL      = "https://api.mexc.com"
-	apiV3TickerPrice = "/api/v3/ticker/price"
-	apiV3Kline       = "/api/v3/kline"
-	apiV3Depth       = "/api/v3/depth"
+	mexcBaseURL       = "https://api.mexc.com"
+	apiV3Ticker24hr   = "/api/v3/ticker/24hr"
+	apiV3Kline        = "/api/v3/klines"
+	apiV3Depth        = "/api/v3/depth"
+	apiV3ExchangeInfo = "/api/v3/exchangeInfo"
 )
 
 type SpotREST struct{ http *resty.Client }
@@ -26,15 +28,10 @@ func NewSpotREST() *SpotREST {
 	return &SpotREST{http: resty.New().SetBaseURL(mexcBaseURL).SetTimeout(10 * time.Second)}
 }
 
-func (m *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
-	var resp struct {
-		Symbol    string `json:"symbol"`
-		Price     string `json:"price"`
-		Volume    string `json:"volume"`
-		QuoteVol  string `json:"quoteVolume"`
-		Timestamp int64  `json:"time"`
-	}
-	r, err := m.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("symbol", symbol).Get(apiV3TickerPrice)
+// GetTicker 获取单个币对的24小时滚动行情
+func (s *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	var resp schema.MEXCTickerResponse
+	r, err := s.http.R().SetContext(ctx).SetQueryParam("symbol", symbol).SetResult(&resp).Get(apiV3Ticker24hr)
 	if err != nil {
 		return schema.Ticker{}, err
 	}
@@ -42,23 +39,48 @@ func (m *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker,
 		return schema.Ticker{}, errors.New(r.Status())
 	}
 
-	// 保存原始响应结果用于调试
-	rawResponse := string(r.Body())
-	logger.Debug("MEXC Spot Ticker 原始响应: %s", rawResponse)
+	logger.Debug("MEXC Spot Ticker 原始响应: %s", string(r.Body()))
+	return convertTicker(resp), nil
+}
+
+// GetTickers 获取全部币对的24小时滚动行情
+func (s *SpotREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	var resp []schema.MEXCTickerResponse
+	r, err := s.http.R().SetContext(ctx).SetResult(&resp).Get(apiV3Ticker24hr)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
 
-	price, _ := decimal.NewFromString(resp.Price)
-	volume, _ := decimal.NewFromString(resp.Volume)
-	quoteVolume, _ := decimal.NewFromString(resp.QuoteVol)
+	out := make([]schema.Ticker, 0, len(resp))
+	for _, t := range resp {
+		out = append(out, convertTicker(t))
+	}
+	return out, nil
+}
+
+func convertTicker(t schema.MEXCTickerResponse) schema.Ticker {
+	price, _ := decimal.NewFromString(t.LastPrice)
+	open, _ := decimal.NewFromString(t.OpenPrice)
+	high, _ := decimal.NewFromString(t.HighPrice)
+	low, _ := decimal.NewFromString(t.LowPrice)
+	volume, _ := decimal.NewFromString(t.Volume)
+	quoteVolume, _ := decimal.NewFromString(t.QuoteVolume)
 
 	return schema.Ticker{
 		Exchange:  schema.MEXC,
 		Market:    schema.SPOT,
-		Symbol:    resp.Symbol,
+		Symbol:    t.Symbol,
 		Price:     price,
+		Open:      open,
+		High:      high,
+		Low:       low,
 		Volume:    volume,
 		QuoteVol:  quoteVolume,
-		Timestamp: time.UnixMilli(resp.Timestamp),
-	}, nil
+		Timestamp: time.UnixMilli(t.CloseTime),
+	}
 }
 
 func (s *SpotREST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
@@ -86,27 +108,135 @@ func (s *SpotREST) GetKline(ctx context.Context, symbol string, interval schema.
 	return out, nil
 }
 
-func (m *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
-	var resp struct {
-		LastUpdateId int64      `json:"lastUpdateId"`
-		Bids         [][]string `json:"bids"`
-		Asks         [][]string `json:"asks"`
+// maxKlinesPerRequest MEXC 现货K线接口单次最多返回的条数
+const maxKlinesPerRequest = 1000
+
+// restKlineIntervals schema.Interval 到 REST K线周期的映射，与 WebSocket 的 Min1 等写法不同
+var restKlineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1m",
+	schema.Interval5m:  "5m",
+	schema.Interval15m: "15m",
+	schema.Interval30m: "30m",
+	schema.Interval1h:  "60m",
+	schema.Interval4h:  "4h",
+	schema.Interval1d:  "1d",
+}
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按开盘时间由旧到新分页拉取，end 为零值时取到当前时间
+func (s *SpotREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if _, ok := restKlineIntervals[interval]; !ok {
+		return nil, fmt.Errorf("unsupported kline interval %s for mexc", interval)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	from := start
+	for !from.After(end) {
+		var resp schema.MEXCKlineResponse
+		r, err := s.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+			"symbol":    symbol,
+			"interval":  restKlineIntervals[interval],
+			"startTime": strconv.FormatInt(from.UnixMilli(), 10),
+			"endTime":   strconv.FormatInt(end.UnixMilli(), 10),
+			"limit":     strconv.Itoa(maxKlinesPerRequest),
+		}).Get(apiV3Kline)
+		if err != nil {
+			return nil, err
+		}
+		if r.IsError() {
+			return nil, fmt.Errorf("%s: %s", r.Status(), string(r.Body()))
+		}
+		logger.Debug("MEXC Spot Kline 返回 %d 条", len(resp))
+
+		page := convertKlines(resp, symbol, interval)
+		out = append(out, page...)
+		if len(page) < maxKlinesPerRequest {
+			break
+		}
+
+		// 从最后一根的下一根继续拉取
+		next := page[len(page)-1].OpenTime.Add(interval.Duration())
+		if !next.After(from) {
+			break
+		}
+		from = next
 	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// convertKlines 转换K线数组，成交量为基础币数量，成交额为计价币数量；收盘时间未到的K线标记为未完结
+func convertKlines(rows schema.MEXCKlineResponse, symbol string, interval schema.Interval) []schema.Kline {
+	now := time.Now()
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 8 {
+			continue
+		}
+		openTime, _ := row[0].Int64()
+		closeTime, _ := row[6].Int64()
+		open, _ := decimal.NewFromString(row[1].String())
+		high, _ := decimal.NewFromString(row[2].String())
+		low, _ := decimal.NewFromString(row[3].String())
+		close, _ := decimal.NewFromString(row[4].String())
+		volume, _ := decimal.NewFromString(row[5].String())
+		quoteVolume, _ := decimal.NewFromString(row[7].String())
+		out = append(out, schema.Kline{
+			Exchange:    schema.MEXC,
+			Market:      schema.SPOT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    time.UnixMilli(openTime),
+			CloseTime:   time.UnixMilli(closeTime),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     time.UnixMilli(closeTime).Before(now),
+		})
+	}
+	return out
+}
+
+// mexcDepth /api/v3/depth 响应，lastUpdateId 用于与 WebSocket 增量对齐
+type mexcDepth struct {
+	LastUpdateId int64      `json:"lastUpdateId"`
+	Bids         [][]string `json:"bids"`
+	Asks         [][]string `json:"asks"`
+	Timestamp    int64      `json:"timestamp"`
+}
+
+// depth 获取带 lastUpdateId 的深度快照
+func (m *SpotREST) depth(ctx context.Context, symbol string, limit int) (*mexcDepth, error) {
+	var resp mexcDepth
 	r, err := m.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
 		"symbol": symbol,
 		"limit":  fmt.Sprintf("%d", limit),
 	}).Get(apiV3Depth)
 	if err != nil {
-		return schema.Depth{}, err
+		return nil, err
 	}
 	if r.IsError() {
-		return schema.Depth{}, errors.New(r.Status())
+		return nil, errors.New(r.Status())
 	}
 
 	// 保存原始响应结果用于调试
 	rawResponse := string(r.Body())
 	logger.Debug("MEXC Spot Depth 原始响应: %s", rawResponse)
 
+	return &resp, nil
+}
+
+func (m *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
+	resp, err := m.depth(ctx, symbol, limit)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+
 	convert := func(levels [][]string) []schema.PriceLevel {
 		out := make([]schema.PriceLevel, 0, len(levels))
 		for _, level := range levels {
@@ -122,26 +252,96 @@ func (m *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (sche
 		return out
 	}
 
+	updatedAt := time.Now()
+	if resp.Timestamp > 0 {
+		updatedAt = time.UnixMilli(resp.Timestamp)
+	}
+
 	return schema.Depth{
 		Exchange:     schema.MEXC,
 		Market:       schema.SPOT,
 		Symbol:       symbol,
 		Bids:         convert(resp.Bids),
 		Asks:         convert(resp.Asks),
-		UpdatedAt:    time.Now(),
+		UpdatedAt:    updatedAt,
 		LastUpdateId: fmt.Sprintf("%d", resp.LastUpdateId),
 	}, nil
 }
 
+// GetExchangeInfo 获取现货交易规则，MEXC 的 filters 为空，步长和最小下单金额取自 baseSizePrecision、quoteAmountPrecision
 func (m *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现MEXC交易规则信息获取
+	var resp struct {
+		Timezone   string `json:"timezone"`
+		ServerTime int64  `json:"serverTime"`
+		Symbols    []struct {
+			Symbol               string `json:"symbol"`
+			Status               string `json:"status"` // 1 正常 2 暂停 3 下线
+			BaseAsset            string `json:"baseAsset"`
+			QuoteAsset           string `json:"quoteAsset"`
+			BaseAssetPrecision   int    `json:"baseAssetPrecision"`
+			QuotePrecision       int    `json:"quotePrecision"`
+			BaseSizePrecision    string `json:"baseSizePrecision"`
+			QuoteAmountPrecision string `json:"quoteAmountPrecision"`
+			MaxQuoteAmount       string `json:"maxQuoteAmount"`
+			IsSpotTradingAllowed bool   `json:"isSpotTradingAllowed"`
+		} `json:"symbols"`
+	}
+	r, err := m.http.R().SetContext(ctx).SetResult(&resp).Get(apiV3ExchangeInfo)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	if r.IsError() {
+		return schema.ExchangeInfo{}, errors.New(r.Status())
+	}
+	logger.Debug("MEXC Spot ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+	symbols := make([]schema.Symbol, 0, len(resp.Symbols))
+	for _, s := range resp.Symbols {
+		status, ok := symbolStatus(s.Status)
+		if !ok {
+			continue
+		}
+		if !s.IsSpotTradingAllowed && status == schema.SymbolStatusTrading {
+			status = schema.SymbolStatusHalt
+		}
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       s.Symbol,
+			Base:         s.BaseAsset,
+			Quote:        s.QuoteAsset,
+			ExchangeName: schema.MEXC,
+			MarketType:   schema.SPOT,
+
+			QuantityPrecision: s.BaseAssetPrecision,
+			PricePrecision:    s.QuotePrecision,
+			MinQuantity:       s.BaseSizePrecision,
+			MinNotional:       s.QuoteAmountPrecision,
+			TickSize:          decimal.New(1, -int32(s.QuotePrecision)).String(),
+			StepSize:          s.BaseSizePrecision,
+
+			Status: status,
+		})
+	}
+
+	logger.Info("MEXC Spot 交易规则已加载: %d 个交易对", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.MEXC,
 		Market:     schema.SPOT,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: time.UnixMilli(resp.ServerTime),
 		RateLimits: []schema.RateLimit{},
-		Timezone:   "UTC",
+		Timezone:   resp.Timezone,
 	}, nil
 }
+
+// symbolStatus 归一化交易对状态，已下线（3）的交易对返回 false；新版接口也可能返回 ENABLED 等文字状态
+func symbolStatus(status string) (schema.SymbolStatus, bool) {
+	switch status {
+	case "1", "ENABLED":
+		return schema.SymbolStatusTrading, true
+	case "2", "PAUSED":
+		return schema.SymbolStatusHalt, true
+	default:
+		return "", false
+	}
+}
@@ -59,3 +59,23 @@ func TestMEXCSpotWS_Kline(t *testing.T) {
 
 	log.Printf("=== MEXC WS Kline 集成测试完成 ===")
 }
+
+func TestMEXCSpotREST_ExchangeInfo(t *testing.T) {
+	r := NewSpotREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	info, err := r.GetExchangeInfo(ctx)
+	if err != nil {
+		t.Fatalf("exchange info error: %v", err)
+	}
+	var btc *schema.Symbol
+	for i := range info.Symbols {
+		if info.Symbols[i].Symbol == "BTCUSDT" {
+			btc = &info.Symbols[i]
+		}
+	}
+	if btc == nil || btc.TickSize == "" || btc.StepSize == "" || !btc.IsTrading() {
+		t.Fatalf("unexpected BTCUSDT: %+v", btc)
+	}
+	log.Printf("MEXC Spot ExchangeInfo: 交易对数=%d, BTCUSDT=%+v", len(info.Symbols), *btc)
+}
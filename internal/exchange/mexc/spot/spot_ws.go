@@ -2,383 +2,1152 @@ package spot
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
 	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
-
-	"github.com/shopspring/decimal"
 )
 
 const (
-	wsURL = "wss://wbs.mexc.com/ws"
+	// v3 行情推送为 protobuf 二进制帧，旧的 JSON 接口 wbs.mexc.com 已下线
+	wsURL = "wss://wbs-api.mexc.com/ws"
+
+	methodSubscribe   = "SUBSCRIPTION"
+	methodUnsubscribe = "UNSUBSCRIPTION"
+	methodPing        = "PING"
+
+	// spot@public.kline.v3.api.pb@BTCUSDT@Min1
+	channelKlinePrefix = "spot@public.kline.v3.api.pb@"
+	// spot@public.aggre.depth.v3.api.pb@100ms@BTCUSDT
+	channelDepthPrefix = "spot@public.aggre.depth.v3.api.pb@"
+	// spot@public.aggre.deals.v3.api.pb@100ms@BTCUSDT
+	channelDealsPrefix = "spot@public.aggre.deals.v3.api.pb@"
+	// spot@public.aggre.bookTicker.v3.api.pb@100ms@BTCUSDT
+	channelBookTickerPrefix = "spot@public.aggre.bookTicker.v3.api.pb@"
+	// spot@public.miniTicker.v3.api.pb@BTCUSDT@24H，24H 表示24小时滚动统计
+	channelMiniTickerPrefix = "spot@public.miniTicker.v3.api.pb@"
+	miniTickerTimezone      = "24H"
+
+	depthFrequency  = "100ms"
+	depthSnapshotSz = 1000
+
+	// 单条订阅消息的最大频道数
+	maxParamsPerRequest = 30
+
+	// 服务端60秒内未收到 PING 会断开连接
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
+
+	// 快照获取失败后的重试间隔
+	syncRetryInterval = 3 * time.Second
 )
 
+type mexcRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params,omitempty"`
+}
+
+// depthUpdate 聚合深度增量，版本号已解析为整数
+type depthUpdate struct {
+	fromVersion int64
+	toVersion   int64
+	bids        []pbDepthItem
+	asks        []pbDepthItem
+	ts          int64
+}
+
+// klineIntervals schema.Interval 到 MEXC K线周期的映射，MEXC 不支持 3m
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "Min1",
+	schema.Interval5m:  "Min5",
+	schema.Interval15m: "Min15",
+	schema.Interval30m: "Min30",
+	schema.Interval1h:  "Min60",
+	schema.Interval4h:  "Hour4",
+	schema.Interval1d:  "Day1",
+}
+
+// orderBook 本地订单簿
+type orderBook struct {
+	version int64
+	synced  bool                       // 是否已应用快照之后的第一条增量；之前按 fromVersion <= version+1 衔接，之后要求严格连续
+	bids    map[string]decimal.Decimal // price -> quantity
+	asks    map[string]decimal.Decimal // price -> quantity
+}
+
+// bookSync 记录快照同步期间缓存的增量
+type bookSync struct {
+	buffer   []*depthUpdate
+	syncing  bool
+	failedAt time.Time
+}
+
 type SpotWS struct {
-	conn interfaces.WSConn
-	mu   sync.RWMutex
-	subs interfaces.SubscriptionManager
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+	rest    *SpotREST
+
+	// per-symbol local order books and sync state
+	orderBooks map[string]*orderBook
+	syncs      map[string]*bookSync
+
+	// 最近一根K线，用于在新K线开始时标记上一根已完结
+	klineMu    sync.Mutex
+	lastKlines map[schema.KlineSubscription]schema.Kline
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewSpotWS(subs interfaces.SubscriptionManager) *SpotWS {
+func NewSpotWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *SpotREST) *SpotWS {
+	d := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &SpotWS{
-		subs: subs,
+		dialer:     d,
+		cache:      c,
+		gaps:       cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:       subs,
+		rest:       rest,
+		orderBooks: make(map[string]*orderBook),
+		syncs:      make(map[string]*bookSync),
+		lastKlines: make(map[schema.KlineSubscription]schema.Kline),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 }
 
 func (s *SpotWS) Connect(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.conn != nil {
+		s.mu.Unlock()
 		logger.Info("MEXC Spot WS 已连接，跳过连接")
 		return nil
 	}
 
 	logger.Info("MEXC Spot WS 开始连接...")
+	conn, _, err := s.dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		s.mu.Unlock()
+		logger.Error("MEXC Spot WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024)
+	s.conn = conn
+	s.mu.Unlock()
 
-	// 这里应该实现实际的WebSocket连接逻辑
-	// 由于没有具体的连接实现，我们只是记录日志
-	logger.Info("MEXC Spot WS 连接成功")
+	now := time.Now()
+	s.healthMu.Lock()
+	s.lastMessage = now
+	s.lastPing = now
+	s.healthMu.Unlock()
 
-	// 启动消息读取
-	go s.StartReading(ctx)
+	logger.Info("MEXC Spot WS 连接成功")
 
+	// 重连时自动恢复订阅
+	_ = s.applySubscriptions(ctx)
 	return nil
 }
 
 func (s *SpotWS) Close() error {
+	s.cancel()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.conn != nil {
-		return s.conn.Close()
+		err := s.conn.Close()
+		s.conn = nil
+		return err
 	}
 	return nil
 }
 
-func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
 	}
 
-	// 固定订阅1m K线数据
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+	newlyAdded := s.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
 	if len(newlyAdded) == 0 {
 		logger.Info("MEXC Spot WS 所有币对都已订阅 kline，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("MEXC Spot WS 新增订阅 kline: %v (固定1m)", newlyAdded)
+	logger.Info("MEXC Spot WS 新增订阅 kline: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("MEXC Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendKline(ctx, methodSubscribe, newlyAdded)
+}
+
+// UnsubscribeKline 只退订K线频道的指定周期，未指定周期时退订全部周期
+func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都未订阅 kline，跳过退订请求")
+		return nil
+	}
 
-	// 构建并发送 kline 订阅消息
-	return s.sendKlineSubscription(conn, newlyAdded)
+	logger.Info("MEXC Spot WS 退订 kline: %v", removed)
+	s.gaps.Forget(removed)
+
+	s.klineMu.Lock()
+	for _, sub := range removed {
+		delete(s.lastKlines, sub)
+	}
+	s.klineMu.Unlock()
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.sendKline(ctx, methodUnsubscribe, removed)
 }
 
-func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都已订阅 depth，跳过订阅请求")
+		return nil
 	}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("MEXC Spot WS 所有币对都已退订 kline，跳过退订请求")
+	logger.Info("MEXC Spot WS 新增订阅 depth: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendDepth(ctx, methodSubscribe, newlyAdded)
+}
 
-	logger.Info("MEXC Spot WS 退订 kline: %v", actuallyRemoved)
+func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
+	return s.unsubscribe(ctx, symbols, "depth")
+}
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
-		logger.Warn("MEXC Spot WS 未连接，退订状态已保存，连接后将自动应用")
+func (s *SpotWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都已订阅 trades，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("MEXC Spot WS 新增订阅 trades: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendKlineUnsubscription(conn, actuallyRemoved)
+	return s.sendDeals(ctx, methodSubscribe, newlyAdded)
 }
 
-func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
 	}
 
-	// Subscribe only depth data
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+	logger.Info("MEXC Spot WS 退订 trades: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.sendDeals(ctx, methodUnsubscribe, removed)
+}
+
+func (s *SpotWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
 	if len(newlyAdded) == 0 {
-		logger.Info("MEXC Spot WS 所有币对都已订阅 depth，跳过订阅请求")
+		logger.Info("MEXC Spot WS 所有币对都已订阅 bookTicker，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("MEXC Spot WS 新增订阅 depth: %v", newlyAdded)
+	logger.Info("MEXC Spot WS 新增订阅 bookTicker: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("MEXC Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendBookTicker(ctx, methodSubscribe, newlyAdded)
+}
+
+func (s *SpotWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都未订阅 bookTicker，跳过退订请求")
+		return nil
+	}
 
-	// 构建并发送 depth 订阅消息
-	return s.sendDepthSubscription(conn, newlyAdded)
+	logger.Info("MEXC Spot WS 退订 bookTicker: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.sendBookTicker(ctx, methodUnsubscribe, removed)
 }
 
-func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都已订阅 ticker，跳过订阅请求")
+		return nil
 	}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("MEXC Spot WS 所有币对都已退订 depth，跳过退订请求")
+	logger.Info("MEXC Spot WS 新增订阅 ticker: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.sendTicker(ctx, methodSubscribe, newlyAdded)
+}
 
-	logger.Info("MEXC Spot WS 退订 depth: %v", actuallyRemoved)
+func (s *SpotWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都未订阅 ticker，跳过退订请求")
+		return nil
+	}
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	logger.Info("MEXC Spot WS 退订 ticker: %v", removed)
+
+	if !s.isConnected() {
 		logger.Warn("MEXC Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendDepthUnsubscription(conn, actuallyRemoved)
+	return s.sendTicker(ctx, methodUnsubscribe, removed)
 }
 
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 depth 频道
+func (s *SpotWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(s.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("MEXC Spot WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
+	}
+
+	logger.Info("MEXC Spot WS 退订 %s: %v", kind, removed)
+
+	s.mu.Lock()
+	for _, symbol := range removed {
+		delete(s.orderBooks, symbol)
+		delete(s.syncs, symbol)
+	}
+	s.mu.Unlock()
+
+	s.klineMu.Lock()
+	for _, sub := range klines {
+		delete(s.lastKlines, sub)
+	}
+	s.klineMu.Unlock()
+
+	if !s.isConnected() {
+		logger.Warn("MEXC Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+
+	if err := s.sendKline(ctx, methodUnsubscribe, klines); err != nil {
+		return err
+	}
+	return s.sendDepth(ctx, methodUnsubscribe, removed)
+}
+
+// SendMessage sends a message to WebSocket server
 func (s *SpotWS) SendMessage(ctx context.Context, message interface{}) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
-
 	if conn == nil {
-		return fmt.Errorf("WebSocket connection not established")
+		return errors.New("WebSocket connection not established")
 	}
 
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("MEXC Spot WS SendMessage: %s", string(jsonData))
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("MEXC Spot WS 序列化消息失败: %v", err)
+		return err
 	}
-	return conn.WriteJSON(message)
+
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		logger.Error("MEXC Spot WS 发送消息失败: %v", err)
+		return err
+	}
+
+	logger.Info("MEXC Spot WS SendMessage: %s", string(data))
+	return nil
+}
+
+// sendKline 按 maxParamsPerRequest 分批发送 K线订阅/退订
+func (s *SpotWS) sendKline(ctx context.Context, method string, subs []schema.KlineSubscription) error {
+	params := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		params = append(params, klineChannel(sub.Symbol, sub.Interval))
+	}
+	return s.sendParams(ctx, method, params)
 }
 
-func (s *SpotWS) sendKlineSubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"method": "SUBSCRIPTION",
-			"params": []string{fmt.Sprintf("spot@public.kline.v3.api@%s@1m", sym)},
+func (s *SpotWS) sendDepth(ctx context.Context, method string, symbols []string) error {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, depthChannel(symbol))
+	}
+	return s.sendParams(ctx, method, params)
+}
+
+func (s *SpotWS) sendDeals(ctx context.Context, method string, symbols []string) error {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, dealsChannel(symbol))
+	}
+	return s.sendParams(ctx, method, params)
+}
+
+func (s *SpotWS) sendBookTicker(ctx context.Context, method string, symbols []string) error {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, bookTickerChannel(symbol))
+	}
+	return s.sendParams(ctx, method, params)
+}
+
+func (s *SpotWS) sendTicker(ctx context.Context, method string, symbols []string) error {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, miniTickerChannel(symbol))
+	}
+	return s.sendParams(ctx, method, params)
+}
+
+func (s *SpotWS) sendParams(ctx context.Context, method string, params []string) error {
+	for start := 0; start < len(params); start += maxParamsPerRequest {
+		end := start + maxParamsPerRequest
+		if end > len(params) {
+			end = len(params)
 		}
-		if err := conn.WriteJSON(msg); err != nil {
+		if err := s.SendMessage(ctx, &mexcRequest{Method: method, Params: params[start:end]}); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (s *SpotWS) sendKlineUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"method": "UNSUBSCRIPTION",
-			"params": []string{fmt.Sprintf("spot@public.kline.v3.api@%s@1m", sym)},
+func (s *SpotWS) applySubscriptions(ctx context.Context) error {
+	klineSubs := s.subs.GetKlineSubscriptions()
+	depthSymbols := s.subs.GetDepthSymbols()
+	tradeSymbols := s.subs.GetTradeSymbols()
+	bookTickerSymbols := s.subs.GetBookTickerSymbols()
+	tickerSymbols := s.subs.GetTickerSymbols()
+	if len(klineSubs) == 0 && len(depthSymbols) == 0 && len(tradeSymbols) == 0 && len(bookTickerSymbols) == 0 && len(tickerSymbols) == 0 {
+		logger.Info("MEXC Spot WS 无订阅")
+		return nil
+	}
+	if err := s.sendKline(ctx, methodSubscribe, klineSubs); err != nil {
+		return err
+	}
+	if err := s.sendDepth(ctx, methodSubscribe, depthSymbols); err != nil {
+		return err
+	}
+	if err := s.sendDeals(ctx, methodSubscribe, tradeSymbols); err != nil {
+		return err
+	}
+	if err := s.sendBookTicker(ctx, methodSubscribe, bookTickerSymbols); err != nil {
+		return err
+	}
+	return s.sendTicker(ctx, methodSubscribe, tickerSymbols)
+}
+
+func klineChannel(symbol string, interval schema.Interval) string {
+	return channelKlinePrefix + symbol + "@" + klineIntervals[interval]
+}
+
+func depthChannel(symbol string) string {
+	return channelDepthPrefix + depthFrequency + "@" + symbol
+}
+
+func dealsChannel(symbol string) string {
+	return channelDealsPrefix + depthFrequency + "@" + symbol
+}
+
+func bookTickerChannel(symbol string) string {
+	return channelBookTickerPrefix + depthFrequency + "@" + symbol
+}
+
+func miniTickerChannel(symbol string) string {
+	return channelMiniTickerPrefix + symbol + "@" + miniTickerTimezone
+}
+
+// klineIntervalOf 由 MEXC K线周期解析 schema.Interval，如 Min60 -> 1h
+func klineIntervalOf(v string) (schema.Interval, bool) {
+	for interval, name := range klineIntervals {
+		if name == v {
+			return interval, true
 		}
-		if err := conn.WriteJSON(msg); err != nil {
-			return err
+	}
+	return "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for mexc", interval)
 		}
 	}
 	return nil
 }
 
-func (s *SpotWS) sendDepthSubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"method": "SUBSCRIPTION",
-			"params": []string{fmt.Sprintf("spot@public.depth.v3.api@%s@20", sym)},
-		}
-		if err := conn.WriteJSON(msg); err != nil {
-			return err
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
 		}
+		seen[s] = struct{}{}
+		out = append(out, s)
 	}
-	return nil
+	return out
 }
 
-func (s *SpotWS) sendDepthUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	for _, sym := range symbols {
-		msg := map[string]any{
-			"method": "UNSUBSCRIPTION",
-			"params": []string{fmt.Sprintf("spot@public.depth.v3.api@%s@20", sym)},
+func (s *SpotWS) isConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
+func (s *SpotWS) StartReading(ctx context.Context) error {
+	logger.Info("MEXC Spot WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("MEXC Spot WS 上下文取消")
+				return
+			case <-s.ctx.Done():
+				logger.Info("MEXC Spot WS 已关闭")
+				return
+			default:
+			}
+
+			s.mu.RLock()
+			conn := s.conn
+			s.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			msgType, message, err := conn.ReadMessage()
+			if err != nil {
+				if s.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("MEXC Spot WS 读取消息失败: %v", err)
+				s.reconnect(ctx)
+				continue
+			}
+
+			s.healthMu.Lock()
+			s.lastMessage = time.Now()
+			s.healthMu.Unlock()
+
+			if msgType == websocket.BinaryMessage {
+				s.handleBinaryMessage(message)
+			} else {
+				s.handleTextMessage(message)
+			}
 		}
-		if err := conn.WriteJSON(msg); err != nil {
-			return err
+	}()
+
+	return nil
+}
+
+// handleTextMessage 处理订阅响应与 PONG，均为 JSON 文本
+func (s *SpotWS) handleTextMessage(message []byte) {
+	logger.Debug("MEXC Spot WS 收到文本消息: %s", string(message))
+
+	var msg struct {
+		Id   int64  `json:"id"`
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("MEXC Spot WS 解析文本消息失败: %v", err)
+		return
+	}
+
+	switch {
+	case msg.Msg == "PONG":
+		logger.Debug("MEXC Spot WS 收到 PONG")
+	case msg.Code != 0:
+		logger.Error("MEXC Spot WS 请求错误: code=%d, msg=%s", msg.Code, msg.Msg)
+	default:
+		logger.Info("MEXC Spot WS 订阅响应: %s", msg.Msg)
+	}
+}
+
+// handleBinaryMessage 处理 protobuf 编码的行情推送
+func (s *SpotWS) handleBinaryMessage(message []byte) {
+	push, err := decodePushData(message)
+	if err != nil {
+		logger.Error("MEXC Spot WS 解析protobuf推送失败: %v", err)
+		return
+	}
+
+	switch {
+	case push.Kline != nil:
+		s.handleKline(push)
+	case push.Depth != nil:
+		s.handleDepth(push)
+	case push.Deals != nil:
+		s.handleDeals(push)
+	case push.BookTicker != nil:
+		s.handleBookTicker(push)
+	case push.MiniTicker != nil:
+		s.handleTicker(push)
+	default:
+		logger.Debug("MEXC Spot WS 未知频道: %s", push.Channel)
+	}
+}
+
+// handleDeals 处理聚合成交，推送不含成交ID
+func (s *SpotWS) handleDeals(push *pbPushData) {
+	for _, deal := range push.Deals.Deals {
+		price, _ := decimal.NewFromString(deal.Price)
+		quantity, _ := decimal.NewFromString(deal.Quantity)
+
+		side := schema.OrderSideBuy
+		if deal.TradeType == 2 {
+			side = schema.OrderSideSell
 		}
+
+		s.cache.AddTrade(schema.Trade{
+			Exchange:  schema.MEXC,
+			Market:    schema.SPOT,
+			Symbol:    push.Symbol,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  price.Mul(quantity),
+			Timestamp: time.UnixMilli(deal.Time),
+		})
 	}
-	return nil
 }
 
-func (s *SpotWS) StartReading(ctx context.Context) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
+// handleBookTicker 处理聚合最优挂单，推送不含订单簿版本号
+func (s *SpotWS) handleBookTicker(push *pbPushData) {
+	row := push.BookTicker
+	bidPrice, _ := decimal.NewFromString(row.BidPrice)
+	bidQty, _ := decimal.NewFromString(row.BidQuantity)
+	askPrice, _ := decimal.NewFromString(row.AskPrice)
+	askQty, _ := decimal.NewFromString(row.AskQuantity)
+
+	s.cache.SetBookTicker(schema.BookTicker{
+		Exchange:  schema.MEXC,
+		Market:    schema.SPOT,
+		Symbol:    push.Symbol,
+		BidPrice:  bidPrice,
+		BidQty:    bidQty,
+		AskPrice:  askPrice,
+		AskQty:    askQty,
+		UpdatedAt: time.UnixMilli(push.SendTime),
+	})
+}
+
+// handleTicker 处理迷你行情，推送不含开盘价
+func (s *SpotWS) handleTicker(push *pbPushData) {
+	row := push.MiniTicker
+	symbol := row.Symbol
+	if symbol == "" {
+		symbol = push.Symbol
+	}
+	price, _ := decimal.NewFromString(row.Price)
+	high, _ := decimal.NewFromString(row.High)
+	low, _ := decimal.NewFromString(row.Low)
+	volume, _ := decimal.NewFromString(row.Quantity)
+	quoteVolume, _ := decimal.NewFromString(row.Volume)
+
+	s.cache.SetTicker(schema.Ticker{
+		Exchange:  schema.MEXC,
+		Market:    schema.SPOT,
+		Symbol:    symbol,
+		Price:     price,
+		High:      high,
+		Low:       low,
+		Volume:    volume,
+		QuoteVol:  quoteVolume,
+		Timestamp: time.UnixMilli(push.SendTime),
+	})
+}
+
+func (s *SpotWS) handleKline(push *pbPushData) {
+	row := push.Kline
+	symbol := push.Symbol
+	interval, ok := klineIntervalOf(row.Interval)
+	if !ok {
+		logger.Warn("MEXC Spot WS 未知K线周期: %s", row.Interval)
+		return
+	}
+
+	open, _ := decimal.NewFromString(row.OpeningPrice)
+	high, _ := decimal.NewFromString(row.HighestPrice)
+	low, _ := decimal.NewFromString(row.LowestPrice)
+	close, _ := decimal.NewFromString(row.ClosingPrice)
+	volume, _ := decimal.NewFromString(row.Volume)
+	quoteVolume, _ := decimal.NewFromString(row.Amount)
+
+	eventTime := time.Now()
+	if push.SendTime > 0 {
+		eventTime = time.UnixMilli(push.SendTime)
+	}
+
+	openTime := time.Unix(row.WindowStart, 0)
+	kline := schema.Kline{
+		Exchange:    schema.MEXC,
+		Market:      schema.SPOT,
+		Symbol:      symbol,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   time.Unix(row.WindowEnd, 0).Add(-time.Millisecond),
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       close,
+		Volume:      volume,
+		QuoteVolume: quoteVolume,
+		EventTime:   eventTime,
+	}
+
+	// MEXC 不推送K线完结标记，收到新K线时将上一根标记为已完结
+	s.klineMu.Lock()
+	key := schema.KlineSubscription{Symbol: symbol, Interval: interval}
+	prev, ok := s.lastKlines[key]
+	s.lastKlines[key] = kline
+	s.klineMu.Unlock()
+	if ok && prev.OpenTime.Before(openTime) {
+		prev.IsFinal = true
+		s.gaps.SetKline(prev)
+	}
+
+	s.gaps.SetKline(kline)
+}
+
+// handleDepth 按 MEXC 文档维护本地订单簿：
+// 1. 订阅后先缓存增量，再通过 REST 获取带 lastUpdateId 的快照
+// 2. 丢弃 toVersion <= lastUpdateId 的增量，第一条应用的增量需满足 fromVersion <= lastUpdateId+1
+// 3. 之后每条增量的 fromVersion 必须等于上一条的 toVersion+1，否则重新同步
+func (s *SpotWS) handleDepth(push *pbPushData) {
+	symbol := push.Symbol
+	fromVersion, err1 := strconv.ParseInt(push.Depth.FromVersion, 10, 64)
+	toVersion, err2 := strconv.ParseInt(push.Depth.ToVersion, 10, 64)
+	if err1 != nil || err2 != nil {
+		logger.Error("MEXC Spot WS %s 深度版本号无效: from=%s, to=%s",
+			symbol, push.Depth.FromVersion, push.Depth.ToVersion)
+		return
+	}
+	update := &depthUpdate{
+		fromVersion: fromVersion,
+		toVersion:   toVersion,
+		bids:        push.Depth.Bids,
+		asks:        push.Depth.Asks,
+		ts:          push.SendTime,
+	}
+
+	s.mu.Lock()
+	ob := s.orderBooks[symbol]
+	if ob == nil {
+		st := s.syncs[symbol]
+		if st == nil {
+			st = &bookSync{}
+			s.syncs[symbol] = st
 		}
-		s.mu.RLock()
-		conn := s.conn
-		s.mu.RUnlock()
-		if conn == nil {
-			time.Sleep(200 * time.Millisecond)
-			continue
+		st.buffer = append(st.buffer, update)
+		start := !st.syncing && time.Since(st.failedAt) >= syncRetryInterval
+		if start {
+			st.syncing = true
 		}
-		var msg json.RawMessage
-		if err := conn.ReadJSON(&msg); err != nil {
-			time.Sleep(300 * time.Millisecond)
-			continue
+		s.mu.Unlock()
+		if start {
+			go s.syncOrderBook(symbol)
 		}
+		return
+	}
 
-		// 检查是否是 ping 消息
-		if s.handlePingMessage(msg, conn) {
-			continue
+	if update.toVersion <= ob.version {
+		s.mu.Unlock()
+		return
+	}
+	if (ob.synced && update.fromVersion != ob.version+1) || (!ob.synced && update.fromVersion > ob.version+1) {
+		logger.Warn("MEXC Spot WS %s 版本不连续: fromVersion=%d, 本地version=%d，重新同步快照",
+			symbol, update.fromVersion, ob.version)
+		delete(s.orderBooks, symbol)
+		s.syncs[symbol] = &bookSync{buffer: []*depthUpdate{update}, syncing: true}
+		s.mu.Unlock()
+		go s.syncOrderBook(symbol)
+		return
+	}
+	applyLevels(ob.bids, update.bids)
+	applyLevels(ob.asks, update.asks)
+	ob.version = update.toVersion
+	ob.synced = true
+	s.mu.Unlock()
+
+	s.publishDepth(symbol, update.ts)
+}
+
+// syncOrderBook 获取 REST 快照并回放缓存的增量
+func (s *SpotWS) syncOrderBook(symbol string) {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Second)
+	defer cancel()
+
+	snapshot, err := s.rest.depth(ctx, symbol, depthSnapshotSz)
+	if err != nil {
+		logger.Error("MEXC Spot WS 同步深度快照失败 %s: %v", symbol, err)
+		s.mu.Lock()
+		if st := s.syncs[symbol]; st != nil {
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
 		}
+		s.mu.Unlock()
+		return
+	}
+
+	s.mu.Lock()
+	st := s.syncs[symbol]
+	if st == nil {
+		// 同步期间已退订
+		s.mu.Unlock()
+		return
+	}
+
+	ob := &orderBook{
+		version: snapshot.LastUpdateId,
+		bids:    make(map[string]decimal.Decimal),
+		asks:    make(map[string]decimal.Decimal),
+	}
+	applySnapshotLevels(ob.bids, snapshot.Bids)
+	applySnapshotLevels(ob.asks, snapshot.Asks)
 
-		var raw map[string]any
-		if err := json.Unmarshal(msg, &raw); err != nil {
+	lastTs := snapshot.Timestamp
+	for _, update := range st.buffer {
+		if update.toVersion <= ob.version {
 			continue
 		}
-		topic, _ := raw["c"].(string)
-		if strings.Contains(topic, "kline") {
-			var d struct {
-				D map[string]any `json:"d"`
-			}
-			b, _ := json.Marshal(raw)
-			_ = json.Unmarshal(b, &d)
-			sym, _ := d.D["s"].(string)
-			iv, _ := d.D["i"].(string)
-			o := decimal.RequireFromString(d.D["o"].(string))
-			h := decimal.RequireFromString(d.D["h"].(string))
-			l := decimal.RequireFromString(d.D["l"].(string))
-			c := decimal.RequireFromString(d.D["c"].(string))
-			v := decimal.RequireFromString(d.D["v"].(string))
-			ts, _ := toInt64(d.D["t"])
-			k := schema.Kline{
-				Exchange:  schema.MEXC,
-				Market:    schema.SPOT,
-				Symbol:    sym,
-				Interval:  schema.Interval(iv),
-				OpenTime:  time.UnixMilli(ts),
-				CloseTime: time.UnixMilli(ts),
-				Open:      o,
-				High:      h,
-				Low:       l,
-				Close:     c,
-				Volume:    v,
-				IsFinal:   true,
-			}
-			// Note: Since DataReader interface doesn't have AppendKline method,
-			// we'll need to handle this differently or extend the interface
-			logger.Info("MEXC Spot WS 收到Kline数据: %+v", k)
-		} else if strings.Contains(topic, "depth") {
-			// Handle depth data
-			logger.Info("MEXC Spot WS 收到Depth数据: %s", topic)
+		if update.fromVersion > ob.version+1 {
+			// 快照早于缓存的增量，稍后用新的快照重试
+			logger.Warn("MEXC Spot WS %s 快照与增量不衔接: 快照version=%d, fromVersion=%d，稍后重试",
+				symbol, ob.version, update.fromVersion)
+			st.syncing = false
+			st.buffer = nil
+			st.failedAt = time.Now()
+			s.mu.Unlock()
+			return
 		}
+		applyLevels(ob.bids, update.bids)
+		applyLevels(ob.asks, update.asks)
+		ob.version = update.toVersion
+		ob.synced = true
+		lastTs = update.ts
 	}
+
+	s.orderBooks[symbol] = ob
+	delete(s.syncs, symbol)
+	s.mu.Unlock()
+
+	logger.Info("MEXC Spot WS %s 深度快照已加载: lastUpdateId=%d, 买单%d档, 卖单%d档",
+		symbol, snapshot.LastUpdateId, len(snapshot.Bids), len(snapshot.Asks))
+
+	s.publishDepth(symbol, lastTs)
 }
 
-func toFloat(v any) (float64, error) {
-	switch t := v.(type) {
-	case string:
-		return strconv.ParseFloat(t, 64)
-	case float64:
-		return t, nil
-	default:
-		return 0, fmt.Errorf("bad type")
+// publishDepth 将本地订单簿写入缓存
+func (s *SpotWS) publishDepth(symbol string, ts int64) {
+	if depth := s.buildDepthFromOrderBook(symbol, ts); depth != nil {
+		s.cache.SetDepth(*depth)
 	}
 }
 
-func toInt64(v any) (int64, error) {
-	switch t := v.(type) {
-	case string:
-		return strconv.ParseInt(t, 10, 64)
-	case float64:
-		return int64(t), nil
-	default:
-		return 0, fmt.Errorf("bad type")
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]decimal.Decimal, levels []pbDepthItem) {
+	for _, lv := range levels {
+		setLevel(side, lv.Price, lv.Quantity)
 	}
 }
 
-// handlePingMessage 检查并处理 ping 消息
-func (s *SpotWS) handlePingMessage(data json.RawMessage, conn interfaces.WSConn) bool {
-	// MEXC 使用 "ping" 字符串作为心跳（类似 OKX）
-	var pingCheck string
-	if err := json.Unmarshal(data, &pingCheck); err == nil && pingCheck == "ping" {
-		logger.Info("MEXC WS 收到 ping 消息")
-		// 发送 "pong" 响应
-		if err := conn.WriteJSON("pong"); err != nil {
-			logger.Error("MEXC WS 发送 pong 失败: %v", err)
+// applySnapshotLevels 加载 REST 快照档位 [价格, 数量]
+func applySnapshotLevels(side map[string]decimal.Decimal, levels [][]string) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
 		}
-		return true
+		setLevel(side, lv[0], lv[1])
 	}
-	return false
 }
 
-// HandlePing 处理接收到的 ping（实现接口）
-func (s *SpotWS) HandlePing(data []byte) error {
-	logger.Info("MEXC WS HandlePing called")
-
-	// 检查是否是 "ping" 字符串
-	var pingCheck string
-	if err := json.Unmarshal(data, &pingCheck); err == nil && pingCheck == "ping" {
-		// 发送 "pong" 响应
-		s.mu.RLock()
-		conn := s.conn
-		s.mu.RUnlock()
-
-		if conn == nil {
-			return fmt.Errorf("WebSocket not connected")
-		}
+func setLevel(side map[string]decimal.Decimal, priceStr, qtyStr string) {
+	price, err := decimal.NewFromString(priceStr)
+	if err != nil {
+		return
+	}
+	qty, err := decimal.NewFromString(qtyStr)
+	if err != nil {
+		return
+	}
+	// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+	key := price.String()
+	if qty.IsZero() {
+		delete(side, key)
+	} else {
+		side[key] = qty
+	}
+}
+
+// buildDepthFromOrderBook 将本地订单簿排序裁剪为 schema.Depth
+func (s *SpotWS) buildDepthFromOrderBook(symbol string, ts int64) *schema.Depth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-		return conn.WriteJSON("pong")
+	ob := s.orderBooks[symbol]
+	if ob == nil {
+		return nil
 	}
 
-	return nil
+	bids := make([]schema.PriceLevel, 0, len(ob.bids))
+	for priceStr, qty := range ob.bids {
+		price, _ := decimal.NewFromString(priceStr)
+		bids = append(bids, schema.PriceLevel{Price: price, Quantity: qty})
+	}
+	asks := make([]schema.PriceLevel, 0, len(ob.asks))
+	for priceStr, qty := range ob.asks {
+		price, _ := decimal.NewFromString(priceStr)
+		asks = append(asks, schema.PriceLevel{Price: price, Quantity: qty})
+	}
+
+	// 排序：买单降序，卖单升序
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	if len(bids) > maxDepthLevels {
+		bids = bids[:maxDepthLevels]
+	}
+	if len(asks) > maxDepthLevels {
+		asks = asks[:maxDepthLevels]
+	}
+
+	updatedAt := time.Now()
+	if ts > 0 {
+		updatedAt = time.UnixMilli(ts)
+	}
+
+	return &schema.Depth{
+		Exchange:     schema.MEXC,
+		Market:       schema.SPOT,
+		Symbol:       symbol,
+		Bids:         bids,
+		Asks:         asks,
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", ob.version),
+	}
 }
 
-// SendPing sends ping to server (if supported by exchange)
+// HandlePing 处理服务端的 ping 帧
+func (s *SpotWS) HandlePing(data []byte) error {
+	s.mu.RLock()
+	conn := s.conn
+	s.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
+
+// SendPing 发送 MEXC 心跳 {"method":"PING"}，服务端回复 msg 为 PONG 的消息
 func (s *SpotWS) SendPing(ctx context.Context) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
 
-	if conn != nil {
-		// MEXC 使用 "ping" 字符串作为心跳
-		return conn.WriteJSON("ping")
+	data, _ := json.Marshal(&mexcRequest{Method: methodPing})
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("WebSocket connection not established")
+
+	s.healthMu.Lock()
+	s.lastPing = time.Now()
+	s.healthMu.Unlock()
+	return nil
 }
 
-// StartHealthCheck starts connection health monitoring
+// StartHealthCheck 定时发送心跳，超时未收到消息则重连
 func (s *SpotWS) StartHealthCheck(ctx context.Context) error {
-	// MEXC 使用内置的 ping-pong 机制，这里不需要额外的健康检查
-	// 心跳处理已经在 StartReading 中实现
-	return nil
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("MEXC Spot WS 健康检查停止")
+			return nil
+		case <-s.ctx.Done():
+			logger.Info("MEXC Spot WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			s.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) checkConnectionHealth(ctx context.Context) {
+	if !s.isConnected() {
+		return
+	}
+
+	s.healthMu.RLock()
+	sinceMsg := time.Since(s.lastMessage)
+	sincePing := time.Since(s.lastPing)
+	s.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("MEXC Spot WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		s.reconnect(ctx)
+		return
+	}
+
+	// 数据推送不能替代心跳，MEXC 要求定期发送 ping
+	if sincePing > pingInterval {
+		if err := s.SendPing(ctx); err != nil {
+			logger.Warn("MEXC Spot WS ping失败: %v", err)
+			s.reconnect(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) reconnect(ctx context.Context) {
+	s.reconnectMu.Lock()
+	if s.reconnecting {
+		s.reconnectMu.Unlock()
+		return
+	}
+	s.reconnecting = true
+	s.reconnectMu.Unlock()
+
+	defer func() {
+		s.reconnectMu.Lock()
+		s.reconnecting = false
+		s.reconnectMu.Unlock()
+	}()
+
+	s.mu.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	// 重连后重新获取快照
+	s.orderBooks = make(map[string]*orderBook)
+	s.syncs = make(map[string]*bookSync)
+	s.mu.Unlock()
+
+	for {
+		s.reconnectMu.Lock()
+		s.reconnectCount++
+		reconnectCount := s.reconnectCount
+		s.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("MEXC Spot WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := s.Connect(ctx); err != nil {
+			logger.Error("MEXC Spot WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("MEXC Spot WS 重连成功")
+		// 补齐断线期间缺失的K线
+		s.gaps.Backfill(ctx, s.subs.GetKlineSubscriptions())
+		s.reconnectMu.Lock()
+		s.reconnectCount = 0
+		s.reconnectMu.Unlock()
+		return
+	}
 }
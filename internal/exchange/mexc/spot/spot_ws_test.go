@@ -0,0 +1,341 @@
+package spot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+// 按 MEXC proto 定义编码测试用的推送帧
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v int64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func pushFrame(channel, symbol string, sendTime int64, bodyNum protowire.Number, body []byte) []byte {
+	var b []byte
+	b = appendString(b, wrapperChannel, channel)
+	b = appendString(b, wrapperSymbol, symbol)
+	b = appendVarint(b, wrapperSendTime, sendTime)
+	return appendMessage(b, bodyNum, body)
+}
+
+func klineFrame(start int64, open, close, high, low, volume, amount string) []byte {
+	var k []byte
+	k = appendString(k, 1, "Min1")
+	k = appendVarint(k, 2, start)
+	k = appendString(k, 3, open)
+	k = appendString(k, 4, close)
+	k = appendString(k, 5, high)
+	k = appendString(k, 6, low)
+	k = appendString(k, 7, volume)
+	k = appendString(k, 8, amount)
+	k = appendVarint(k, 9, start+60)
+	return pushFrame(klineChannel("BTCUSDT", schema.Interval1m), "BTCUSDT", (start+1)*1000, wrapperPublicSpotKline, k)
+}
+
+func depthFrame(from, to string, bids, asks [][2]string) []byte {
+	item := func(lv [2]string) []byte {
+		return appendString(appendString(nil, 1, lv[0]), 2, lv[1])
+	}
+	var d []byte
+	for _, lv := range asks {
+		d = appendMessage(d, 1, item(lv))
+	}
+	for _, lv := range bids {
+		d = appendMessage(d, 2, item(lv))
+	}
+	d = appendString(d, 3, depthChannel("BTCUSDT"))
+	d = appendString(d, 4, from)
+	d = appendString(d, 5, to)
+	return pushFrame(depthChannel("BTCUSDT"), "BTCUSDT", 1700000000100, wrapperPublicAggreDepths, d)
+}
+
+func dealsFrame(deals ...pbDealItem) []byte {
+	var d []byte
+	for _, deal := range deals {
+		var item []byte
+		item = appendString(item, 1, deal.Price)
+		item = appendString(item, 2, deal.Quantity)
+		item = appendVarint(item, 3, int64(deal.TradeType))
+		item = appendVarint(item, 4, deal.Time)
+		d = appendMessage(d, 1, item)
+	}
+	d = appendString(d, 2, dealsChannel("BTCUSDT"))
+	return pushFrame(dealsChannel("BTCUSDT"), "BTCUSDT", 1700000000100, wrapperPublicAggreDeals, d)
+}
+
+func bookTickerFrame(bidPrice, bidQty, askPrice, askQty string) []byte {
+	var b []byte
+	b = appendString(b, 1, bidPrice)
+	b = appendString(b, 2, bidQty)
+	b = appendString(b, 3, askPrice)
+	b = appendString(b, 4, askQty)
+	return pushFrame(bookTickerChannel("BTCUSDT"), "BTCUSDT", 1700000000100, wrapperPublicBookTicker, b)
+}
+
+func miniTickerFrame(price, high, low, volume, quantity string) []byte {
+	var t []byte
+	t = appendString(t, 1, "BTCUSDT")
+	t = appendString(t, 2, price)
+	t = appendString(t, 3, "0.01")
+	t = appendString(t, 5, high)
+	t = appendString(t, 6, low)
+	t = appendString(t, 7, volume)
+	t = appendString(t, 8, quantity)
+	return pushFrame(miniTickerChannel("BTCUSDT"), "BTCUSDT", 1700000000100, wrapperPublicMiniTicker, t)
+}
+
+func TestDecodePushData_Kline(t *testing.T) {
+	push, err := decodePushData(klineFrame(1700000040, "100", "101", "102", "99", "1.5", "151.5"))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if push.Symbol != "BTCUSDT" || push.Channel != klineChannel("BTCUSDT", schema.Interval1m) || push.Kline == nil {
+		t.Fatalf("unexpected push: %+v", push)
+	}
+	if k := push.Kline; k.WindowStart != 1700000040 || k.WindowEnd != 1700000100 || k.ClosingPrice != "101" || k.Amount != "151.5" {
+		t.Fatalf("unexpected kline: %+v", k)
+	}
+}
+
+// readFrame 读取 testdata 下按 MEXC 官方 proto 编码的推送帧
+func readFrame(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return data
+}
+
+func TestDecodePushData_Testdata(t *testing.T) {
+	push, err := decodePushData(readFrame(t, "aggre_depth.bin"))
+	if err != nil {
+		t.Fatalf("decode depth: %v", err)
+	}
+	if push.Symbol != "BTCUSDT" || push.Channel != depthChannel("BTCUSDT") || push.SendTime != 1736411838730 || push.Depth == nil {
+		t.Fatalf("unexpected depth push: %+v", push)
+	}
+	if d := push.Depth; d.FromVersion != "10589632359" || d.ToVersion != "10589632360" || len(d.Asks) != 2 || len(d.Bids) != 1 ||
+		d.Asks[0].Price != "92877.58" || d.Asks[0].Quantity != "0.00000000" || d.Bids[0].Quantity != "0.18461200" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+
+	push, err = decodePushData(readFrame(t, "aggre_deals.bin"))
+	if err != nil {
+		t.Fatalf("decode deals: %v", err)
+	}
+	if push.Channel != dealsChannel("BTCUSDT") || push.Deals == nil || len(push.Deals.Deals) != 2 {
+		t.Fatalf("unexpected deals push: %+v", push)
+	}
+	if d := push.Deals.Deals[0]; d.Price != "93220.00" || d.Quantity != "0.04438243" || d.TradeType != 2 || d.Time != 1736409765051 {
+		t.Fatalf("unexpected deal: %+v", d)
+	}
+
+	push, err = decodePushData(readFrame(t, "kline.bin"))
+	if err != nil {
+		t.Fatalf("decode kline: %v", err)
+	}
+	if push.Channel != klineChannel("BTCUSDT", schema.Interval15m) || push.SymbolId != "2fb942154ef44a4ab2ef98c8afb6a4a7" ||
+		push.CreateTime != 1736410707571 || push.Kline == nil {
+		t.Fatalf("unexpected kline push: %+v", push)
+	}
+	if k := push.Kline; k.Interval != "Min15" || k.WindowStart != 1736410500 || k.WindowEnd != 1736411400 ||
+		k.OpeningPrice != "92925" || k.LowestPrice != "92800" || k.Volume != "36.83803224" || k.Amount != "3424811.71" {
+		t.Fatalf("unexpected kline: %+v", k)
+	}
+
+	push, err = decodePushData(readFrame(t, "aggre_book_ticker.bin"))
+	if err != nil {
+		t.Fatalf("decode book ticker: %v", err)
+	}
+	if push.Channel != bookTickerChannel("BTCUSDT") || push.BookTicker == nil {
+		t.Fatalf("unexpected book ticker push: %+v", push)
+	}
+	if b := push.BookTicker; b.BidPrice != "93387.28" || b.BidQuantity != "3.73485" || b.AskPrice != "93387.29" || b.AskQuantity != "7.669875" {
+		t.Fatalf("unexpected book ticker: %+v", b)
+	}
+}
+
+func TestHandleKline(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleBinaryMessage(klineFrame(1700000040, "100", "101", "102", "99", "1.5", "151.5"))
+
+	kl, ok := c.GetKline(schema.MEXC, schema.SPOT, "BTCUSDT", schema.Interval1m)
+	if !ok || len(kl) != 1 {
+		t.Fatalf("kline not cached")
+	}
+	k := kl[0]
+	if !k.OpenTime.Equal(time.Unix(1700000040, 0)) || !k.CloseTime.Equal(time.Unix(1700000100, 0).Add(-time.Millisecond)) ||
+		k.Volume.String() != "1.5" || k.QuoteVolume.String() != "151.5" || k.IsFinal {
+		t.Fatalf("unexpected kline: %+v", k)
+	}
+}
+
+func TestHandleDepth_SnapshotSync(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("unexpected symbol: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"lastUpdateId":100,"bids":[["99","1"]],"asks":[["101","1"]],"timestamp":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	rest := NewSpotREST()
+	rest.http.SetBaseURL(srv.URL)
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), rest)
+
+	// 快照前的增量被缓存，快照加载后丢弃 toVersion <= lastUpdateId 的部分并回放其余增量
+	s.handleBinaryMessage(depthFrame("95", "100", [][2]string{{"98", "5"}}, nil))
+	s.handleBinaryMessage(depthFrame("101", "102", [][2]string{{"99.50", "2"}}, nil))
+
+	deadline := time.Now().Add(2 * time.Second)
+	var d schema.Depth
+	for time.Now().Before(deadline) {
+		var ok bool
+		if d, ok = c.GetDepth(schema.MEXC, schema.SPOT, "BTCUSDT"); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if d.LastUpdateId != "102" || len(d.Bids) != 2 || d.Bids[0].Price.String() != "99.5" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+
+	// 同步完成后增量直接应用
+	s.handleBinaryMessage(depthFrame("103", "104", nil, [][2]string{{"101", "0"}, {"100.5", "3"}}))
+	d, _ = c.GetDepth(schema.MEXC, schema.SPOT, "BTCUSDT")
+	if d.LastUpdateId != "104" || len(d.Asks) != 1 || d.Asks[0].Price.String() != "100.5" {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+
+	// 版本不连续时丢弃本地订单簿并重新同步
+	srv.Close()
+	s.handleBinaryMessage(depthFrame("110", "111", [][2]string{{"98", "1"}}, nil))
+	s.mu.RLock()
+	_, ok := s.orderBooks["BTCUSDT"]
+	s.mu.RUnlock()
+	if ok {
+		t.Fatalf("order book should be dropped after version gap")
+	}
+}
+
+func TestHandleDepth_SnapshotInsideLiveUpdate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"lastUpdateId":10589632359,"bids":[["92876.01","1"]],"asks":[["92877.58","1"]],"timestamp":1736411838700}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	rest := NewSpotREST()
+	rest.http.SetBaseURL(srv.URL)
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), rest)
+
+	// 缓存的增量全部早于快照，快照加载后没有回放任何增量
+	s.handleBinaryMessage(depthFrame("10589632350", "10589632355", [][2]string{{"92870", "5"}}, nil))
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := c.GetDepth(schema.MEXC, schema.SPOT, "BTCUSDT"); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 同步后的第一条实时增量跨越快照版本（from=10589632359 <= 10589632360 <= to=10589632360），应直接应用
+	s.handleBinaryMessage(readFrame(t, "aggre_depth.bin"))
+	d, _ := c.GetDepth(schema.MEXC, schema.SPOT, "BTCUSDT")
+	if d.LastUpdateId != "10589632360" || len(d.Asks) != 1 || d.Asks[0].Price.String() != "92879.16" || d.Bids[0].Quantity.String() != "0.184612" {
+		t.Fatalf("straddling update not applied: %+v", d)
+	}
+
+	// 之后的增量要求严格连续
+	s.handleBinaryMessage(depthFrame("10589632361", "10589632362", [][2]string{{"92875", "1"}}, nil))
+	d, _ = c.GetDepth(schema.MEXC, schema.SPOT, "BTCUSDT")
+	if d.LastUpdateId != "10589632362" || len(d.Bids) != 2 {
+		t.Fatalf("unexpected depth after update: %+v", d)
+	}
+	s.handleBinaryMessage(depthFrame("10589632360", "10589632364", nil, nil))
+	s.mu.RLock()
+	_, resyncing := s.syncs["BTCUSDT"]
+	s.mu.RUnlock()
+	if !resyncing {
+		t.Fatalf("overlapping update after sync should trigger resync")
+	}
+}
+
+func TestHandleDeals(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleBinaryMessage(dealsFrame(
+		pbDealItem{Price: "100", Quantity: "0.5", TradeType: 1, Time: 1700000000001},
+		pbDealItem{Price: "99.9", Quantity: "2", TradeType: 2, Time: 1700000000002},
+	))
+
+	trades, ok := c.GetTrades(schema.MEXC, schema.SPOT, "BTCUSDT", 10)
+	if !ok || len(trades) != 2 {
+		t.Fatalf("trades not cached: %+v", trades)
+	}
+	if trades[0].Side != schema.OrderSideBuy || trades[0].QuoteQty.String() != "50" {
+		t.Fatalf("unexpected first trade: %+v", trades[0])
+	}
+	if trades[1].Side != schema.OrderSideSell || trades[1].Timestamp.UnixMilli() != 1700000000002 {
+		t.Fatalf("unexpected second trade: %+v", trades[1])
+	}
+}
+
+func TestHandleBookTicker(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleBinaryMessage(bookTickerFrame("99.9", "1.5", "100.1", "2"))
+
+	bt, ok := c.GetBookTicker(schema.MEXC, schema.SPOT, "BTCUSDT")
+	if !ok {
+		t.Fatalf("book ticker not cached")
+	}
+	if bt.BidPrice.String() != "99.9" || bt.BidQty.String() != "1.5" || bt.AskPrice.String() != "100.1" || bt.AskQty.String() != "2" ||
+		bt.UpdatedAt.UnixMilli() != 1700000000100 {
+		t.Fatalf("unexpected book ticker: %+v", bt)
+	}
+}
+
+func TestHandleTicker(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleBinaryMessage(miniTickerFrame("101", "102", "98", "3030", "30"))
+
+	tk, ok := c.GetTicker(schema.MEXC, schema.SPOT, "BTCUSDT")
+	if !ok {
+		t.Fatalf("ticker not cached")
+	}
+	if tk.Price.String() != "101" || tk.High.String() != "102" || tk.Low.String() != "98" ||
+		tk.Volume.String() != "30" || tk.QuoteVol.String() != "3030" || tk.Timestamp.UnixMilli() != 1700000000100 {
+		t.Fatalf("unexpected ticker: %+v", tk)
+	}
+}
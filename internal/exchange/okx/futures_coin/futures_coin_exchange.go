@@ -6,16 +6,18 @@ import (
 	"github.com/kingsmao/exchange-connector/pkg/schema"
 )
 
-// FuturesCoinExchange bundles REST and WS for OKX Coin-margined Futures.
+// FuturesCoinExchange bundles REST and WS for OKX coin-margined Futures.
 type FuturesCoinExchange struct {
 	rest *FuturesCoinREST
 	ws   *FuturesCoinWS
 }
 
 func NewFuturesCoinExchange(c *cache.MemoryCache) *FuturesCoinExchange {
+	subs := cache.NewSubscriptionManager()
+	rest := NewFuturesCoinREST()
 	return &FuturesCoinExchange{
-		rest: NewFuturesCoinREST(),
-		ws:   NewFuturesCoinWS(c),
+		rest: rest,
+		ws:   NewFuturesCoinWS(c, subs, rest),
 	}
 }
 
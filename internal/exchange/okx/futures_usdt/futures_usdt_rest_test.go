@@ -0,0 +1,47 @@
+//go:build integration
+
+package futures_usdt
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+func TestOKXFuturesUSDTREST_Depth(t *testing.T) {
+	r := NewFuturesUSDTREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	d, err := r.GetDepth(ctx, "BTC-USDT-SWAP", 5)
+	if err != nil {
+		t.Fatalf("depth error: %v", err)
+	}
+	if len(d.Bids) == 0 || len(d.Asks) == 0 {
+		t.Fatalf("empty orderbook")
+	}
+	log.Printf("OKX Futures USDT REST Depth: Bids=%d, Asks=%d, 最佳买价=%v(%v BTC), 最佳卖价=%v(%v BTC)",
+		len(d.Bids), len(d.Asks), d.Bids[0].Price, d.Bids[0].Quantity, d.Asks[0].Price, d.Asks[0].Quantity)
+}
+
+func TestOKXFuturesUSDTREST_ExchangeInfo(t *testing.T) {
+	r := NewFuturesUSDTREST()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	info, err := r.GetExchangeInfo(ctx)
+	if err != nil {
+		t.Fatalf("exchange info error: %v", err)
+	}
+	var btc *schema.Symbol
+	for i := range info.Symbols {
+		if info.Symbols[i].Symbol == "BTC-USDT-SWAP" {
+			btc = &info.Symbols[i]
+		}
+	}
+	if btc == nil || btc.TickSize == "" || btc.StepSize == "" || btc.MinNotional == "" || !btc.IsTrading() {
+		t.Fatalf("unexpected BTC-USDT-SWAP: %+v", btc)
+	}
+	log.Printf("OKX Futures USDT ExchangeInfo: 合约数=%d, BTC-USDT-SWAP=%+v", len(info.Symbols), *btc)
+}
@@ -14,9 +14,10 @@ type SpotExchange struct {
 
 func NewSpotExchange(c *cache.MemoryCache) *SpotExchange {
 	subs := cache.NewSubscriptionManager()
+	rest := NewSpotREST()
 	return &SpotExchange{
-		rest: NewSpotREST(),
-		ws:   NewSpotWS(c, subs),
+		rest: rest,
+		ws:   NewSpotWS(c, subs, rest),
 	}
 }
 
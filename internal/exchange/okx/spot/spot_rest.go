@@ -16,9 +16,14 @@ import (
 )
 
 const (
-	okxBaseURL         = "https://www.okx.com"
-	apiV5MarketCandles = "/api/v5/market/candles"
-	apiV5MarketBooks   = "/api/v5/market/books"
+	okxBaseURL            = "https://www.okx.com"
+	apiV5MarketTicker     = "/api/v5/market/ticker"
+	apiV5MarketTickers    = "/api/v5/market/tickers"
+	apiV5MarketCandles    = "/api/v5/market/candles"
+	apiV5MarketBooks      = "/api/v5/market/books"
+	apiV5HistoryCandles   = "/api/v5/market/history-candles"
+	apiV5PublicInstrument = "/api/v5/public/instruments"
+	apiV5PublicTime       = "/api/v5/public/time"
 )
 
 type SpotREST struct{ http *resty.Client }
@@ -100,6 +105,169 @@ func (o *SpotREST) GetKline(ctx context.Context, symbol string, interval schema.
 	return out, nil
 }
 
+// maxKlinesPerRequest OKX Spot 历史K线接口单次最多返回的条数
+const maxKlinesPerRequest = 100
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，接口按时间倒序返回，使用 after 游标向前翻页，end 为零值时取到当前时间
+func (o *SpotREST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	after := end.UnixMilli() + 1
+	for {
+		rows, err := o.historyCandles(ctx, symbol, interval, after)
+		if err != nil {
+			return nil, err
+		}
+		page := convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+		if len(rows) < maxKlinesPerRequest || len(page) == 0 {
+			break
+		}
+
+		// 以本页最早一根的开盘时间作为下一页的游标
+		oldest := page[len(page)-1].OpenTime.UnixMilli()
+		if oldest <= start.UnixMilli() || oldest >= after {
+			break
+		}
+		after = oldest
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// historyCandles 拉取开盘时间早于 after 的一页K线
+func (o *SpotREST) historyCandles(ctx context.Context, symbol string, interval schema.Interval, after int64) ([][]string, error) {
+	var resp schema.OKXKlineResponse
+	r, err := o.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"instId": symbol,
+		"bar":    klineIntervals[interval],
+		"after":  strconv.FormatInt(after, 10),
+		"limit":  strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV5HistoryCandles)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+
+	logger.Debug("OKX Spot Kline 返回 %d 条", len(resp.Data))
+	return resp.Data, nil
+}
+
+// convertKlines 转换K线数组，现货 vol 为基础币数量，volCcyQuote 为计价币数量
+func convertKlines(rows [][]string, symbol string, interval schema.Interval) []schema.Kline {
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		open, _ := decimal.NewFromString(row[1])
+		high, _ := decimal.NewFromString(row[2])
+		low, _ := decimal.NewFromString(row[3])
+		close, _ := decimal.NewFromString(row[4])
+		volume, _ := decimal.NewFromString(row[5])
+		quoteVolume, _ := decimal.NewFromString(row[7])
+		openTime := time.UnixMilli(ts)
+		out = append(out, schema.Kline{
+			Exchange:    schema.OKX,
+			Market:      schema.SPOT,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row[8] == "1",
+		})
+	}
+	return out
+}
+
+// GetTicker 获取单个币对的24小时行情
+func (o *SpotREST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	data, err := o.tickers(ctx, apiV5MarketTicker, map[string]string{"instId": okxSymbol(symbol)})
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	if len(data) == 0 {
+		return schema.Ticker{}, errors.New("no ticker data")
+	}
+	return convertTicker(data[0]), nil
+}
+
+// GetTickers 获取全部币对的24小时行情
+func (o *SpotREST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	data, err := o.tickers(ctx, apiV5MarketTickers, map[string]string{"instType": "SPOT"})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.Ticker, 0, len(data))
+	for _, t := range data {
+		out = append(out, convertTicker(t))
+	}
+	return out, nil
+}
+
+func (o *SpotREST) tickers(ctx context.Context, path string, params map[string]string) ([]schema.OKXTicker, error) {
+	var resp schema.OKXTickerResponse
+	r, err := o.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(params).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+
+	logger.Debug("OKX Spot Ticker 原始响应: %s", string(r.Body()))
+	return resp.Data, nil
+}
+
+func convertTicker(t schema.OKXTicker) schema.Ticker {
+	price, _ := decimal.NewFromString(t.Last)
+	open, _ := decimal.NewFromString(t.Open24h)
+	high, _ := decimal.NewFromString(t.High24h)
+	low, _ := decimal.NewFromString(t.Low24h)
+	// 现货 vol24h 为基础币成交量，volCcy24h 为计价币成交额
+	volume, _ := decimal.NewFromString(t.Vol24h)
+	quoteVolume, _ := decimal.NewFromString(t.VolCcy24h)
+	ts, _ := strconv.ParseInt(t.Ts, 10, 64)
+
+	return schema.Ticker{
+		Exchange:  schema.OKX,
+		Market:    schema.SPOT,
+		Symbol:    t.InstID,
+		Price:     price,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Volume:    volume,
+		QuoteVol:  quoteVolume,
+		Timestamp: time.UnixMilli(ts),
+	}
+}
+
 func (o *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
 	var resp struct {
 		Code string `json:"code"`
@@ -157,15 +325,126 @@ func (o *SpotREST) GetDepth(ctx context.Context, symbol string, limit int) (sche
 	}, nil
 }
 
+// okxInstrument 现货交易产品基础信息
+type okxInstrument struct {
+	InstId   string `json:"instId"`
+	BaseCcy  string `json:"baseCcy"`
+	QuoteCcy string `json:"quoteCcy"`
+	TickSz   string `json:"tickSz"`
+	LotSz    string `json:"lotSz"`
+	MinSz    string `json:"minSz"`
+	MaxLmtSz string `json:"maxLmtSz"`
+	State    string `json:"state"` // live / suspend / preopen / test
+}
+
+// GetExchangeInfo 获取现货交易规则，OKX 不提供最小下单金额
 func (o *SpotREST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
-	// 暂未实现OKX交易规则信息获取
+	var resp struct {
+		Code string          `json:"code"`
+		Msg  string          `json:"msg"`
+		Data []okxInstrument `json:"data"`
+	}
+	r, err := o.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("instType", "SPOT").Get(apiV5PublicInstrument)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	if r.IsError() {
+		return schema.ExchangeInfo{}, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return schema.ExchangeInfo{}, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+	logger.Debug("OKX Spot ExchangeInfo 原始响应长度: %d bytes", len(r.Body()))
+
+	serverTime, err := o.serverTime(ctx)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+
+	symbols := make([]schema.Symbol, 0, len(resp.Data))
+	for _, inst := range resp.Data {
+		status, ok := symbolStatus(inst.State)
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       inst.InstId,
+			Base:         inst.BaseCcy,
+			Quote:        inst.QuoteCcy,
+			ExchangeName: schema.OKX,
+			MarketType:   schema.SPOT,
+
+			QuantityPrecision: decimalPlaces(inst.LotSz),
+			PricePrecision:    decimalPlaces(inst.TickSz),
+			MinQuantity:       inst.MinSz,
+			MaxQuantity:       inst.MaxLmtSz,
+			TickSize:          inst.TickSz,
+			StepSize:          inst.LotSz,
+
+			Status: status,
+		})
+	}
+
+	logger.Info("OKX Spot 交易规则已加载: %d 个交易对", len(symbols))
 	return schema.ExchangeInfo{
 		Exchange:   schema.OKX,
 		Market:     schema.SPOT,
-		Symbols:    []schema.Symbol{},
+		Symbols:    symbols,
 		UpdatedAt:  time.Now(),
-		ServerTime: time.Now(),
+		ServerTime: serverTime,
 		RateLimits: []schema.RateLimit{},
 		Timezone:   "UTC",
 	}, nil
 }
+
+// serverTime 获取 OKX 服务器时间
+func (o *SpotREST) serverTime(ctx context.Context) (time.Time, error) {
+	var resp struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			Ts string `json:"ts"`
+		} `json:"data"`
+	}
+	r, err := o.http.R().SetContext(ctx).SetResult(&resp).Get(apiV5PublicTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if r.IsError() {
+		return time.Time{}, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return time.Time{}, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+	if len(resp.Data) == 0 {
+		return time.Time{}, errors.New("okx server time: empty data")
+	}
+	ts, err := strconv.ParseInt(resp.Data[0].Ts, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("okx server time: %w", err)
+	}
+	return time.UnixMilli(ts), nil
+}
+
+// symbolStatus 归一化产品状态，测试产品（test）返回 false
+func symbolStatus(state string) (schema.SymbolStatus, bool) {
+	switch state {
+	case "live":
+		return schema.SymbolStatusTrading, true
+	case "preopen":
+		return schema.SymbolStatusPreTrading, true
+	case "suspend":
+		return schema.SymbolStatusHalt, true
+	default:
+		return "", false
+	}
+}
+
+// decimalPlaces 根据步长计算小数位数，如 "0.001" -> 3
+func decimalPlaces(step string) int {
+	d, err := decimal.NewFromString(step)
+	if err != nil || d.Exponent() >= 0 {
+		return 0
+	}
+	return int(-d.Exponent())
+}
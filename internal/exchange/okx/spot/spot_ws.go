@@ -2,445 +2,1034 @@ package spot
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/crc32"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
 	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
 	"github.com/kingsmao/exchange-connector/pkg/logger"
 	"github.com/kingsmao/exchange-connector/pkg/schema"
-
-	"github.com/shopspring/decimal"
 )
 
 const (
 	wsURL = "wss://ws.okx.com:8443/ws/v5/public"
+
+	channelKlinePrefix = "candle" // candle1m、candle1H 等，周期见 klineIntervals
+	channelTrade       = "trades"
+	channelBBO         = "bbo-tbt" // 最优一档，逐笔推送
+	channelTicker      = "tickers" // 24小时行情，最快100ms推送一次
+	channelDepth       = "books"   // 首次推送400档全量，之后增量推送
+
+	// OKX 30秒内没有数据会断开连接，空闲超过pingInterval主动发送 "ping"
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
+
+	// 校验和取买卖各前25档
+	checksumLevels = 25
 )
 
+// klineIntervals schema.Interval 到 OKX K线周期的映射，小时和天使用大写单位，日线使用 UTC 零点对齐的 1Dutc
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1m",
+	schema.Interval3m:  "3m",
+	schema.Interval5m:  "5m",
+	schema.Interval15m: "15m",
+	schema.Interval30m: "30m",
+	schema.Interval1h:  "1H",
+	schema.Interval4h:  "4H",
+	schema.Interval1d:  "1Dutc",
+}
+
+type okxArg struct {
+	Channel string `json:"channel"`
+	InstId  string `json:"instId"`
+}
+
+// okxTradeData trades 频道推送数据
+type okxTradeData struct {
+	InstId  string `json:"instId"`
+	TradeId string `json:"tradeId"`
+	Px      string `json:"px"`
+	Sz      string `json:"sz"`
+	Side    string `json:"side"` // 主动成交方向 buy/sell
+	Ts      string `json:"ts"`
+}
+
+// okxBBOData bbo-tbt 频道推送数据，档位格式 [价格, 数量, 0, 订单数]
+type okxBBOData struct {
+	Asks  [][]string `json:"asks"`
+	Bids  [][]string `json:"bids"`
+	Ts    string     `json:"ts"`
+	SeqId int64      `json:"seqId"`
+}
+
+type okxSubscriptionMessage struct {
+	Op   string   `json:"op"`
+	Args []okxArg `json:"args"`
+}
+
+// okxBookData 是 books 频道单条推送数据
+type okxBookData struct {
+	Asks      [][]string `json:"asks"` // [价格, 数量, 废弃字段, 订单数]
+	Bids      [][]string `json:"bids"`
+	Ts        string     `json:"ts"`
+	Checksum  int64      `json:"checksum"`
+	PrevSeqId int64      `json:"prevSeqId"`
+	SeqId     int64      `json:"seqId"`
+}
+
+// bookLevel 保留交易所原始字符串，校验和必须使用推送中的原始格式计算
+type bookLevel struct {
+	price decimal.Decimal
+	qty   decimal.Decimal
+	rawPx string
+	rawSz string
+}
+
+// orderBook 本地订单簿，key 为规范化后的价格
+type orderBook struct {
+	seqId int64
+	bids  map[string]bookLevel
+	asks  map[string]bookLevel
+}
+
 type SpotWS struct {
-	conn  interfaces.WSConn
-	mu    sync.RWMutex
-	cache *cache.MemoryCache
-	subs  interfaces.SubscriptionManager
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+
+	// per-instId local order books
+	orderBooks map[string]*orderBook
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
 }
 
-func NewSpotWS(cache *cache.MemoryCache, subs interfaces.SubscriptionManager) *SpotWS {
+func NewSpotWS(c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *SpotREST) *SpotWS {
+	d := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &SpotWS{
-		cache: cache,
-		subs:  subs,
+		dialer:     d,
+		cache:      c,
+		gaps:       cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:       subs,
+		orderBooks: make(map[string]*orderBook),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 }
 
 func (s *SpotWS) Connect(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.conn != nil {
+		s.mu.Unlock()
 		logger.Info("OKX Spot WS 已连接，跳过连接")
 		return nil
 	}
 
 	logger.Info("OKX Spot WS 开始连接...")
+	conn, _, err := s.dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		s.mu.Unlock()
+		logger.Error("OKX Spot WS 连接失败: %v", err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024) // books 首次推送400档全量
+	s.conn = conn
+	s.mu.Unlock()
 
-	// 这里应该实现实际的WebSocket连接逻辑
-	// 由于没有具体的连接实现，我们只是记录日志
-	logger.Info("OKX Spot WS 连接成功")
+	now := time.Now()
+	s.healthMu.Lock()
+	s.lastMessage = now
+	s.lastPing = now
+	s.healthMu.Unlock()
 
-	// 启动消息读取
-	go s.StartReading(ctx)
+	logger.Info("OKX Spot WS 连接成功")
 
+	// 重连时自动恢复订阅
+	_ = s.applySubscriptions(ctx)
 	return nil
 }
 
 func (s *SpotWS) Close() error {
+	s.cancel()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.conn != nil {
-		return s.conn.Close()
+		err := s.conn.Close()
+		s.conn = nil
+		return err
 	}
 	return nil
 }
 
-func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
 	}
 
-	// 固定订阅1m K线数据
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+	newlyAdded := s.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
 	if len(newlyAdded) == 0 {
 		logger.Info("OKX Spot WS 所有币对都已订阅 kline，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("OKX Spot WS 新增订阅 kline: %v (固定1m)", newlyAdded)
+	logger.Info("OKX Spot WS 新增订阅 kline: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("OKX Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.SendMessage(ctx, buildKlineMessage("subscribe", newlyAdded))
+}
+
+// UnsubscribeKline 只退订K线频道的指定周期，未指定周期时退订全部周期
+func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("OKX Spot WS 所有币对都未订阅 kline，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("OKX Spot WS 退订 kline: %v", removed)
+	s.gaps.Forget(removed)
 
-	// 构建并发送 kline 订阅消息（固定1m）
-	return s.sendKlineSubscription(conn, newlyAdded, "1m")
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.SendMessage(ctx, buildKlineMessage("unsubscribe", removed))
 }
 
-func (s *SpotWS) UnsubscribeKline(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("OKX Spot WS 所有币对都已订阅 depth，跳过订阅请求")
+		return nil
 	}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("OKX Spot WS 所有币对都已退订 kline，跳过退订请求")
+	logger.Info("OKX Spot WS 新增订阅 depth: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.SendMessage(ctx, buildMessage("subscribe", channelDepth, newlyAdded))
+}
 
-	logger.Info("OKX Spot WS 退订 kline: %v", actuallyRemoved)
+func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
+	return s.unsubscribe(ctx, symbols, "depth")
+}
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
-		logger.Warn("OKX Spot WS 未连接，退订状态已保存，连接后将自动应用")
+func (s *SpotWS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("OKX Spot WS 所有币对都已订阅 trades，跳过订阅请求")
 		return nil
 	}
-	return s.sendKlineUnsubscription(conn, actuallyRemoved)
+
+	logger.Info("OKX Spot WS 新增订阅 trades: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.SendMessage(ctx, buildMessage("subscribe", channelTrade, newlyAdded))
 }
 
-func (s *SpotWS) SubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("OKX Spot WS 所有币对都未订阅 trades，跳过退订请求")
+		return nil
+	}
+
+	logger.Info("OKX Spot WS 退订 trades: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
 	}
+	return s.SendMessage(ctx, buildMessage("unsubscribe", channelTrade, removed))
+}
 
-	// Subscribe only depth data
-	newlyAdded := s.subs.SubscribeSymbols(upperSymbols)
+func (s *SpotWS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
 	if len(newlyAdded) == 0 {
-		logger.Info("OKX Spot WS 所有币对都已订阅 depth，跳过订阅请求")
+		logger.Info("OKX Spot WS 所有币对都已订阅 bbo，跳过订阅请求")
 		return nil
 	}
 
-	logger.Info("OKX Spot WS 新增订阅 depth: %v", newlyAdded)
+	logger.Info("OKX Spot WS 新增订阅 bbo: %v", newlyAdded)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
 		logger.Warn("OKX Spot WS 未连接，订阅状态已保存，连接后将自动应用")
 		return nil
 	}
+	return s.SendMessage(ctx, buildMessage("subscribe", channelBBO, newlyAdded))
+}
+
+func (s *SpotWS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("OKX Spot WS 所有币对都未订阅 bbo，跳过退订请求")
+		return nil
+	}
 
-	// 构建并发送 depth 订阅消息
-	return s.sendDepthSubscription(conn, newlyAdded)
+	logger.Info("OKX Spot WS 退订 bbo: %v", removed)
+
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.SendMessage(ctx, buildMessage("unsubscribe", channelBBO, removed))
 }
 
-func (s *SpotWS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
-	// Convert symbols to uppercase for consistency
-	upperSymbols := make([]string, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+func (s *SpotWS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := s.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("OKX Spot WS 所有币对都已订阅 tickers，跳过订阅请求")
+		return nil
+	}
+
+	logger.Info("OKX Spot WS 新增订阅 tickers: %v", newlyAdded)
+
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，订阅状态已保存，连接后将自动应用")
+		return nil
 	}
+	return s.SendMessage(ctx, buildMessage("subscribe", channelTicker, newlyAdded))
+}
 
-	actuallyRemoved := s.subs.UnsubscribeSymbols(upperSymbols)
-	if len(actuallyRemoved) == 0 {
-		logger.Info("OKX Spot WS 所有币对都已退订 depth，跳过退订请求")
+func (s *SpotWS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := s.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("OKX Spot WS 所有币对都未订阅 tickers，跳过退订请求")
 		return nil
 	}
 
-	logger.Info("OKX Spot WS 退订 depth: %v", actuallyRemoved)
+	logger.Info("OKX Spot WS 退订 tickers: %v", removed)
 
-	s.mu.RLock()
-	conn := s.conn
-	s.mu.RUnlock()
-	if conn == nil {
+	if !s.isConnected() {
+		logger.Warn("OKX Spot WS 未连接，退订状态已保存，连接后将自动应用")
+		return nil
+	}
+	return s.SendMessage(ctx, buildMessage("unsubscribe", channelTicker, removed))
+}
+
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 depth 频道
+func (s *SpotWS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := s.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(s.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("OKX Spot WS 所有币对都未订阅 %s，跳过退订请求", kind)
+		return nil
+	}
+
+	logger.Info("OKX Spot WS 退订 %s: %v", kind, removed)
+
+	s.mu.Lock()
+	for _, instId := range removed {
+		delete(s.orderBooks, instId)
+	}
+	s.mu.Unlock()
+
+	if !s.isConnected() {
 		logger.Warn("OKX Spot WS 未连接，退订状态已保存，连接后将自动应用")
 		return nil
 	}
-	return s.sendDepthUnsubscription(conn, actuallyRemoved)
+
+	msg := buildKlineMessage("unsubscribe", klines)
+	msg.Args = append(msg.Args, buildMessage("unsubscribe", channelDepth, removed).Args...)
+	return s.SendMessage(ctx, msg)
 }
 
 func (s *SpotWS) SendMessage(ctx context.Context, message interface{}) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
-
 	if conn == nil {
 		return fmt.Errorf("WebSocket connection not established")
 	}
 
-	// Convert message to JSON for logging
-	if jsonData, err := json.Marshal(message); err == nil {
-		logger.Info("OKX Spot WS SendMessage: %s", string(jsonData))
-	}
-	return conn.WriteJSON(message)
-}
-
-func (s *SpotWS) sendKlineSubscription(conn interfaces.WSConn, symbols []string, interval schema.Interval) error {
-	var args []map[string]string
-	for _, inst := range symbols {
-		bar := map[schema.Interval]string{
-			schema.Interval1m:  "1m",
-			schema.Interval3m:  "3m",
-			schema.Interval5m:  "5m",
-			schema.Interval15m: "15m",
-			schema.Interval30m: "30m",
-			schema.Interval1h:  "1H",
-			schema.Interval4h:  "4H",
-			schema.Interval1d:  "1D",
-		}[interval]
-		if bar == "" {
-			bar = "1m"
-		}
-		// OKX 的 kline channel 格式是 "candle" + interval
-		channelName := "candle" + bar
-		args = append(args, map[string]string{"channel": channelName, "instId": inst})
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("OKX Spot WS 序列化消息失败: %v", err)
+		return err
+	}
+
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	s.writeMu.Unlock()
+	if err != nil {
+		logger.Error("OKX Spot WS 发送消息失败: %v", err)
+		return err
 	}
-	if len(args) == 0 {
+
+	logger.Info("OKX Spot WS SendMessage: %s", string(data))
+	return nil
+}
+
+func (s *SpotWS) applySubscriptions(ctx context.Context) error {
+	msg := buildKlineMessage("subscribe", s.subs.GetKlineSubscriptions())
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelDepth, s.subs.GetDepthSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelTrade, s.subs.GetTradeSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelBBO, s.subs.GetBookTickerSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelTicker, s.subs.GetTickerSymbols()).Args...)
+	if len(msg.Args) == 0 {
+		logger.Info("OKX Spot WS 无订阅")
 		return nil
 	}
-	msg := map[string]any{"op": "subscribe", "args": args}
-	logger.Info("OKX WS 订阅消息: %+v", msg)
-	return conn.WriteJSON(msg)
+	return s.SendMessage(ctx, msg)
 }
 
-func (s *SpotWS) sendKlineUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []map[string]string
-	for _, inst := range symbols {
-		// Use default interval for unsubscribe
-		args = append(args, map[string]string{"channel": "candle1m", "instId": inst})
+// resubscribeDepth 丢弃本地订单簿并重新订阅 books 频道，服务端会重新推送全量快照
+func (s *SpotWS) resubscribeDepth(instId string) {
+	s.mu.Lock()
+	delete(s.orderBooks, instId)
+	s.mu.Unlock()
+
+	if err := s.SendMessage(s.ctx, buildMessage("unsubscribe", channelDepth, []string{instId})); err != nil {
+		logger.Error("OKX Spot WS 退订深度失败 %s: %v", instId, err)
+		return
 	}
-	if len(args) == 0 {
-		return nil
+	if err := s.SendMessage(s.ctx, buildMessage("subscribe", channelDepth, []string{instId})); err != nil {
+		logger.Error("OKX Spot WS 重新订阅深度失败 %s: %v", instId, err)
 	}
-	msg := map[string]any{"op": "unsubscribe", "args": args}
-	logger.Info("OKX WS 退订消息: %+v", msg)
-	return conn.WriteJSON(msg)
 }
 
-func (s *SpotWS) sendDepthSubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []map[string]string
-	for _, inst := range symbols {
-		args = append(args, map[string]string{"channel": "books", "instId": inst})
+// buildKlineMessage 按币对和周期构建K线订阅/退订消息
+func buildKlineMessage(op string, subs []schema.KlineSubscription) *okxSubscriptionMessage {
+	msg := &okxSubscriptionMessage{Op: op}
+	for _, sub := range subs {
+		msg.Args = append(msg.Args, okxArg{Channel: channelKlinePrefix + klineIntervals[sub.Interval], InstId: sub.Symbol})
 	}
-	if len(args) == 0 {
-		return nil
+	return msg
+}
+
+// klineIntervalOf 由K线频道名解析周期，如 candle1H -> 1h
+func klineIntervalOf(channel string) (schema.Interval, bool) {
+	bar := strings.TrimPrefix(channel, channelKlinePrefix)
+	for interval, v := range klineIntervals {
+		if v == bar {
+			return interval, true
+		}
 	}
-	msg := map[string]any{"op": "subscribe", "args": args}
-	logger.Info("OKX WS 订阅深度消息: %+v", msg)
-	return conn.WriteJSON(msg)
+	return "", false
 }
 
-func (s *SpotWS) sendDepthUnsubscription(conn interfaces.WSConn, symbols []string) error {
-	var args []map[string]string
-	for _, inst := range symbols {
-		args = append(args, map[string]string{"channel": "books", "instId": inst})
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for okx", interval)
+		}
 	}
-	if len(args) == 0 {
-		return nil
+	return nil
+}
+
+func buildMessage(op, channel string, instIds []string) *okxSubscriptionMessage {
+	msg := &okxSubscriptionMessage{Op: op}
+	for _, instId := range instIds {
+		msg.Args = append(msg.Args, okxArg{Channel: channel, InstId: instId})
+	}
+	return msg
+}
+
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
 	}
-	msg := map[string]any{"op": "unsubscribe", "args": args}
-	logger.Info("OKX WS 退订深度消息: %+v", msg)
-	return conn.WriteJSON(msg)
+	return out
 }
 
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (s *SpotWS) isConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
 func (s *SpotWS) StartReading(ctx context.Context) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
+	logger.Info("OKX Spot WS 开始读取消息...")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("OKX Spot WS 上下文取消")
+				return
+			case <-s.ctx.Done():
+				logger.Info("OKX Spot WS 已关闭")
+				return
+			default:
+			}
+
+			s.mu.RLock()
+			conn := s.conn
+			s.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if s.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("OKX Spot WS 读取消息失败: %v", err)
+				s.reconnect(ctx)
+				continue
+			}
+
+			s.healthMu.Lock()
+			s.lastMessage = time.Now()
+			s.healthMu.Unlock()
+
+			s.handleRawMessage(message)
 		}
-		s.mu.RLock()
-		conn := s.conn
-		s.mu.RUnlock()
-		if conn == nil {
-			time.Sleep(200 * time.Millisecond)
-			continue
+	}()
+
+	return nil
+}
+
+func (s *SpotWS) handleRawMessage(message []byte) {
+	// OKX 心跳响应为纯文本 "pong"
+	if string(message) == "pong" {
+		logger.Debug("OKX Spot WS 收到 pong")
+		return
+	}
+
+	logger.Debug("OKX Spot WS 收到原始消息: %s", string(message))
+
+	var msg struct {
+		Event  string          `json:"event"`
+		Code   string          `json:"code"`
+		Msg    string          `json:"msg"`
+		Action string          `json:"action"`
+		Arg    okxArg          `json:"arg"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("OKX Spot WS 解析原始消息失败: %v", err)
+		return
+	}
+
+	switch msg.Event {
+	case "":
+	case "error":
+		logger.Error("OKX Spot WS 订阅错误: code=%s, msg=%s", msg.Code, msg.Msg)
+		return
+	default:
+		logger.Info("OKX Spot WS 收到事件: %s %s %s", msg.Event, msg.Arg.Channel, msg.Arg.InstId)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(msg.Arg.Channel, channelKlinePrefix):
+		interval, ok := klineIntervalOf(msg.Arg.Channel)
+		if !ok {
+			logger.Warn("OKX Spot WS 未知K线频道: %s", msg.Arg.Channel)
+			return
 		}
-		var msg json.RawMessage
-		if err := conn.ReadJSON(&msg); err != nil {
-			time.Sleep(500 * time.Millisecond)
-			continue
+		s.handleKline(msg.Arg.InstId, interval, msg.Data)
+	case msg.Arg.Channel == channelDepth:
+		s.handleDepth(msg.Arg.InstId, msg.Action, msg.Data)
+	case msg.Arg.Channel == channelTrade:
+		s.handleTrade(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelBBO:
+		s.handleBBO(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelTicker:
+		s.handleTicker(msg.Arg.InstId, msg.Data)
+	default:
+		logger.Debug("OKX Spot WS 未知频道: %s", msg.Arg.Channel)
+	}
+}
+
+// handleTrade 处理公共成交，side 为主动成交方向
+func (s *SpotWS) handleTrade(instId string, data json.RawMessage) {
+	var rows []okxTradeData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("OKX Spot WS 解析trades失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Px)
+		quantity, _ := decimal.NewFromString(row.Sz)
+		quoteQty := price.Mul(quantity)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+
+		side := schema.OrderSideBuy
+		if row.Side == "sell" {
+			side = schema.OrderSideSell
 		}
 
-		// 检查是否是 ping 消息
-		if s.handlePingMessage(msg, conn) {
+		s.cache.AddTrade(schema.Trade{
+			Exchange:  schema.OKX,
+			Market:    schema.SPOT,
+			Symbol:    instId,
+			TradeID:   row.TradeId,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(ts),
+		})
+	}
+}
+
+// handleBBO 处理最优一档推送
+func (s *SpotWS) handleBBO(instId string, data json.RawMessage) {
+	var rows []okxBBOData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("OKX Spot WS 解析bbo失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		if len(row.Bids) == 0 || len(row.Asks) == 0 || len(row.Bids[0]) < 2 || len(row.Asks[0]) < 2 {
 			continue
 		}
+		bidPrice, _ := decimal.NewFromString(row.Bids[0][0])
+		askPrice, _ := decimal.NewFromString(row.Asks[0][0])
+		bidQty, _ := decimal.NewFromString(row.Bids[0][1])
+		askQty, _ := decimal.NewFromString(row.Asks[0][1])
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
 
-		var raw map[string]any
-		if err := json.Unmarshal(msg, &raw); err != nil {
+		s.cache.SetBookTicker(schema.BookTicker{
+			Exchange:     schema.OKX,
+			Market:       schema.SPOT,
+			Symbol:       instId,
+			BidPrice:     bidPrice,
+			BidQty:       bidQty,
+			AskPrice:     askPrice,
+			AskQty:       askQty,
+			UpdatedAt:    time.UnixMilli(ts),
+			LastUpdateId: strconv.FormatInt(row.SeqId, 10),
+		})
+	}
+}
+
+// handleTicker 处理24小时行情推送，现货 vol24h 为基础币成交量，volCcy24h 为计价币成交额
+func (s *SpotWS) handleTicker(instId string, data json.RawMessage) {
+	var rows []schema.OKXTicker
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("OKX Spot WS 解析tickers失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Last)
+		open, _ := decimal.NewFromString(row.Open24h)
+		high, _ := decimal.NewFromString(row.High24h)
+		low, _ := decimal.NewFromString(row.Low24h)
+		volume, _ := decimal.NewFromString(row.Vol24h)
+		quoteVolume, _ := decimal.NewFromString(row.VolCcy24h)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+
+		s.cache.SetTicker(schema.Ticker{
+			Exchange:  schema.OKX,
+			Market:    schema.SPOT,
+			Symbol:    instId,
+			Price:     price,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Volume:    volume,
+			QuoteVol:  quoteVolume,
+			Timestamp: time.UnixMilli(ts),
+		})
+	}
+}
+
+func (s *SpotWS) handleKline(instId string, interval schema.Interval, data json.RawMessage) {
+	// [ts, o, h, l, c, vol(基础币), volCcy(计价币), volCcyQuote(计价币), confirm]
+	var rows [][]string
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("OKX Spot WS 解析kline失败: %v", err)
+		return
+	}
+
+	for _, row := range rows {
+		if len(row) < 9 {
+			logger.Warn("OKX Spot WS kline 字段不足: %v", row)
 			continue
 		}
-		logger.Debug("OKX WS 收到消息: %+v", raw)
-		if ch, _ := raw["arg"].(map[string]any); ch != nil {
-			if channel, _ := ch["channel"].(string); strings.HasPrefix(channel, "candle") {
-				logger.Debug("OKX WS 处理 kline 数据，channel: %s", channel)
-				var data []map[string]any
-				b, _ := json.Marshal(raw["data"])
-				_ = json.Unmarshal(b, &data)
-				for _, d := range data {
-					arr, _ := d["candle"].([]any) // new formats may vary; fallback to array in d
-					if arr == nil {
-						if a, ok := d["data"].([]any); ok {
-							arr = a
-						}
-					}
-					if arr == nil {
-						logger.Error("OKX WS kline 数据格式异常: %+v", d)
-						continue
-					}
-					logger.Info("OKX WS 解析 kline 数组: %+v", arr)
-					// OKX candle: [ts, o,h,l,c,vol,...]
-					ts, _ := toInt64OKX(arr[0])
-					oStr, _ := toStringOKX(arr[1])
-					hStr, _ := toStringOKX(arr[2])
-					lStr, _ := toStringOKX(arr[3])
-					cStr, _ := toStringOKX(arr[4])
-					vStr, _ := toStringOKX(arr[5])
-					o := decimal.RequireFromString(oStr)
-					h := decimal.RequireFromString(hStr)
-					l := decimal.RequireFromString(lStr)
-					cval := decimal.RequireFromString(cStr)
-					v := decimal.RequireFromString(vStr)
-					instId, _ := ch["instId"].(string)
-					kline := schema.Kline{
-						Exchange:  schema.OKX,
-						Market:    schema.SPOT,
-						Symbol:    strings.ReplaceAll(instId, "-", ""),
-						Interval:  schema.Interval(strings.TrimPrefix(channel, "candle")),
-						OpenTime:  time.UnixMilli(ts),
-						CloseTime: time.UnixMilli(ts),
-						Open:      o,
-						High:      h,
-						Low:       l,
-						Close:     cval,
-						Volume:    v,
-						IsFinal:   true,
-					}
-					logger.Debug("OKX WS 解析 kline 成功: %+v", kline)
-					// Note: Since DataReader interface doesn't have AppendKline method,
-					// we'll need to handle this differently or extend the interface
-					logger.Info("OKX Spot WS 收到Kline数据: %+v", kline)
-				}
-			} else if strings.HasPrefix(channel, "books") {
-				// Handle depth data
-				logger.Info("OKX Spot WS 收到Depth数据: %s", channel)
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			logger.Error("OKX Spot WS 解析kline时间失败: %v", err)
+			continue
+		}
+		open, _ := decimal.NewFromString(row[1])
+		high, _ := decimal.NewFromString(row[2])
+		low, _ := decimal.NewFromString(row[3])
+		close, _ := decimal.NewFromString(row[4])
+		volume, _ := decimal.NewFromString(row[5])
+		quoteVolume, _ := decimal.NewFromString(row[7])
 
-				// 解析depth数据
-				var data []map[string]any
-				b, _ := json.Marshal(raw["data"])
-				_ = json.Unmarshal(b, &data)
+		openTime := time.UnixMilli(ts)
+		s.gaps.SetKline(schema.Kline{
+			Exchange:    schema.OKX,
+			Market:      schema.SPOT,
+			Symbol:      instId,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row[8] == "1",
+			EventTime:   time.Now(),
+		})
+	}
+}
 
-				for _, d := range data {
-					instId, _ := ch["instId"].(string)
-					if instId == "" {
-						continue
-					}
+// handleDepth 应用 books 频道的全量与增量推送：
+// 1. snapshot 重建本地订单簿
+// 2. update 要求 prevSeqId 等于本地 seqId
+// 3. 每次应用后校验 checksum，不一致时重新订阅获取新快照
+func (s *SpotWS) handleDepth(instId, action string, data json.RawMessage) {
+	var books []okxBookData
+	if err := json.Unmarshal(data, &books); err != nil {
+		logger.Error("OKX Spot WS 解析depth失败: %v", err)
+		return
+	}
 
-					// 解析asks和bids数据
-					asksRaw, _ := d["asks"].([]any)
-					bidsRaw, _ := d["bids"].([]any)
+	for _, book := range books {
+		s.mu.Lock()
+		var ob *orderBook
+		switch action {
+		case "snapshot":
+			ob = &orderBook{
+				seqId: book.SeqId,
+				bids:  make(map[string]bookLevel),
+				asks:  make(map[string]bookLevel),
+			}
+			applyLevels(ob.bids, book.Bids)
+			applyLevels(ob.asks, book.Asks)
+			s.orderBooks[instId] = ob
+			logger.Info("OKX Spot WS %s 深度快照已加载: seqId=%d, 买单%d档, 卖单%d档",
+				instId, book.SeqId, len(book.Bids), len(book.Asks))
+		case "update":
+			ob = s.orderBooks[instId]
+			if ob == nil {
+				// 尚未收到快照，等待快照
+				s.mu.Unlock()
+				return
+			}
+			if book.PrevSeqId != ob.seqId {
+				s.mu.Unlock()
+				logger.Warn("OKX Spot WS %s 序列号不连续: prevSeqId=%d, 本地seqId=%d，重新订阅",
+					instId, book.PrevSeqId, ob.seqId)
+				s.resubscribeDepth(instId)
+				return
+			}
+			applyLevels(ob.bids, book.Bids)
+			applyLevels(ob.asks, book.Asks)
+			ob.seqId = book.SeqId
+		default:
+			s.mu.Unlock()
+			logger.Warn("OKX Spot WS 未知深度动作: %s", action)
+			return
+		}
 
-					if len(asksRaw) == 0 || len(bidsRaw) == 0 {
-						continue
-					}
+		bids, asks := sortedLevels(ob)
+		s.mu.Unlock()
 
-				}
-			}
+		if local := checksum(bids, asks); int64(local) != book.Checksum {
+			logger.Warn("OKX Spot WS %s 校验和不一致: checksum=%d, 本地=%d，重新订阅",
+				instId, book.Checksum, local)
+			s.resubscribeDepth(instId)
+			return
 		}
+
+		s.cache.SetDepth(buildDepth(instId, bids, asks, book.SeqId, book.Ts))
 	}
 }
 
-func toInt64OKX(v any) (int64, error) {
-	switch t := v.(type) {
-	case string:
-		return strconv.ParseInt(t, 10, 64)
-	case float64:
-		return int64(t), nil
-	case int64:
-		return t, nil
-	default:
-		return 0, fmt.Errorf("bad type for int64: %T", v)
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]bookLevel, levels [][]string) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
+		}
+		price, err := decimal.NewFromString(lv[0])
+		if err != nil {
+			continue
+		}
+		qty, err := decimal.NewFromString(lv[1])
+		if err != nil {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := price.String()
+		if qty.IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = bookLevel{price: price, qty: qty, rawPx: lv[0], rawSz: lv[1]}
+		}
 	}
 }
 
-func toStringOKX(v any) (string, error) {
-	switch t := v.(type) {
-	case string:
-		return t, nil
-	case float64:
-		return strconv.FormatFloat(t, 'f', -1, 64), nil
-	case int64:
-		return strconv.FormatInt(t, 10), nil
-	default:
-		return "", fmt.Errorf("bad type for string: %T", v)
+// sortedLevels 返回排序后的档位：买单降序，卖单升序
+func sortedLevels(ob *orderBook) (bids, asks []bookLevel) {
+	bids = make([]bookLevel, 0, len(ob.bids))
+	for _, lv := range ob.bids {
+		bids = append(bids, lv)
+	}
+	asks = make([]bookLevel, 0, len(ob.asks))
+	for _, lv := range ob.asks {
+		asks = append(asks, lv)
 	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].price.GreaterThan(bids[j].price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].price.LessThan(asks[j].price) })
+	return bids, asks
 }
 
-// handlePingMessage 检查并处理 ping 消息
-func (s *SpotWS) handlePingMessage(data json.RawMessage, conn interfaces.WSConn) bool {
-	// OKX 使用 "ping" 字符串作为心跳
-	var pingCheck string
-	if err := json.Unmarshal(data, &pingCheck); err == nil && pingCheck == "ping" {
-		logger.Info("OKX WS 收到 ping 消息")
-		// 发送 "pong" 响应
-		if err := conn.WriteJSON("pong"); err != nil {
-			logger.Error("OKX WS 发送 pong 失败: %v", err)
+// checksumString 按 OKX 规则拼接前25档：bid1价:bid1量:ask1价:ask1量:bid2价:...
+// 某一侧档位不足时跳过该侧
+func checksumString(bids, asks []bookLevel) string {
+	parts := make([]string, 0, checksumLevels*4)
+	for i := 0; i < checksumLevels; i++ {
+		if i < len(bids) {
+			parts = append(parts, bids[i].rawPx, bids[i].rawSz)
+		}
+		if i < len(asks) {
+			parts = append(parts, asks[i].rawPx, asks[i].rawSz)
 		}
-		return true
 	}
-	return false
+	return strings.Join(parts, ":")
+}
+
+// checksum 计算 CRC32 校验和，OKX 推送的是有符号32位整数
+func checksum(bids, asks []bookLevel) int32 {
+	return int32(crc32.ChecksumIEEE([]byte(checksumString(bids, asks))))
 }
 
-// HandlePing handles ping messages from the server
+// buildDepth 将排序后的档位裁剪为缓存使用的深度
+func buildDepth(instId string, bids, asks []bookLevel, seqId int64, ts string) schema.Depth {
+	convert := func(levels []bookLevel) []schema.PriceLevel {
+		if len(levels) > maxDepthLevels {
+			levels = levels[:maxDepthLevels]
+		}
+		out := make([]schema.PriceLevel, 0, len(levels))
+		for _, lv := range levels {
+			out = append(out, schema.PriceLevel{Price: lv.price, Quantity: lv.qty})
+		}
+		return out
+	}
+
+	updatedAt := time.Now()
+	if ms, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		updatedAt = time.UnixMilli(ms)
+	}
+
+	return schema.Depth{
+		Exchange:     schema.OKX,
+		Market:       schema.SPOT,
+		Symbol:       instId,
+		Bids:         convert(bids),
+		Asks:         convert(asks),
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", seqId),
+	}
+}
+
+// HandlePing 处理服务端的 ping 帧
 func (s *SpotWS) HandlePing(data []byte) error {
-	// Send pong response
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
-
-	if conn != nil {
-		pongMsg := map[string]interface{}{
-			"op": "pong",
-		}
-		pongData, err := json.Marshal(pongMsg)
-		if err != nil {
-			return fmt.Errorf("failed to marshal pong message: %w", err)
-		}
-		return conn.WriteMessage(1, pongData)
+	if conn == nil {
+		return errors.New("connection not established")
 	}
-	return nil
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
 }
 
-// SendPing sends ping to server (if supported by exchange)
+// SendPing 发送 OKX 文本心跳 "ping"，服务端回复 "pong"
 func (s *SpotWS) SendPing(ctx context.Context) error {
 	s.mu.RLock()
 	conn := s.conn
 	s.mu.RUnlock()
+	if conn == nil {
+		return fmt.Errorf("WebSocket connection not established")
+	}
 
-	if conn != nil {
-		// OKX 使用 "ping" 字符串作为心跳
-		return conn.WriteJSON("ping")
+	s.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+	s.writeMu.Unlock()
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("WebSocket connection not established")
+
+	s.healthMu.Lock()
+	s.lastPing = time.Now()
+	s.healthMu.Unlock()
+	return nil
 }
 
-// StartHealthCheck starts connection health monitoring
+// StartHealthCheck 空闲时发送心跳，超时未收到消息则重连
 func (s *SpotWS) StartHealthCheck(ctx context.Context) error {
-	// OKX 使用内置的 ping-pong 机制，这里不需要额外的健康检查
-	// 心跳处理已经在 StartReading 中实现
-	return nil
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("OKX Spot WS 健康检查停止")
+			return nil
+		case <-s.ctx.Done():
+			logger.Info("OKX Spot WS 健康检查停止")
+			return nil
+		case <-ticker.C:
+			s.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) checkConnectionHealth(ctx context.Context) {
+	if !s.isConnected() {
+		return
+	}
+
+	s.healthMu.RLock()
+	sinceMsg := time.Since(s.lastMessage)
+	sincePing := time.Since(s.lastPing)
+	s.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("OKX Spot WS 长时间未收到消息 (%.2f秒)，尝试重连", sinceMsg.Seconds())
+		s.reconnect(ctx)
+		return
+	}
+
+	if sinceMsg > pingInterval && sincePing > pingInterval {
+		if err := s.SendPing(ctx); err != nil {
+			logger.Warn("OKX Spot WS ping失败: %v", err)
+			s.reconnect(ctx)
+		}
+	}
+}
+
+func (s *SpotWS) reconnect(ctx context.Context) {
+	s.reconnectMu.Lock()
+	if s.reconnecting {
+		s.reconnectMu.Unlock()
+		return
+	}
+	s.reconnecting = true
+	s.reconnectMu.Unlock()
+
+	defer func() {
+		s.reconnectMu.Lock()
+		s.reconnecting = false
+		s.reconnectMu.Unlock()
+	}()
+
+	s.mu.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	// 重新订阅后服务端会推送新的全量快照
+	s.orderBooks = make(map[string]*orderBook)
+	s.mu.Unlock()
+
+	for {
+		s.reconnectMu.Lock()
+		s.reconnectCount++
+		reconnectCount := s.reconnectCount
+		s.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("OKX Spot WS 第%d次重连，等待 %.0f 秒", reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := s.Connect(ctx); err != nil {
+			logger.Error("OKX Spot WS 重连失败: %v", err)
+			continue
+		}
+
+		logger.Info("OKX Spot WS 重连成功")
+		// 补齐断线期间缺失的K线
+		s.gaps.Backfill(ctx, s.subs.GetKlineSubscriptions())
+		s.reconnectMu.Lock()
+		s.reconnectCount = 0
+		s.reconnectMu.Unlock()
+		return
+	}
 }
@@ -0,0 +1,141 @@
+package spot
+
+import (
+	"context"
+	"encoding/json"
+	"hash/crc32"
+	"testing"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+// OKX 文档中的校验和示例
+func TestChecksumString(t *testing.T) {
+	ob := &orderBook{bids: make(map[string]bookLevel), asks: make(map[string]bookLevel)}
+	applyLevels(ob.bids, [][]string{{"3366.1", "7", "0", "3"}, {"3366", "6", "3", "4"}})
+	applyLevels(ob.asks, [][]string{{"3366.8", "9", "10", "3"}, {"3368", "8", "3", "4"}})
+
+	bids, asks := sortedLevels(ob)
+	want := "3366.1:7:3366.8:9:3366:6:3368:8"
+	if got := checksumString(bids, asks); got != want {
+		t.Fatalf("checksum string = %q, want %q", got, want)
+	}
+}
+
+func TestHandleDepth_ChecksumVerified(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	sum := func(str string) int64 { return int64(int32(crc32.ChecksumIEEE([]byte(str)))) }
+	push := func(bids, asks [][]string, prevSeqId, seqId, checksum int64) json.RawMessage {
+		data, _ := json.Marshal([]okxBookData{{
+			Bids: bids, Asks: asks, Ts: "1700000000000",
+			PrevSeqId: prevSeqId, SeqId: seqId, Checksum: checksum,
+		}})
+		return data
+	}
+
+	s.handleDepth("BTC-USDT", "snapshot", push(
+		[][]string{{"100.0", "1", "0", "1"}}, [][]string{{"101.0", "2", "0", "1"}},
+		-1, 10, sum("100.0:1:101.0:2")))
+	s.handleDepth("BTC-USDT", "update", push(
+		[][]string{{"100.5", "3", "0", "1"}}, nil,
+		10, 11, sum("100.5:3:101.0:2:100.0:1")))
+
+	d, ok := c.GetDepth(schema.OKX, schema.SPOT, "BTC-USDT")
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	if len(d.Bids) != 2 || d.Bids[0].Price.String() != "100.5" || d.LastUpdateId != "11" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+
+	// 校验和不一致时丢弃本地订单簿，等待重新订阅后的快照
+	s.handleDepth("BTC-USDT", "update", push(
+		[][]string{{"100.5", "0", "0", "0"}}, nil,
+		11, 12, 12345))
+	if _, ok := s.orderBooks["BTC-USDT"]; ok {
+		t.Fatalf("order book should be dropped after checksum mismatch")
+	}
+}
+
+func TestHandleTrade(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"130639474","px":"42219.9","sz":"0.12060306","side":"sell","ts":"1630048897897","count":"3"}]}`))
+
+	trades, ok := c.GetTrades(schema.OKX, schema.SPOT, "BTC-USDT", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	tr := trades[0]
+	if tr.TradeID != "130639474" || tr.Side != schema.OrderSideSell || tr.Quantity.String() != "0.12060306" || tr.Timestamp.UnixMilli() != 1630048897897 {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+func TestHandleBBO(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"arg":{"channel":"bbo-tbt","instId":"BTC-USDT"},"data":[{"asks":[["8446","95","0","3"]],"bids":[["8445.9","2","0","1"]],"ts":"1597026383085","seqId":123456}]}`))
+
+	bt, ok := c.GetBookTicker(schema.OKX, schema.SPOT, "BTC-USDT")
+	if !ok {
+		t.Fatalf("book ticker not cached")
+	}
+	if bt.BidPrice.String() != "8445.9" || bt.BidQty.String() != "2" || bt.AskPrice.String() != "8446" || bt.AskQty.String() != "95" ||
+		bt.LastUpdateId != "123456" || bt.UpdatedAt.UnixMilli() != 1597026383085 {
+		t.Fatalf("unexpected book ticker: %+v", bt)
+	}
+}
+
+func TestHandleTicker(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instType":"SPOT","instId":"BTC-USDT","last":"9999.99","lastSz":"0.1","askPx":"9999.99","askSz":"11","bidPx":"8888.88","bidSz":"5","open24h":"9000","high24h":"10000","low24h":"8888.88","volCcy24h":"2222","vol24h":"2222","sodUtc0":"2222","sodUtc8":"2222","ts":"1597026383085"}]}`))
+
+	tk, ok := c.GetTicker(schema.OKX, schema.SPOT, "BTC-USDT")
+	if !ok {
+		t.Fatalf("ticker not cached")
+	}
+	if tk.Price.String() != "9999.99" || tk.Open.String() != "9000" || tk.High.String() != "10000" || tk.Low.String() != "8888.88" ||
+		tk.Volume.String() != "2222" || tk.QuoteVol.String() != "2222" || tk.Timestamp.UnixMilli() != 1597026383085 {
+		t.Fatalf("unexpected ticker: %+v", tk)
+	}
+}
+
+// 不同周期的K线频道按周期分别写入缓存，收盘时间按周期长度计算
+func TestHandleKline_Intervals(t *testing.T) {
+	c := cache.NewMemoryCache()
+	s := NewSpotWS(c, cache.NewSubscriptionManager(), NewSpotREST())
+
+	s.handleRawMessage([]byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT"},"data":[["1597026360000","8533","8553.74","8527.17","8548.26","45.3","387000","387000","0"]]}`))
+	s.handleRawMessage([]byte(`{"arg":{"channel":"candle1H","instId":"BTC-USDT"},"data":[["1597024800000","8500","8600","8480","8548.26","900.5","7700000","7700000","1"]]}`))
+
+	kl, ok := c.GetKline(schema.OKX, schema.SPOT, "BTC-USDT", schema.Interval1h)
+	if !ok || len(kl) != 1 {
+		t.Fatalf("1h kline not cached")
+	}
+	if k := kl[0]; k.Interval != schema.Interval1h || k.CloseTime.UnixMilli() != 1597028399999 || !k.IsFinal || k.Volume.String() != "900.5" {
+		t.Fatalf("unexpected 1h kline: %+v", k)
+	}
+	if kl, ok = c.GetKline(schema.OKX, schema.SPOT, "BTC-USDT", schema.Interval1m); !ok || kl[0].CloseTime.UnixMilli() != 1597026419999 {
+		t.Fatalf("unexpected 1m kline: %+v", kl)
+	}
+}
+
+func TestSubscribeKline_UnsupportedInterval(t *testing.T) {
+	subs := cache.NewSubscriptionManager()
+	s := NewSpotWS(cache.NewMemoryCache(), subs, NewSpotREST())
+
+	if err := s.SubscribeKline(context.Background(), []string{"BTC-USDT"}, schema.Interval5m, "7m"); err == nil {
+		t.Fatalf("expected error for unsupported interval")
+	}
+	if len(subs.GetKlineSubscriptions()) != 0 {
+		t.Fatalf("nothing should be subscribed when an interval is rejected")
+	}
+}
@@ -0,0 +1,58 @@
+// Package swap 实现 OKX 永续合约（SWAP）的 REST 与 WebSocket 行情，U本位与币本位合约共用，
+// 两者只在计价币种、合约类型以及张数到基础币数量的换算上不同，由 Market 描述。
+package swap
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+const (
+	ctTypeLinear  = "linear"  // U本位：面值以基础币计，如 BTC-USDT-SWAP 一张 0.01 BTC
+	ctTypeInverse = "inverse" // 币本位：面值以计价币计，如 BTC-USD-SWAP 一张 100 USD
+)
+
+// Market 描述一种永续合约市场
+type Market struct {
+	Type   schema.MarketType
+	Name   string // 日志前缀，如 OKX Futures USDT
+	Quote  string // 计价币种，instId 形如 BTC-<Quote>-SWAP
+	CtType string // linear / inverse
+}
+
+var (
+	// USDT U本位永续合约，USDT 结算
+	USDT = Market{Type: schema.FUTURESUSDT, Name: "OKX Futures USDT", Quote: "USDT", CtType: ctTypeLinear}
+	// Coin 币本位永续合约，以基础币结算
+	Coin = Market{Type: schema.FUTURESCOIN, Name: "OKX Futures Coin", Quote: "USD", CtType: ctTypeInverse}
+)
+
+// inverse 是否为币本位合约
+func (m Market) inverse() bool {
+	return m.CtType == ctTypeInverse
+}
+
+// instSuffix 当前市场合约 instId 的后缀，如 -USDT-SWAP
+func (m Market) instSuffix() string {
+	return "-" + m.Quote + "-SWAP"
+}
+
+// toBase 将张数换算为基础币数量：U本位为 张数 × 面值，币本位为 张数 × 面值 / 价格
+func (m Market) toBase(sz, ctVal, price decimal.Decimal) decimal.Decimal {
+	if !m.inverse() {
+		return sz.Mul(ctVal)
+	}
+	if price.IsZero() {
+		return decimal.Zero
+	}
+	return sz.Mul(ctVal).Div(price)
+}
+
+// toQuote 将张数换算为计价币金额：U本位为 基础币数量 × 价格，币本位直接为 张数 × 面值
+func (m Market) toQuote(sz, ctVal, price decimal.Decimal) decimal.Decimal {
+	if m.inverse() {
+		return sz.Mul(ctVal)
+	}
+	return sz.Mul(ctVal).Mul(price)
+}
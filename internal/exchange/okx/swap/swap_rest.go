@@ -0,0 +1,663 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/pkg/logger"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+const (
+	okxBaseURL            = "https://www.okx.com"
+	apiV5MarketTicker     = "/api/v5/market/ticker"
+	apiV5MarketTickers    = "/api/v5/market/tickers"
+	apiV5MarketBooks      = "/api/v5/market/books"
+	apiV5HistoryCandles   = "/api/v5/market/history-candles"
+	apiV5PublicInstrument = "/api/v5/public/instruments"
+	apiV5PublicTime       = "/api/v5/public/time"
+	apiV5OpenInterest     = "/api/v5/public/open-interest"
+	apiV5OpenInterestHist = "/api/v5/rubik/stat/contracts/open-interest-history"
+	apiV5LongShortRatio   = "/api/v5/rubik/stat/contracts/long-short-account-ratio-contract"
+)
+
+// REST implements RESTClient for OKX perpetual swaps of one Market.
+type REST struct {
+	market Market
+	http   *resty.Client
+
+	// 合约面值缓存 instId -> ctVal，U本位为一张合约对应的基础币数量，币本位为对应的计价币金额（如 BTC-USD-SWAP 为 100 USD）
+	ctValMu sync.RWMutex
+	ctVals  map[string]decimal.Decimal
+}
+
+func NewREST(market Market) *REST {
+	return &REST{
+		market: market,
+		http:   resty.New().SetBaseURL(okxBaseURL).SetTimeout(10 * time.Second),
+		ctVals: make(map[string]decimal.Decimal),
+	}
+}
+
+// GetKline 获取最近 limit 根K线，按时间范围委托给 GetKlines
+func (f *REST) GetKline(ctx context.Context, symbol string, interval schema.Interval, limit int) ([]schema.Kline, error) {
+	return schema.LatestKlines(ctx, f.GetKlines, symbol, interval, limit)
+}
+
+// maxKlinesPerRequest OKX 永续合约历史K线接口单次最多返回的条数
+const maxKlinesPerRequest = 100
+
+// GetKlines 获取开盘时间在 [start, end] 内的历史K线，接口按时间倒序返回，使用 after 游标向前翻页，end 为零值时取到当前时间
+func (f *REST) GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error) {
+	if err := checkKlineIntervals([]schema.Interval{interval}); err != nil {
+		return nil, err
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var out []schema.Kline
+	after := end.UnixMilli() + 1
+	for {
+		rows, err := f.historyCandles(ctx, symbol, interval, after)
+		if err != nil {
+			return nil, err
+		}
+		page := f.convertKlines(rows, symbol, interval)
+		out = append(out, page...)
+		if len(rows) < maxKlinesPerRequest || len(page) == 0 {
+			break
+		}
+
+		// 以本页最早一根的开盘时间作为下一页的游标
+		oldest := page[len(page)-1].OpenTime.UnixMilli()
+		if oldest <= start.UnixMilli() || oldest >= after {
+			break
+		}
+		after = oldest
+	}
+
+	return schema.NormalizeKlines(out, start, end), nil
+}
+
+// historyCandles 拉取开盘时间早于 after 的一页K线
+func (f *REST) historyCandles(ctx context.Context, symbol string, interval schema.Interval, after int64) ([][]string, error) {
+	var resp schema.OKXKlineResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"instId": symbol,
+		"bar":    klineIntervals[interval],
+		"after":  strconv.FormatInt(after, 10),
+		"limit":  strconv.Itoa(maxKlinesPerRequest),
+	}).Get(apiV5HistoryCandles)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+
+	logger.Debug("%s Kline 返回 %d 条", f.market.Name, len(resp.Data))
+	return resp.Data, nil
+}
+
+// convertKlines 转换K线数组，与 WebSocket 推送一致，volCcy 为基础币数量，volCcyQuote 为计价币数量
+func (f *REST) convertKlines(rows [][]string, symbol string, interval schema.Interval) []schema.Kline {
+	out := make([]schema.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		open, _ := decimal.NewFromString(row[1])
+		high, _ := decimal.NewFromString(row[2])
+		low, _ := decimal.NewFromString(row[3])
+		close, _ := decimal.NewFromString(row[4])
+		volume, _ := decimal.NewFromString(row[6])
+		quoteVolume, _ := decimal.NewFromString(row[7])
+		openTime := time.UnixMilli(ts)
+		out = append(out, schema.Kline{
+			Exchange:    schema.OKX,
+			Market:      f.market.Type,
+			Symbol:      symbol,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row[8] == "1",
+		})
+	}
+	return out
+}
+
+// GetTicker 获取单个币对的24小时行情
+func (f *REST) GetTicker(ctx context.Context, symbol string) (schema.Ticker, error) {
+	data, err := f.tickers(ctx, apiV5MarketTicker, map[string]string{"instId": symbol})
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	if len(data) == 0 {
+		return schema.Ticker{}, errors.New("no ticker data")
+	}
+	return f.convertTicker(ctx, data[0]), nil
+}
+
+// GetTickers 获取全部合约的24小时行情
+func (f *REST) GetTickers(ctx context.Context) ([]schema.Ticker, error) {
+	data, err := f.tickers(ctx, apiV5MarketTickers, map[string]string{"instType": "SWAP"})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.Ticker, 0, len(data))
+	for _, t := range data {
+		// SWAP 行情同时包含U本位和币本位合约，只保留当前市场
+		if !strings.HasSuffix(t.InstID, f.market.instSuffix()) {
+			continue
+		}
+		out = append(out, f.convertTicker(ctx, t))
+	}
+	return out, nil
+}
+
+func (f *REST) tickers(ctx context.Context, path string, params map[string]string) ([]schema.OKXTicker, error) {
+	var resp schema.OKXTickerResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(params).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+
+	logger.Debug("%s Ticker 原始响应: %s", f.market.Name, string(r.Body()))
+	return resp.Data, nil
+}
+
+func (f *REST) convertTicker(ctx context.Context, t schema.OKXTicker) schema.Ticker {
+	price, _ := decimal.NewFromString(t.Last)
+	open, _ := decimal.NewFromString(t.Open24h)
+	high, _ := decimal.NewFromString(t.High24h)
+	low, _ := decimal.NewFromString(t.Low24h)
+	// volCcy24h 为基础币成交量；U本位合约接口不提供计价币成交额，币本位合约 vol24h 为张数，按面值换算 USD 成交额
+	volume, _ := decimal.NewFromString(t.VolCcy24h)
+	var quoteVolume decimal.Decimal
+	if f.market.inverse() {
+		contracts, _ := decimal.NewFromString(t.Vol24h)
+		if ctVal, err := f.contractValue(ctx, t.InstID); err == nil {
+			quoteVolume = contracts.Mul(ctVal)
+		}
+	}
+	ts, _ := strconv.ParseInt(t.Ts, 10, 64)
+
+	return schema.Ticker{
+		Exchange:  schema.OKX,
+		Market:    f.market.Type,
+		Symbol:    t.InstID,
+		Price:     price,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Volume:    volume,
+		QuoteVol:  quoteVolume,
+		Timestamp: time.UnixMilli(ts),
+	}
+}
+
+// GetDepth 获取合约深度，数量已由张数按价格换算为基础币数量
+func (f *REST) GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error) {
+	ctVal, err := f.contractValue(ctx, symbol)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+
+	var resp struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			Asks [][]string `json:"asks"`
+			Bids [][]string `json:"bids"`
+			Ts   string     `json:"ts"`
+		} `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"instId": symbol,
+		"sz":     fmt.Sprintf("%d", limit),
+	}).Get(apiV5MarketBooks)
+	if err != nil {
+		return schema.Depth{}, err
+	}
+	if r.IsError() {
+		return schema.Depth{}, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return schema.Depth{}, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+
+	// 保存原始响应结果用于调试
+	rawResponse := string(r.Body())
+	logger.Debug("%s Depth 原始响应: %s", f.market.Name, rawResponse)
+
+	if len(resp.Data) == 0 {
+		return schema.Depth{}, errors.New("no depth data")
+	}
+
+	data := resp.Data[0]
+	convert := func(levels [][]string) []schema.PriceLevel {
+		out := make([]schema.PriceLevel, 0, len(levels))
+		for _, lv := range levels {
+			if len(lv) < 2 {
+				continue
+			}
+			p, _ := decimal.NewFromString(lv[0])
+			sz, _ := decimal.NewFromString(lv[1])
+			out = append(out, schema.PriceLevel{Price: p, Quantity: f.market.toBase(sz, ctVal, p)})
+		}
+		return out
+	}
+
+	ts, _ := strconv.ParseInt(data.Ts, 10, 64)
+	return schema.Depth{
+		Exchange:     schema.OKX,
+		Market:       f.market.Type,
+		Symbol:       symbol,
+		Bids:         convert(data.Bids),
+		Asks:         convert(data.Asks),
+		UpdatedAt:    time.UnixMilli(ts),
+		LastUpdateId: data.Ts,
+	}, nil
+}
+
+// cachedContractValue 只读取已缓存的合约面值，不发起请求
+func (f *REST) cachedContractValue(instId string) (decimal.Decimal, bool) {
+	f.ctValMu.RLock()
+	defer f.ctValMu.RUnlock()
+	v, ok := f.ctVals[instId]
+	return v, ok
+}
+
+// contractValue 获取合约面值，结果按 instId 缓存
+func (f *REST) contractValue(ctx context.Context, instId string) (decimal.Decimal, error) {
+	f.ctValMu.RLock()
+	v, ok := f.ctVals[instId]
+	f.ctValMu.RUnlock()
+	if ok {
+		return v, nil
+	}
+
+	var resp struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			InstId   string `json:"instId"`
+			CtVal    string `json:"ctVal"`
+			CtMult   string `json:"ctMult"`
+			CtValCcy string `json:"ctValCcy"`
+		} `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"instType": "SWAP",
+		"instId":   instId,
+	}).Get(apiV5PublicInstrument)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if r.IsError() {
+		return decimal.Zero, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return decimal.Zero, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+	if len(resp.Data) == 0 {
+		return decimal.Zero, fmt.Errorf("instrument %s not found", instId)
+	}
+
+	ctVal, err := decimal.NewFromString(resp.Data[0].CtVal)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid ctVal %q for %s: %w", resp.Data[0].CtVal, instId, err)
+	}
+	if ctMult, err := decimal.NewFromString(resp.Data[0].CtMult); err == nil && !ctMult.IsZero() {
+		ctVal = ctVal.Mul(ctMult)
+	}
+
+	f.ctValMu.Lock()
+	f.ctVals[instId] = ctVal
+	f.ctValMu.Unlock()
+
+	logger.Info("%s 合约面值已加载: %s ctVal=%s %s", f.market.Name, instId, ctVal, resp.Data[0].CtValCcy)
+	return ctVal, nil
+}
+
+// okxInstrument 永续合约产品基础信息
+type okxInstrument struct {
+	InstId    string `json:"instId"`
+	Uly       string `json:"uly"`
+	SettleCcy string `json:"settleCcy"`
+	CtType    string `json:"ctType"` // linear / inverse
+	CtVal     string `json:"ctVal"`
+	CtMult    string `json:"ctMult"`
+	CtValCcy  string `json:"ctValCcy"`
+	TickSz    string `json:"tickSz"`
+	LotSz     string `json:"lotSz"`
+	MinSz     string `json:"minSz"`
+	MaxLmtSz  string `json:"maxLmtSz"`
+	State     string `json:"state"` // live / suspend / preopen / test
+}
+
+// instruments 获取全部永续合约产品信息，U本位和币本位合约由调用方按结算币种区分
+func (f *REST) instruments(ctx context.Context) ([]okxInstrument, error) {
+	var resp struct {
+		Code string          `json:"code"`
+		Msg  string          `json:"msg"`
+		Data []okxInstrument `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParam("instType", "SWAP").Get(apiV5PublicInstrument)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+	logger.Debug("%s ExchangeInfo 原始响应长度: %d bytes", f.market.Name, len(r.Body()))
+	return resp.Data, nil
+}
+
+// serverTime 获取 OKX 服务器时间
+func (f *REST) serverTime(ctx context.Context) (time.Time, error) {
+	var resp struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			Ts string `json:"ts"`
+		} `json:"data"`
+	}
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).Get(apiV5PublicTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if r.IsError() {
+		return time.Time{}, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return time.Time{}, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+	if len(resp.Data) == 0 {
+		return time.Time{}, errors.New("okx server time: empty data")
+	}
+	ts, err := strconv.ParseInt(resp.Data[0].Ts, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("okx server time: %w", err)
+	}
+	return time.UnixMilli(ts), nil
+}
+
+// lastPrices 获取当前市场各合约的最新价，用于估算U本位合约的最小下单金额
+func (f *REST) lastPrices(ctx context.Context) (map[string]decimal.Decimal, error) {
+	data, err := f.tickers(ctx, apiV5MarketTickers, map[string]string{"instType": "SWAP"})
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[string]decimal.Decimal, len(data))
+	for _, t := range data {
+		if price, err := decimal.NewFromString(t.Last); err == nil {
+			prices[t.InstID] = price
+		}
+	}
+	return prices, nil
+}
+
+// GetExchangeInfo 获取当前市场永续合约交易规则：U本位下单数量按合约面值换算为基础币数量，
+// 最小下单金额按 最小张数 × 面值 × 最新价 估算；币本位合约数量以张计，最小下单金额为最小张数对应的计价币面值
+func (f *REST) GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error) {
+	data, err := f.instruments(ctx)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	serverTime, err := f.serverTime(ctx)
+	if err != nil {
+		return schema.ExchangeInfo{}, err
+	}
+	var prices map[string]decimal.Decimal
+	if !f.market.inverse() {
+		// OKX 未提供合约的最小下单金额，取不到最新价时留空
+		if prices, err = f.lastPrices(ctx); err != nil {
+			logger.Warn("%s 获取最新价失败，最小下单金额留空: %v", f.market.Name, err)
+		}
+	}
+
+	symbols := make([]schema.Symbol, 0, len(data))
+	f.ctValMu.Lock()
+	for _, inst := range data {
+		if inst.CtType != f.market.CtType || !strings.HasSuffix(inst.InstId, f.market.instSuffix()) {
+			continue
+		}
+		ctVal, err := decimal.NewFromString(inst.CtVal)
+		if err != nil || ctVal.IsZero() {
+			continue
+		}
+		if ctMult, err := decimal.NewFromString(inst.CtMult); err == nil && !ctMult.IsZero() {
+			ctVal = ctVal.Mul(ctMult)
+		}
+		// 顺便刷新合约面值缓存
+		f.ctVals[inst.InstId] = ctVal
+
+		status, ok := symbolStatus(inst.State)
+		if !ok {
+			continue
+		}
+		base, quote, _ := strings.Cut(inst.Uly, "-")
+		minQty, maxQty, step, minNotional := inst.MinSz, inst.MaxLmtSz, inst.LotSz, ""
+		if f.market.inverse() {
+			if minSz, err := decimal.NewFromString(inst.MinSz); err == nil {
+				minNotional = minSz.Mul(ctVal).String()
+			}
+		} else {
+			toBase := func(sz string) string {
+				d, err := decimal.NewFromString(sz)
+				if err != nil {
+					return ""
+				}
+				return d.Mul(ctVal).String()
+			}
+			minQty, maxQty, step = toBase(inst.MinSz), toBase(inst.MaxLmtSz), toBase(inst.LotSz)
+			if price, ok := prices[inst.InstId]; ok {
+				if minSz, err := decimal.NewFromString(inst.MinSz); err == nil {
+					minNotional = minSz.Mul(ctVal).Mul(price).String()
+				}
+			}
+		}
+		symbols = append(symbols, schema.Symbol{
+			Symbol:       inst.InstId,
+			Base:         base,
+			Quote:        quote,
+			Margin:       inst.SettleCcy,
+			ExchangeName: schema.OKX,
+			MarketType:   f.market.Type,
+
+			QuantityPrecision: decimalPlaces(step),
+			PricePrecision:    decimalPlaces(inst.TickSz),
+			MinQuantity:       minQty,
+			MaxQuantity:       maxQty,
+			MinNotional:       minNotional,
+			TickSize:          inst.TickSz,
+			StepSize:          step,
+
+			Status: status,
+		})
+	}
+	f.ctValMu.Unlock()
+
+	logger.Info("%s 交易规则已加载: %d 个合约", f.market.Name, len(symbols))
+	return schema.ExchangeInfo{
+		Exchange:   schema.OKX,
+		Market:     f.market.Type,
+		Symbols:    symbols,
+		UpdatedAt:  time.Now(),
+		ServerTime: serverTime,
+		RateLimits: []schema.RateLimit{},
+		Timezone:   "UTC",
+	}, nil
+}
+
+// symbolStatus 归一化产品状态，测试产品（test）返回 false
+func symbolStatus(state string) (schema.SymbolStatus, bool) {
+	switch state {
+	case "live":
+		return schema.SymbolStatusTrading, true
+	case "preopen":
+		return schema.SymbolStatusPreTrading, true
+	case "suspend":
+		return schema.SymbolStatusHalt, true
+	default:
+		return "", false
+	}
+}
+
+// decimalPlaces 根据步长计算小数位数，如 "0.001" -> 3
+func decimalPlaces(step string) int {
+	d, err := decimal.NewFromString(step)
+	if err != nil || d.Exponent() >= 0 {
+		return 0
+	}
+	return int(-d.Exponent())
+}
+
+// GetOpenInterest 获取合约当前持仓量，oiCcy 为基础币数量，oiUsd 为USD价值
+func (f *REST) GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error) {
+	var resp schema.OKXOpenInterestResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"instType": "SWAP",
+		"instId":   symbol,
+	}).Get(apiV5OpenInterest)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	if r.IsError() {
+		return schema.OpenInterest{}, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return schema.OpenInterest{}, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+	if len(resp.Data) == 0 {
+		return schema.OpenInterest{}, errors.New("no open interest data")
+	}
+
+	data := resp.Data[0]
+	openInterest, _ := decimal.NewFromString(data.OiCcy)
+	value, _ := decimal.NewFromString(data.OiUsd)
+	ts, _ := strconv.ParseInt(data.Ts, 10, 64)
+	return schema.OpenInterest{
+		Exchange:     schema.OKX,
+		Market:       f.market.Type,
+		Symbol:       data.InstID,
+		OpenInterest: openInterest,
+		Value:        value,
+		Timestamp:    time.UnixMilli(ts),
+	}, nil
+}
+
+// GetOpenInterestHistory 获取合约持仓量历史，每项为 [ts, oi, oiCcy, oiUsd]
+func (f *REST) GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	rows, err := f.rubik(ctx, apiV5OpenInterestHist, symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.OpenInterest, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 4 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(row[0], 10, 64)
+		openInterest, _ := decimal.NewFromString(row[2])
+		value, _ := decimal.NewFromString(row[3])
+		out = append(out, schema.OpenInterest{
+			Exchange:     schema.OKX,
+			Market:       f.market.Type,
+			Symbol:       symbol,
+			OpenInterest: openInterest,
+			Value:        value,
+			Timestamp:    time.UnixMilli(ts),
+		})
+	}
+	return out, nil
+}
+
+// GetLongShortRatio 获取合约多空账户比历史，每项为 [ts, longShortAccountRatio]
+func (f *REST) GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	rows, err := f.rubik(ctx, apiV5LongShortRatio, symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]schema.LongShortRatio, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(row[0], 10, 64)
+		ratio, _ := decimal.NewFromString(row[1])
+		out = append(out, schema.NewLongShortRatio(schema.OKX, f.market.Type, symbol, ratio, time.UnixMilli(ts)))
+	}
+	return out, nil
+}
+
+// rubik 请求交易大数据接口，返回结果已按时间升序排列
+func (f *REST) rubik(ctx context.Context, path, symbol string, period schema.Interval, limit int) ([][]string, error) {
+	var resp schema.OKXRubikResponse
+	r, err := f.http.R().SetContext(ctx).SetResult(&resp).SetQueryParams(map[string]string{
+		"instId": symbol,
+		"period": periodOKX(period),
+		"limit":  fmt.Sprintf("%d", limit),
+	}).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsError() {
+		return nil, errors.New(r.Status())
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg)
+	}
+
+	logger.Debug("%s Rubik 原始响应: %s", f.market.Name, string(r.Body()))
+
+	rows := resp.Data
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	return rows, nil
+}
+
+// periodOKX 统计周期，小时和天使用大写单位，如 1H、1D
+func periodOKX(iv schema.Interval) string {
+	m := map[schema.Interval]string{schema.Interval5m: "5m", schema.Interval15m: "15m", schema.Interval30m: "30m", schema.Interval1h: "1H", schema.Interval4h: "4H", schema.Interval1d: "1D"}
+	if v, ok := m[iv]; ok {
+		return v
+	}
+	return "5m"
+}
@@ -0,0 +1,1464 @@
+package swap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/interfaces"
+	"github.com/kingsmao/exchange-connector/pkg/logger"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+const (
+	okxWSBase = "wss://ws.okx.com:8443/ws/v5/public"
+
+	channelKlinePrefix = "candle" // candle1m、candle1H 等，周期见 klineIntervals
+	channelTrade       = "trades"
+	channelBBO         = "bbo-tbt" // 最优一档，逐笔推送
+	channelTicker      = "tickers" // 24小时行情，最快100ms推送一次
+	channelDepth       = "books"   // 首次推送400档全量，之后增量推送
+
+	// 资金费相关频道，订阅时三个频道一起订阅并合并写入缓存
+	channelMarkPrice   = "mark-price"    // 标记价格
+	channelFundingRate = "funding-rate"  // 资金费率与下次结算时间
+	channelIndexTicker = "index-tickers" // 指数价格，instId 为指数名称，如 BTC-USDT
+
+	channelOpenInterest = "open-interest" // 持仓量，约3秒推送一次
+
+	// 强平订单按产品类型订阅，推送全部永续合约，按已订阅合约过滤；每个合约每秒最多推送一条
+	channelLiquidation = "liquidation-orders"
+	instTypeSwap       = "SWAP"
+
+	// OKX 30秒内没有数据会断开连接，空闲超过pingInterval主动发送 "ping"
+	pingInterval = 20 * time.Second
+	writeWait    = 10 * time.Second
+
+	// 输出到缓存的最大深度档位
+	maxDepthLevels = 100
+
+	// 合约面值获取失败后的重试间隔
+	ctValRetryInterval = 10 * time.Second
+
+	// 校验和取买卖各前25档
+	checksumLevels = 25
+)
+
+// klineIntervals schema.Interval 到 OKX K线周期的映射，小时和天使用大写单位，日线使用 UTC 零点对齐的 1Dutc
+var klineIntervals = map[schema.Interval]string{
+	schema.Interval1m:  "1m",
+	schema.Interval3m:  "3m",
+	schema.Interval5m:  "5m",
+	schema.Interval15m: "15m",
+	schema.Interval30m: "30m",
+	schema.Interval1h:  "1H",
+	schema.Interval4h:  "4H",
+	schema.Interval1d:  "1Dutc",
+}
+
+type okxArg struct {
+	Channel  string `json:"channel"`
+	InstId   string `json:"instId,omitempty"`
+	InstType string `json:"instType,omitempty"`
+}
+
+// okxTradeData trades 频道推送数据
+type okxTradeData struct {
+	InstId  string `json:"instId"`
+	TradeId string `json:"tradeId"`
+	Px      string `json:"px"`
+	Sz      string `json:"sz"`
+	Side    string `json:"side"` // 主动成交方向 buy/sell
+	Ts      string `json:"ts"`
+}
+
+// okxBBOData bbo-tbt 频道推送数据，档位格式 [价格, 数量, 0, 订单数]
+type okxBBOData struct {
+	Asks  [][]string `json:"asks"`
+	Bids  [][]string `json:"bids"`
+	Ts    string     `json:"ts"`
+	SeqId int64      `json:"seqId"`
+}
+
+type okxSubscriptionMessage struct {
+	Op   string   `json:"op"`
+	Args []okxArg `json:"args"`
+}
+
+// okxBookData 是 books 频道单条推送数据
+type okxBookData struct {
+	Asks      [][]string `json:"asks"` // [价格, 张数, 废弃字段, 订单数]
+	Bids      [][]string `json:"bids"`
+	Ts        string     `json:"ts"`
+	Checksum  int64      `json:"checksum"`
+	PrevSeqId int64      `json:"prevSeqId"`
+	SeqId     int64      `json:"seqId"`
+}
+
+// bookLevel 保留交易所原始字符串，校验和必须使用推送中的原始格式计算
+type bookLevel struct {
+	price decimal.Decimal
+	qty   decimal.Decimal // 张数
+	rawPx string
+	rawSz string
+}
+
+// orderBook 本地订单簿，key 为规范化后的价格，数量单位为张
+type orderBook struct {
+	seqId int64
+	bids  map[string]bookLevel
+	asks  map[string]bookLevel
+}
+
+// WS implements WSConnector for OKX perpetual swaps of one Market.
+type WS struct {
+	market Market
+	name   string // 日志前缀
+
+	dialer  *websocket.Dialer
+	conn    *websocket.Conn
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla websocket 不支持并发写
+	cache   *cache.MemoryCache
+	gaps    *cache.KlineGapFiller // 写入K线，重连后补齐缺失的K线
+	subs    interfaces.SubscriptionManager
+	rest    *REST
+
+	// per-instId local order books
+	orderBooks map[string]*orderBook
+
+	// 合约面值开始后台加载或加载失败的时间，用于限制重试频率
+	ctValPending map[string]time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connection health monitoring
+	healthMu    sync.RWMutex
+	lastMessage time.Time
+	lastPing    time.Time
+
+	// 重连相关
+	reconnectMu    sync.Mutex
+	reconnectCount int
+	reconnecting   bool
+}
+
+func NewWS(market Market, c *cache.MemoryCache, subs interfaces.SubscriptionManager, rest *REST) *WS {
+	d := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: false},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WS{
+		market:       market,
+		name:         market.Name + " WS",
+		dialer:       d,
+		cache:        c,
+		gaps:         cache.NewKlineGapFiller(c, rest.GetKlines),
+		subs:         subs,
+		rest:         rest,
+		orderBooks:   make(map[string]*orderBook),
+		ctValPending: make(map[string]time.Time),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+func (f *WS) Connect(ctx context.Context) error {
+	f.mu.Lock()
+	if f.conn != nil {
+		f.mu.Unlock()
+		logger.Info("%s 已连接，跳过连接", f.name)
+		return nil
+	}
+
+	logger.Info("%s 开始连接...", f.name)
+	conn, _, err := f.dialer.DialContext(ctx, okxWSBase, nil)
+	if err != nil {
+		f.mu.Unlock()
+		logger.Error("%s 连接失败: %v", f.name, err)
+		return err
+	}
+	conn.SetReadLimit(1024 * 1024) // books 首次推送400档全量
+	f.conn = conn
+	f.mu.Unlock()
+
+	now := time.Now()
+	f.healthMu.Lock()
+	f.lastMessage = now
+	f.lastPing = now
+	f.healthMu.Unlock()
+
+	logger.Info("%s 连接成功", f.name)
+
+	// 重连时自动恢复订阅，失败时断开连接，由调用方重试
+	if err := f.applySubscriptions(ctx); err != nil {
+		logger.Error("%s 恢复订阅失败: %v", f.name, err)
+		f.mu.Lock()
+		if f.conn == conn {
+			conn.Close()
+			f.conn = nil
+		}
+		f.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (f *WS) Close() error {
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn != nil {
+		err := f.conn.Close()
+		f.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (f *WS) SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	if err := checkKlineIntervals(intervals); err != nil {
+		return err
+	}
+
+	newlyAdded := f.subs.SubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 kline，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 kline: %v", f.name, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildKlineMessage("subscribe", newlyAdded))
+}
+
+// UnsubscribeKline 只退订K线频道的指定周期，未指定周期时退订全部周期
+func (f *WS) UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error {
+	removed := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols), intervals...)
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅 kline，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订 kline: %v", f.name, removed)
+	f.gaps.Forget(removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildKlineMessage("unsubscribe", removed))
+}
+
+func (f *WS) SubscribeDepth(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeDepthSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 depth，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 depth: %v", f.name, newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractValues(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("subscribe", channelDepth, newlyAdded))
+}
+
+func (f *WS) UnsubscribeDepth(ctx context.Context, symbols []string) error {
+	return f.unsubscribe(ctx, symbols, "depth")
+}
+
+func (f *WS) SubscribeTrades(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTradeSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 trades，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 trades: %v", f.name, newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractValues(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("subscribe", channelTrade, newlyAdded))
+}
+
+func (f *WS) UnsubscribeTrades(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTradeSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅 trades，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订 trades: %v", f.name, removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("unsubscribe", channelTrade, removed))
+}
+
+func (f *WS) SubscribeBookTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 bbo，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 bbo: %v", f.name, newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractValues(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("subscribe", channelBBO, newlyAdded))
+}
+
+func (f *WS) UnsubscribeBookTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeBookTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅 bbo，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订 bbo: %v", f.name, removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("unsubscribe", channelBBO, removed))
+}
+
+func (f *WS) SubscribeTicker(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeTickerSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 tickers，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 tickers: %v", f.name, newlyAdded)
+	if f.market.inverse() {
+		// 币本位成交额由张数按面值换算，订阅时预先加载合约面值
+		f.loadContractValues(ctx, newlyAdded)
+	}
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("subscribe", channelTicker, newlyAdded))
+}
+
+func (f *WS) UnsubscribeTicker(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeTickerSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅 tickers，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订 tickers: %v", f.name, removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("unsubscribe", channelTicker, removed))
+}
+
+func (f *WS) SubscribeFunding(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeFundingSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅资金费率，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅资金费率: %v", f.name, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildFundingMessage("subscribe", newlyAdded))
+}
+
+func (f *WS) UnsubscribeFunding(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeFundingSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅资金费率，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订资金费率: %v", f.name, removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildFundingMessage("unsubscribe", removed))
+}
+
+func (f *WS) SubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 open-interest，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 open-interest: %v", f.name, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("subscribe", channelOpenInterest, newlyAdded))
+}
+
+func (f *WS) UnsubscribeOpenInterest(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeOpenInterestSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅 open-interest，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订 open-interest: %v", f.name, removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, buildMessage("unsubscribe", channelOpenInterest, removed))
+}
+
+func (f *WS) SubscribeLiquidations(ctx context.Context, symbols []string) error {
+	newlyAdded := f.subs.SubscribeLiquidationSymbols(upperSymbols(symbols))
+	if len(newlyAdded) == 0 {
+		logger.Info("%s 所有币对都已订阅 liquidation-orders，跳过订阅请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 新增订阅 liquidation-orders: %v", f.name, newlyAdded)
+	// 推送中的数量为张数，订阅时预先加载合约面值
+	f.loadContractValues(ctx, newlyAdded)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，订阅状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	// 频道按产品类型订阅，已有其它合约订阅时无需重复发送
+	if len(f.subs.GetLiquidationSymbols()) > len(newlyAdded) {
+		return nil
+	}
+	return f.SendMessage(ctx, buildLiquidationMessage("subscribe"))
+}
+
+func (f *WS) UnsubscribeLiquidations(ctx context.Context, symbols []string) error {
+	removed := f.subs.UnsubscribeLiquidationSymbols(upperSymbols(symbols))
+	if len(removed) == 0 {
+		logger.Info("%s 所有币对都未订阅 liquidation-orders，跳过退订请求", f.name)
+		return nil
+	}
+
+	logger.Info("%s 退订 liquidation-orders: %v", f.name, removed)
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+	// 仍有合约订阅时保留频道，推送按订阅集合过滤
+	if len(f.subs.GetLiquidationSymbols()) > 0 {
+		return nil
+	}
+	return f.SendMessage(ctx, buildLiquidationMessage("unsubscribe"))
+}
+
+// unsubscribe 订阅管理器按币对统一退订 kline 和 depth，这里同步退订服务端的全部K线周期和 depth 频道
+func (f *WS) unsubscribe(ctx context.Context, symbols []string, kind string) error {
+	klines := f.subs.UnsubscribeKlineSymbols(upperSymbols(symbols))
+	removed := dedupe(f.subs.UnsubscribeSymbols(upperSymbols(symbols)))
+	if len(removed) == 0 && len(klines) == 0 {
+		logger.Info("%s 所有币对都未订阅 %s，跳过退订请求", f.name, kind)
+		return nil
+	}
+
+	logger.Info("%s 退订 %s: %v", f.name, kind, removed)
+
+	f.mu.Lock()
+	for _, instId := range removed {
+		delete(f.orderBooks, instId)
+	}
+	f.mu.Unlock()
+
+	if !f.isConnected() {
+		logger.Warn("%s 未连接，退订状态已保存，连接后将自动应用", f.name)
+		return nil
+	}
+
+	msg := buildKlineMessage("unsubscribe", klines)
+	msg.Args = append(msg.Args, buildMessage("unsubscribe", channelDepth, removed).Args...)
+	return f.SendMessage(ctx, msg)
+}
+
+// SendMessage sends a message to WebSocket server
+func (f *WS) SendMessage(ctx context.Context, message interface{}) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("WebSocket connection not established")
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("%s 序列化消息失败: %v", f.name, err)
+		return err
+	}
+
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	f.writeMu.Unlock()
+	if err != nil {
+		logger.Error("%s 发送消息失败: %v", f.name, err)
+		return err
+	}
+
+	logger.Info("%s SendMessage: %s", f.name, string(data))
+	return nil
+}
+
+func (f *WS) applySubscriptions(ctx context.Context) error {
+	// 数量以张数推送的频道需要合约面值，在发送订阅前加载，避免在读协程中请求 REST
+	instIds := append(append(append(f.subs.GetDepthSymbols(), f.subs.GetTradeSymbols()...),
+		f.subs.GetBookTickerSymbols()...), f.subs.GetLiquidationSymbols()...)
+	if f.market.inverse() {
+		instIds = append(instIds, f.subs.GetTickerSymbols()...)
+	}
+	f.loadContractValues(ctx, instIds)
+
+	msg := buildKlineMessage("subscribe", f.subs.GetKlineSubscriptions())
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelDepth, f.subs.GetDepthSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelTrade, f.subs.GetTradeSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelBBO, f.subs.GetBookTickerSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelTicker, f.subs.GetTickerSymbols()).Args...)
+	msg.Args = append(msg.Args, buildFundingMessage("subscribe", f.subs.GetFundingSymbols()).Args...)
+	msg.Args = append(msg.Args, buildMessage("subscribe", channelOpenInterest, f.subs.GetOpenInterestSymbols()).Args...)
+	if len(f.subs.GetLiquidationSymbols()) > 0 {
+		msg.Args = append(msg.Args, buildLiquidationMessage("subscribe").Args...)
+	}
+	if len(msg.Args) == 0 {
+		logger.Info("%s 无订阅", f.name)
+		return nil
+	}
+	return f.SendMessage(ctx, msg)
+}
+
+// resubscribeDepth 丢弃本地订单簿并重新订阅 books 频道，服务端会重新推送全量快照
+func (f *WS) resubscribeDepth(instId string) {
+	f.mu.Lock()
+	delete(f.orderBooks, instId)
+	f.mu.Unlock()
+
+	if err := f.SendMessage(f.ctx, buildMessage("unsubscribe", channelDepth, []string{instId})); err != nil {
+		logger.Error("%s 退订深度失败 %s: %v", f.name, instId, err)
+		return
+	}
+	if err := f.SendMessage(f.ctx, buildMessage("subscribe", channelDepth, []string{instId})); err != nil {
+		logger.Error("%s 重新订阅深度失败 %s: %v", f.name, instId, err)
+	}
+}
+
+// buildKlineMessage 按币对和周期构建K线订阅/退订消息
+func buildKlineMessage(op string, subs []schema.KlineSubscription) *okxSubscriptionMessage {
+	msg := &okxSubscriptionMessage{Op: op}
+	for _, sub := range subs {
+		msg.Args = append(msg.Args, okxArg{Channel: channelKlinePrefix + klineIntervals[sub.Interval], InstId: sub.Symbol})
+	}
+	return msg
+}
+
+// klineIntervalOf 由K线频道名解析周期，如 candle1H -> 1h
+func klineIntervalOf(channel string) (schema.Interval, bool) {
+	bar := strings.TrimPrefix(channel, channelKlinePrefix)
+	for interval, v := range klineIntervals {
+		if v == bar {
+			return interval, true
+		}
+	}
+	return "", false
+}
+
+// checkKlineIntervals 校验K线周期，存在不支持的周期时整体拒绝
+func checkKlineIntervals(intervals []schema.Interval) error {
+	for _, interval := range intervals {
+		if _, ok := klineIntervals[interval]; !ok {
+			return fmt.Errorf("unsupported kline interval %s for okx", interval)
+		}
+	}
+	return nil
+}
+
+func buildMessage(op, channel string, instIds []string) *okxSubscriptionMessage {
+	msg := &okxSubscriptionMessage{Op: op}
+	for _, instId := range instIds {
+		msg.Args = append(msg.Args, okxArg{Channel: channel, InstId: instId})
+	}
+	return msg
+}
+
+// buildLiquidationMessage 构造永续合约强平订单频道的订阅参数
+func buildLiquidationMessage(op string) *okxSubscriptionMessage {
+	return &okxSubscriptionMessage{Op: op, Args: []okxArg{{Channel: channelLiquidation, InstType: instTypeSwap}}}
+}
+
+// buildFundingMessage 为每个合约构造标记价格、资金费率和指数价格三个频道的订阅参数
+func buildFundingMessage(op string, instIds []string) *okxSubscriptionMessage {
+	msg := &okxSubscriptionMessage{Op: op}
+	for _, instId := range instIds {
+		msg.Args = append(msg.Args,
+			okxArg{Channel: channelMarkPrice, InstId: instId},
+			okxArg{Channel: channelFundingRate, InstId: instId},
+			okxArg{Channel: channelIndexTicker, InstId: indexName(instId)},
+		)
+	}
+	return msg
+}
+
+// indexName 永续合约对应的指数名称，BTC-USDT-SWAP -> BTC-USDT
+func indexName(instId string) string {
+	return strings.TrimSuffix(instId, "-SWAP")
+}
+
+func upperSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		out[i] = strings.ToUpper(symbol)
+	}
+	return out
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (f *WS) isConnected() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.conn != nil
+}
+
+// StartReading starts read loop, handling heartbeats & reconnection internally
+func (f *WS) StartReading(ctx context.Context) error {
+	logger.Info("%s 开始读取消息...", f.name)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("%s 上下文取消", f.name)
+				return
+			case <-f.ctx.Done():
+				logger.Info("%s 已关闭", f.name)
+				return
+			default:
+			}
+
+			f.mu.RLock()
+			conn := f.conn
+			f.mu.RUnlock()
+
+			if conn == nil {
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if f.ctx.Err() != nil || ctx.Err() != nil {
+					return
+				}
+				logger.Error("%s 读取消息失败: %v", f.name, err)
+				f.reconnect(ctx)
+				continue
+			}
+
+			f.healthMu.Lock()
+			f.lastMessage = time.Now()
+			f.healthMu.Unlock()
+
+			f.handleRawMessage(message)
+		}
+	}()
+
+	return nil
+}
+
+func (f *WS) handleRawMessage(message []byte) {
+	// OKX 心跳响应为纯文本 "pong"
+	if string(message) == "pong" {
+		logger.Debug("%s 收到 pong", f.name)
+		return
+	}
+
+	logger.Debug("%s 收到原始消息: %s", f.name, string(message))
+
+	var msg struct {
+		Event  string          `json:"event"`
+		Code   string          `json:"code"`
+		Msg    string          `json:"msg"`
+		Action string          `json:"action"`
+		Arg    okxArg          `json:"arg"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logger.Error("%s 解析原始消息失败: %v", f.name, err)
+		return
+	}
+
+	switch msg.Event {
+	case "":
+	case "error":
+		logger.Error("%s 订阅错误: code=%s, msg=%s", f.name, msg.Code, msg.Msg)
+		return
+	default:
+		logger.Info("%s 收到事件: %s %s %s", f.name, msg.Event, msg.Arg.Channel, msg.Arg.InstId)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(msg.Arg.Channel, channelKlinePrefix):
+		interval, ok := klineIntervalOf(msg.Arg.Channel)
+		if !ok {
+			logger.Warn("%s 未知K线频道: %s", f.name, msg.Arg.Channel)
+			return
+		}
+		f.handleKline(msg.Arg.InstId, interval, msg.Data)
+	case msg.Arg.Channel == channelDepth:
+		f.handleDepth(msg.Arg.InstId, msg.Action, msg.Data)
+	case msg.Arg.Channel == channelTrade:
+		f.handleTrade(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelBBO:
+		f.handleBBO(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelTicker:
+		f.handleTicker(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelMarkPrice:
+		f.handleMarkPrice(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelFundingRate:
+		f.handleFundingRate(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelIndexTicker:
+		f.handleIndexTicker(msg.Arg.InstId+"-SWAP", msg.Data)
+	case msg.Arg.Channel == channelOpenInterest:
+		f.handleOpenInterest(msg.Arg.InstId, msg.Data)
+	case msg.Arg.Channel == channelLiquidation:
+		f.handleLiquidation(msg.Data)
+	default:
+		logger.Debug("%s 未知频道: %s", f.name, msg.Arg.Channel)
+	}
+}
+
+// handleTrade 处理公共成交，side 为主动成交方向，sz 为合约张数，按合约面值换算为基础币数量和计价币金额
+func (f *WS) handleTrade(instId string, data json.RawMessage) {
+	var rows []okxTradeData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析trades失败: %v", f.name, err)
+		return
+	}
+
+	ctVal, ok := f.contractValue(instId)
+	if !ok {
+		return
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Px)
+		sz, _ := decimal.NewFromString(row.Sz)
+		quantity := f.market.toBase(sz, ctVal, price)
+		quoteQty := f.market.toQuote(sz, ctVal, price)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+
+		side := schema.OrderSideBuy
+		if row.Side == "sell" {
+			side = schema.OrderSideSell
+		}
+
+		f.cache.AddTrade(schema.Trade{
+			Exchange:  schema.OKX,
+			Market:    f.market.Type,
+			Symbol:    instId,
+			TradeID:   row.TradeId,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			QuoteQty:  quoteQty,
+			Timestamp: time.UnixMilli(ts),
+		})
+	}
+}
+
+// handleBBO 处理最优一档推送，数量为合约张数，按合约面值换算为基础币数量
+func (f *WS) handleBBO(instId string, data json.RawMessage) {
+	var rows []okxBBOData
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析bbo失败: %v", f.name, err)
+		return
+	}
+
+	ctVal, ok := f.contractValue(instId)
+	if !ok {
+		return
+	}
+
+	for _, row := range rows {
+		if len(row.Bids) == 0 || len(row.Asks) == 0 || len(row.Bids[0]) < 2 || len(row.Asks[0]) < 2 {
+			continue
+		}
+		bidPrice, _ := decimal.NewFromString(row.Bids[0][0])
+		askPrice, _ := decimal.NewFromString(row.Asks[0][0])
+		bidSz, _ := decimal.NewFromString(row.Bids[0][1])
+		askSz, _ := decimal.NewFromString(row.Asks[0][1])
+		bidQty := f.market.toBase(bidSz, ctVal, bidPrice)
+		askQty := f.market.toBase(askSz, ctVal, askPrice)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+
+		f.cache.SetBookTicker(schema.BookTicker{
+			Exchange:     schema.OKX,
+			Market:       f.market.Type,
+			Symbol:       instId,
+			BidPrice:     bidPrice,
+			BidQty:       bidQty,
+			AskPrice:     askPrice,
+			AskQty:       askQty,
+			UpdatedAt:    time.UnixMilli(ts),
+			LastUpdateId: strconv.FormatInt(row.SeqId, 10),
+		})
+	}
+}
+
+// handleTicker 处理24小时行情推送，volCcy24h 为基础币成交量；U本位合约不提供计价币成交额，币本位合约 vol24h 张数按面值换算 USD 成交额
+func (f *WS) handleTicker(instId string, data json.RawMessage) {
+	var rows []schema.OKXTicker
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析tickers失败: %v", f.name, err)
+		return
+	}
+
+	var ctVal decimal.Decimal
+	if f.market.inverse() {
+		var ok bool
+		if ctVal, ok = f.contractValue(instId); !ok {
+			return
+		}
+	}
+
+	for _, row := range rows {
+		price, _ := decimal.NewFromString(row.Last)
+		open, _ := decimal.NewFromString(row.Open24h)
+		high, _ := decimal.NewFromString(row.High24h)
+		low, _ := decimal.NewFromString(row.Low24h)
+		volume, _ := decimal.NewFromString(row.VolCcy24h)
+		var quoteVolume decimal.Decimal
+		if f.market.inverse() {
+			contracts, _ := decimal.NewFromString(row.Vol24h)
+			quoteVolume = contracts.Mul(ctVal)
+		}
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+
+		f.cache.SetTicker(schema.Ticker{
+			Exchange:  schema.OKX,
+			Market:    f.market.Type,
+			Symbol:    instId,
+			Price:     price,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Volume:    volume,
+			QuoteVol:  quoteVolume,
+			Timestamp: time.UnixMilli(ts),
+		})
+	}
+}
+
+// fundingInfo 读取缓存中的资金费信息，三个频道分别推送，各自只更新对应字段
+func (f *WS) fundingInfo(instId string) schema.FundingInfo {
+	info, ok := f.cache.GetFunding(schema.OKX, f.market.Type, instId)
+	if !ok {
+		info = schema.FundingInfo{Exchange: schema.OKX, Market: f.market.Type, Symbol: instId}
+	}
+	return info
+}
+
+func (f *WS) handleMarkPrice(instId string, data json.RawMessage) {
+	var rows []struct {
+		InstId string `json:"instId"`
+		MarkPx string `json:"markPx"`
+		Ts     string `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析mark-price失败: %v", f.name, err)
+		return
+	}
+
+	for _, row := range rows {
+		info := f.fundingInfo(instId)
+		info.MarkPrice, _ = decimal.NewFromString(row.MarkPx)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+		info.Timestamp = time.UnixMilli(ts)
+		f.cache.SetFunding(info)
+	}
+}
+
+// handleFundingRate 处理资金费率推送，fundingTime 为当期资金费的结算时间
+func (f *WS) handleFundingRate(instId string, data json.RawMessage) {
+	var rows []struct {
+		InstId      string `json:"instId"`
+		FundingRate string `json:"fundingRate"`
+		FundingTime string `json:"fundingTime"`
+		Ts          string `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析funding-rate失败: %v", f.name, err)
+		return
+	}
+
+	for _, row := range rows {
+		info := f.fundingInfo(instId)
+		info.FundingRate, _ = decimal.NewFromString(row.FundingRate)
+		if fundingTime, _ := strconv.ParseInt(row.FundingTime, 10, 64); fundingTime > 0 {
+			info.NextFundingTime = time.UnixMilli(fundingTime)
+		}
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+		info.Timestamp = time.UnixMilli(ts)
+		f.cache.SetFunding(info)
+	}
+}
+
+func (f *WS) handleIndexTicker(instId string, data json.RawMessage) {
+	var rows []struct {
+		InstId string `json:"instId"`
+		IdxPx  string `json:"idxPx"`
+		Ts     string `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析index-tickers失败: %v", f.name, err)
+		return
+	}
+
+	for _, row := range rows {
+		info := f.fundingInfo(instId)
+		info.IndexPrice, _ = decimal.NewFromString(row.IdxPx)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+		info.Timestamp = time.UnixMilli(ts)
+		f.cache.SetFunding(info)
+	}
+}
+
+// handleOpenInterest 处理持仓量推送，oiCcy 为基础币数量，oiUsd 为USD价值
+func (f *WS) handleOpenInterest(instId string, data json.RawMessage) {
+	var rows []struct {
+		InstId string `json:"instId"`
+		Oi     string `json:"oi"`
+		OiCcy  string `json:"oiCcy"`
+		OiUsd  string `json:"oiUsd"`
+		Ts     string `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析open-interest失败: %v", f.name, err)
+		return
+	}
+
+	for _, row := range rows {
+		openInterest, _ := decimal.NewFromString(row.OiCcy)
+		value, _ := decimal.NewFromString(row.OiUsd)
+		ts, _ := strconv.ParseInt(row.Ts, 10, 64)
+		f.cache.SetOpenInterest(schema.OpenInterest{
+			Exchange:     schema.OKX,
+			Market:       f.market.Type,
+			Symbol:       instId,
+			OpenInterest: openInterest,
+			Value:        value,
+			Timestamp:    time.UnixMilli(ts),
+		})
+	}
+}
+
+// handleLiquidation 处理强平订单推送，只分发已订阅的合约；bkPx 为破产价格，sz 为合约张数，按合约面值换算为基础币数量
+func (f *WS) handleLiquidation(data json.RawMessage) {
+	var rows []struct {
+		InstId  string `json:"instId"`
+		Details []struct {
+			Side    string `json:"side"`
+			PosSide string `json:"posSide"`
+			BkPx    string `json:"bkPx"`
+			Sz      string `json:"sz"`
+			Ts      string `json:"ts"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析liquidation-orders失败: %v", f.name, err)
+		return
+	}
+
+	subscribed := make(map[string]struct{})
+	for _, instId := range f.subs.GetLiquidationSymbols() {
+		subscribed[instId] = struct{}{}
+	}
+
+	for _, row := range rows {
+		if _, ok := subscribed[row.InstId]; !ok {
+			continue
+		}
+		ctVal, ok := f.contractValue(row.InstId)
+		if !ok {
+			continue
+		}
+
+		for _, detail := range row.Details {
+			price, _ := decimal.NewFromString(detail.BkPx)
+			sz, _ := decimal.NewFromString(detail.Sz)
+			quantity := f.market.toBase(sz, ctVal, price)
+			quoteQty := f.market.toQuote(sz, ctVal, price)
+			ts, _ := strconv.ParseInt(detail.Ts, 10, 64)
+
+			side := schema.OrderSideBuy
+			if detail.Side == "sell" {
+				side = schema.OrderSideSell
+			}
+
+			f.cache.PublishLiquidation(schema.Liquidation{
+				Exchange:  schema.OKX,
+				Market:    f.market.Type,
+				Symbol:    row.InstId,
+				Side:      side,
+				Price:     price,
+				Quantity:  quantity,
+				QuoteQty:  quoteQty,
+				Timestamp: time.UnixMilli(ts),
+			})
+		}
+	}
+}
+
+func (f *WS) handleKline(instId string, interval schema.Interval, data json.RawMessage) {
+	// [ts, o, h, l, c, vol(张), volCcy(基础币), volCcyQuote(计价币), confirm]
+	var rows [][]string
+	if err := json.Unmarshal(data, &rows); err != nil {
+		logger.Error("%s 解析kline失败: %v", f.name, err)
+		return
+	}
+
+	for _, row := range rows {
+		if len(row) < 9 {
+			logger.Warn("%s kline 字段不足: %v", f.name, row)
+			continue
+		}
+		ts, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			logger.Error("%s 解析kline时间失败: %v", f.name, err)
+			continue
+		}
+		open, _ := decimal.NewFromString(row[1])
+		high, _ := decimal.NewFromString(row[2])
+		low, _ := decimal.NewFromString(row[3])
+		close, _ := decimal.NewFromString(row[4])
+		volume, _ := decimal.NewFromString(row[6])
+		quoteVolume, _ := decimal.NewFromString(row[7])
+
+		openTime := time.UnixMilli(ts)
+		f.gaps.SetKline(schema.Kline{
+			Exchange:    schema.OKX,
+			Market:      f.market.Type,
+			Symbol:      instId,
+			Interval:    interval,
+			OpenTime:    openTime,
+			CloseTime:   openTime.Add(interval.Duration() - time.Millisecond),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       close,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			IsFinal:     row[8] == "1",
+			EventTime:   time.Now(),
+		})
+	}
+}
+
+// handleDepth 应用 books 频道的全量与增量推送：
+// 1. snapshot 重建本地订单簿
+// 2. update 要求 prevSeqId 等于本地 seqId
+// 3. 每次应用后按张数原始字符串校验 checksum，不一致时重新订阅获取新快照
+func (f *WS) handleDepth(instId, action string, data json.RawMessage) {
+	var books []okxBookData
+	if err := json.Unmarshal(data, &books); err != nil {
+		logger.Error("%s 解析depth失败: %v", f.name, err)
+		return
+	}
+
+	for _, book := range books {
+		f.mu.Lock()
+		var ob *orderBook
+		switch action {
+		case "snapshot":
+			ob = &orderBook{
+				seqId: book.SeqId,
+				bids:  make(map[string]bookLevel),
+				asks:  make(map[string]bookLevel),
+			}
+			applyLevels(ob.bids, book.Bids)
+			applyLevels(ob.asks, book.Asks)
+			f.orderBooks[instId] = ob
+			logger.Info("%s %s 深度快照已加载: seqId=%d, 买单%d档, 卖单%d档", f.name,
+				instId, book.SeqId, len(book.Bids), len(book.Asks))
+		case "update":
+			ob = f.orderBooks[instId]
+			if ob == nil {
+				// 尚未收到快照，等待快照
+				f.mu.Unlock()
+				return
+			}
+			if book.PrevSeqId != ob.seqId {
+				f.mu.Unlock()
+				logger.Warn("%s %s 序列号不连续: prevSeqId=%d, 本地seqId=%d，重新订阅", f.name,
+					instId, book.PrevSeqId, ob.seqId)
+				f.resubscribeDepth(instId)
+				return
+			}
+			applyLevels(ob.bids, book.Bids)
+			applyLevels(ob.asks, book.Asks)
+			ob.seqId = book.SeqId
+		default:
+			f.mu.Unlock()
+			logger.Warn("%s 未知深度动作: %s", f.name, action)
+			return
+		}
+
+		bids, asks := sortedLevels(ob)
+		f.mu.Unlock()
+
+		if local := checksum(bids, asks); int64(local) != book.Checksum {
+			logger.Warn("%s %s 校验和不一致: checksum=%d, 本地=%d，重新订阅", f.name,
+				instId, book.Checksum, local)
+			f.resubscribeDepth(instId)
+			return
+		}
+
+		ctVal, ok := f.contractValue(instId)
+		if !ok {
+			continue
+		}
+		f.cache.SetDepth(f.buildDepth(instId, bids, asks, ctVal, book.SeqId, book.Ts))
+	}
+}
+
+// applyLevels 应用档位更新，数量为0时删除该价位
+func applyLevels(side map[string]bookLevel, levels [][]string) {
+	for _, lv := range levels {
+		if len(lv) < 2 {
+			continue
+		}
+		price, err := decimal.NewFromString(lv[0])
+		if err != nil {
+			continue
+		}
+		qty, err := decimal.NewFromString(lv[1])
+		if err != nil {
+			continue
+		}
+		// 统一价格格式，避免 "100.10" 与 "100.1" 成为两个价位
+		priceStr := price.String()
+		if qty.IsZero() {
+			delete(side, priceStr)
+		} else {
+			side[priceStr] = bookLevel{price: price, qty: qty, rawPx: lv[0], rawSz: lv[1]}
+		}
+	}
+}
+
+// sortedLevels 返回排序后的档位：买单降序，卖单升序
+func sortedLevels(ob *orderBook) (bids, asks []bookLevel) {
+	bids = make([]bookLevel, 0, len(ob.bids))
+	for _, lv := range ob.bids {
+		bids = append(bids, lv)
+	}
+	asks = make([]bookLevel, 0, len(ob.asks))
+	for _, lv := range ob.asks {
+		asks = append(asks, lv)
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].price.GreaterThan(bids[j].price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].price.LessThan(asks[j].price) })
+	return bids, asks
+}
+
+// checksumString 按 OKX 规则拼接前25档：bid1价:bid1量:ask1价:ask1量:bid2价:...，合约数量为推送中的张数
+// 某一侧档位不足时跳过该侧
+func checksumString(bids, asks []bookLevel) string {
+	parts := make([]string, 0, checksumLevels*4)
+	for i := 0; i < checksumLevels; i++ {
+		if i < len(bids) {
+			parts = append(parts, bids[i].rawPx, bids[i].rawSz)
+		}
+		if i < len(asks) {
+			parts = append(parts, asks[i].rawPx, asks[i].rawSz)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// checksum 计算 CRC32 校验和，OKX 推送的是有符号32位整数
+func checksum(bids, asks []bookLevel) int32 {
+	return int32(crc32.ChecksumIEEE([]byte(checksumString(bids, asks))))
+}
+
+// contractValue 从 REST 客户端缓存读取合约面值，不在读协程中同步请求 REST；
+// 未加载时在后台加载并跳过本条推送，加载失败后 ctValRetryInterval 内不再重试
+func (f *WS) contractValue(instId string) (decimal.Decimal, bool) {
+	if ctVal, ok := f.rest.cachedContractValue(instId); ok {
+		return ctVal, true
+	}
+
+	f.mu.Lock()
+	pendingAt, pending := f.ctValPending[instId]
+	start := !pending || time.Since(pendingAt) >= ctValRetryInterval
+	if start {
+		f.ctValPending[instId] = time.Now()
+	}
+	f.mu.Unlock()
+	if start {
+		go f.loadContractValues(f.ctx, []string{instId})
+	}
+	return decimal.Zero, false
+}
+
+// loadContractValues 在订阅和连接时预先加载合约面值，已缓存的合约直接跳过
+func (f *WS) loadContractValues(ctx context.Context, instIds []string) {
+	for _, instId := range dedupe(instIds) {
+		if _, ok := f.rest.cachedContractValue(instId); ok {
+			continue
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := f.rest.contractValue(reqCtx, instId)
+		cancel()
+
+		f.mu.Lock()
+		if err != nil {
+			logger.Error("%s 获取合约面值失败 %s: %v", f.name, instId, err)
+			f.ctValPending[instId] = time.Now()
+		} else {
+			delete(f.ctValPending, instId)
+		}
+		f.mu.Unlock()
+	}
+}
+
+// buildDepth 将排序后的档位裁剪为缓存使用的深度，并把张数按各档价格换算为基础币数量
+func (f *WS) buildDepth(instId string, bids, asks []bookLevel, ctVal decimal.Decimal, seqId int64, ts string) schema.Depth {
+	convert := func(levels []bookLevel) []schema.PriceLevel {
+		if len(levels) > maxDepthLevels {
+			levels = levels[:maxDepthLevels]
+		}
+		out := make([]schema.PriceLevel, 0, len(levels))
+		for _, lv := range levels {
+			out = append(out, schema.PriceLevel{Price: lv.price, Quantity: f.market.toBase(lv.qty, ctVal, lv.price)})
+		}
+		return out
+	}
+
+	updatedAt := time.Now()
+	if ms, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		updatedAt = time.UnixMilli(ms)
+	}
+
+	return schema.Depth{
+		Exchange:     schema.OKX,
+		Market:       f.market.Type,
+		Symbol:       instId,
+		Bids:         convert(bids),
+		Asks:         convert(asks),
+		UpdatedAt:    updatedAt,
+		LastUpdateId: fmt.Sprintf("%d", seqId),
+	}
+}
+
+// HandlePing 处理服务端的 ping 帧
+func (f *WS) HandlePing(data []byte) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	f.writeMu.Lock()
+	defer f.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.PongMessage, data)
+}
+
+// SendPing 发送 OKX 文本心跳 "ping"，服务端回复 "pong"
+func (f *WS) SendPing(ctx context.Context) error {
+	f.mu.RLock()
+	conn := f.conn
+	f.mu.RUnlock()
+	if conn == nil {
+		return errors.New("connection not established")
+	}
+
+	f.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+	f.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	f.healthMu.Lock()
+	f.lastPing = time.Now()
+	f.healthMu.Unlock()
+	return nil
+}
+
+// StartHealthCheck 空闲时发送心跳，超时未收到消息则重连
+func (f *WS) StartHealthCheck(ctx context.Context) error {
+	ticker := time.NewTicker(schema.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("%s 健康检查停止", f.name)
+			return nil
+		case <-f.ctx.Done():
+			logger.Info("%s 健康检查停止", f.name)
+			return nil
+		case <-ticker.C:
+			f.checkConnectionHealth(ctx)
+		}
+	}
+}
+
+func (f *WS) checkConnectionHealth(ctx context.Context) {
+	if !f.isConnected() {
+		return
+	}
+
+	f.healthMu.RLock()
+	sinceMsg := time.Since(f.lastMessage)
+	sincePing := time.Since(f.lastPing)
+	f.healthMu.RUnlock()
+
+	if sinceMsg > schema.WebSocketTimeout {
+		logger.Warn("%s 长时间未收到消息 (%.2f秒)，尝试重连", f.name, sinceMsg.Seconds())
+		f.reconnect(ctx)
+		return
+	}
+
+	if sinceMsg > pingInterval && sincePing > pingInterval {
+		if err := f.SendPing(ctx); err != nil {
+			logger.Warn("%s ping失败: %v", f.name, err)
+			f.reconnect(ctx)
+		}
+	}
+}
+
+func (f *WS) reconnect(ctx context.Context) {
+	f.reconnectMu.Lock()
+	if f.reconnecting {
+		f.reconnectMu.Unlock()
+		return
+	}
+	f.reconnecting = true
+	f.reconnectMu.Unlock()
+
+	defer func() {
+		f.reconnectMu.Lock()
+		f.reconnecting = false
+		f.reconnectMu.Unlock()
+	}()
+
+	f.mu.Lock()
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+	// 重新订阅后服务端会推送新的全量快照
+	f.orderBooks = make(map[string]*orderBook)
+	f.mu.Unlock()
+
+	for {
+		f.reconnectMu.Lock()
+		f.reconnectCount++
+		reconnectCount := f.reconnectCount
+		f.reconnectMu.Unlock()
+
+		// 前N次：1秒、2秒、3秒...N秒递增
+		// 超过N次后：固定最大等待时间间隔
+		var waitTime time.Duration
+		if reconnectCount <= schema.ReconnectThreshold {
+			waitTime = time.Duration(reconnectCount) * time.Second
+		} else {
+			waitTime = schema.MaxReconnectWaitTime
+		}
+
+		logger.Warn("%s 第%d次重连，等待 %.0f 秒", f.name, reconnectCount, waitTime.Seconds())
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+
+		if err := f.Connect(ctx); err != nil {
+			logger.Error("%s 重连失败: %v", f.name, err)
+			continue
+		}
+
+		logger.Info("%s 重连成功", f.name)
+		// 补齐断线期间缺失的K线
+		f.gaps.Backfill(ctx, f.subs.GetKlineSubscriptions())
+		f.reconnectMu.Lock()
+		f.reconnectCount = 0
+		f.reconnectMu.Unlock()
+		return
+	}
+}
@@ -0,0 +1,275 @@
+package swap
+
+import (
+	"context"
+	"encoding/json"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kingsmao/exchange-connector/internal/cache"
+	"github.com/kingsmao/exchange-connector/pkg/schema"
+)
+
+// 标记价格、资金费率、指数价格分三个频道推送，合并写入同一条缓存
+func TestHandleFunding_MergesChannels(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewWS(USDT, c, cache.NewSubscriptionManager(), NewREST(USDT))
+
+	f.handleRawMessage([]byte(`{"arg":{"channel":"mark-price","instId":"BTC-USDT-SWAP"},"data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","markPx":"42310.6","ts":"1630049139746"}]}`))
+	f.handleRawMessage([]byte(`{"arg":{"channel":"funding-rate","instId":"BTC-USDT-SWAP"},"data":[{"fundingRate":"0.0001875391284828","fundingTime":"1700726400000","instId":"BTC-USDT-SWAP","instType":"SWAP","nextFundingTime":"1700755200000","ts":"1700724675402"}]}`))
+	f.handleRawMessage([]byte(`{"arg":{"channel":"index-tickers","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","idxPx":"42300.1","open24h":"0","high24h":"0","low24h":"0","sodUtc0":"0","sodUtc8":"0","ts":"1700724675500"}]}`))
+
+	info, ok := c.GetFunding(schema.OKX, schema.FUTURESUSDT, "BTC-USDT-SWAP")
+	if !ok {
+		t.Fatalf("funding not cached")
+	}
+	if info.MarkPrice.String() != "42310.6" || info.IndexPrice.String() != "42300.1" || info.FundingRate.String() != "0.0001875391284828" {
+		t.Fatalf("unexpected funding: %+v", info)
+	}
+	if info.NextFundingTime.UnixMilli() != 1700726400000 || info.Timestamp.UnixMilli() != 1700724675500 {
+		t.Fatalf("unexpected funding times: %+v", info)
+	}
+}
+
+func TestHandleOpenInterest(t *testing.T) {
+	c := cache.NewMemoryCache()
+	f := NewWS(USDT, c, cache.NewSubscriptionManager(), NewREST(USDT))
+
+	f.handleRawMessage([]byte(`{"arg":{"channel":"open-interest","instId":"BTC-USDT-SWAP"},"data":[{"instId":"BTC-USDT-SWAP","instType":"SWAP","oi":"2216113.01","oiCcy":"22161.1301","oiUsd":"937504853.3","ts":"1700724675402"}]}`))
+
+	oi, ok := c.GetOpenInterest(schema.OKX, schema.FUTURESUSDT, "BTC-USDT-SWAP")
+	if !ok {
+		t.Fatalf("open interest not cached")
+	}
+	if oi.OpenInterest.String() != "22161.1301" || oi.Value.String() != "937504853.3" || oi.Timestamp.UnixMilli() != 1700724675402 {
+		t.Fatalf("unexpected open interest: %+v", oi)
+	}
+}
+
+// 强平频道推送全部永续合约，只分发已订阅的合约，张数按合约面值换算
+func TestHandleLiquidation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","ctVal":"0.01","ctMult":"1","ctValCcy":"BTC"}]}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	rest := NewREST(USDT)
+	rest.http.SetBaseURL(srv.URL)
+	f := NewWS(USDT, c, cache.NewSubscriptionManager(), rest)
+	if err := f.SubscribeLiquidations(context.Background(), []string{"BTC-USDT-SWAP"}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	var got []schema.Liquidation
+	c.OnLiquidation(func(l schema.Liquidation) { got = append(got, l) })
+
+	f.handleRawMessage([]byte(`{"arg":{"channel":"liquidation-orders","instType":"SWAP"},"data":[{"details":[{"bkLoss":"0","bkPx":"42000","ccy":"","posSide":"long","side":"sell","sz":"30","ts":"1700724675402"}],"instFamily":"BTC-USDT","instId":"BTC-USDT-SWAP","instType":"SWAP","uly":"BTC-USDT"},{"details":[{"bkLoss":"0","bkPx":"2200","ccy":"","posSide":"short","side":"buy","sz":"5","ts":"1700724675403"}],"instFamily":"ETH-USDT","instId":"ETH-USDT-SWAP","instType":"SWAP","uly":"ETH-USDT"}]}`))
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 liquidation, got %+v", got)
+	}
+	l := got[0]
+	if l.Symbol != "BTC-USDT-SWAP" || l.Side != schema.OrderSideSell || l.Price.String() != "42000" ||
+		l.Quantity.String() != "0.3" || l.QuoteQty.String() != "12600" || l.Timestamp.UnixMilli() != 1700724675402 {
+		t.Fatalf("unexpected liquidation: %+v", l)
+	}
+}
+
+// newCtValServer 模拟 instruments 接口返回合约面值，并记录请求次数
+func newCtValServer(t *testing.T, requests *atomic.Int32) *REST {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","ctVal":"0.01","ctMult":"1","ctValCcy":"BTC"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	rest := NewREST(USDT)
+	rest.http.SetBaseURL(srv.URL)
+	return rest
+}
+
+func bookPush(bids, asks [][]string, prevSeqId, seqId, checksum int64) json.RawMessage {
+	data, _ := json.Marshal([]okxBookData{{
+		Bids: bids, Asks: asks, Ts: "1700000000000",
+		PrevSeqId: prevSeqId, SeqId: seqId, Checksum: checksum,
+	}})
+	return data
+}
+
+func crc(str string) int64 { return int64(int32(crc32.ChecksumIEEE([]byte(str)))) }
+
+// 校验和使用推送中的张数原始字符串，缓存中的数量按合约面值换算为基础币
+func TestHandleDepth_SeqIdAndChecksum(t *testing.T) {
+	var requests atomic.Int32
+	rest := newCtValServer(t, &requests)
+	c := cache.NewMemoryCache()
+	f := NewWS(USDT, c, cache.NewSubscriptionManager(), rest)
+	if err := f.SubscribeDepth(context.Background(), []string{"BTC-USDT-SWAP"}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	f.handleDepth("BTC-USDT-SWAP", "snapshot", bookPush(
+		[][]string{{"100.0", "10", "0", "1"}}, [][]string{{"101.0", "20", "0", "1"}},
+		-1, 10, crc("100.0:10:101.0:20")))
+	f.handleDepth("BTC-USDT-SWAP", "update", bookPush(
+		[][]string{{"100.5", "30", "0", "1"}}, nil,
+		10, 11, crc("100.5:30:101.0:20:100.0:10")))
+
+	d, ok := c.GetDepth(schema.OKX, schema.FUTURESUSDT, "BTC-USDT-SWAP")
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	if d.LastUpdateId != "11" || len(d.Bids) != 2 || d.Bids[0].Price.String() != "100.5" ||
+		d.Bids[0].Quantity.String() != "0.3" || d.Asks[0].Quantity.String() != "0.2" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+
+	// prevSeqId 与本地 seqId 不连续时丢弃本地订单簿
+	f.handleDepth("BTC-USDT-SWAP", "update", bookPush(
+		[][]string{{"100.5", "0", "0", "0"}}, nil,
+		12, 13, crc("101.0:20:100.0:10")))
+	if _, ok := f.orderBooks["BTC-USDT-SWAP"]; ok {
+		t.Fatalf("order book should be dropped after seqId gap")
+	}
+
+	// 校验和不一致时同样丢弃本地订单簿
+	f.handleDepth("BTC-USDT-SWAP", "snapshot", bookPush(
+		[][]string{{"100.0", "10", "0", "1"}}, [][]string{{"101.0", "20", "0", "1"}},
+		-1, 20, crc("100.0:10:101.0:20")))
+	f.handleDepth("BTC-USDT-SWAP", "update", bookPush(
+		[][]string{{"100.0", "5", "0", "1"}}, nil,
+		20, 21, 12345))
+	if _, ok := f.orderBooks["BTC-USDT-SWAP"]; ok {
+		t.Fatalf("order book should be dropped after checksum mismatch")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("ctVal should be loaded once at subscribe time, got %d requests", n)
+	}
+}
+
+// 合约面值未加载时读协程不阻塞请求 REST，在后台加载后恢复换算
+func TestHandleTrade_ContractValueLoadedInBackground(t *testing.T) {
+	var requests atomic.Int32
+	rest := newCtValServer(t, &requests)
+	c := cache.NewMemoryCache()
+	f := NewWS(USDT, c, cache.NewSubscriptionManager(), rest)
+
+	trade := []byte(`{"arg":{"channel":"trades","instId":"BTC-USDT-SWAP"},"data":[{"instId":"BTC-USDT-SWAP","tradeId":"1","px":"42000","sz":"30","side":"buy","ts":"1700000000000"}]}`)
+	f.handleRawMessage(trade)
+	if _, ok := c.GetTrades(schema.OKX, schema.FUTURESUSDT, "BTC-USDT-SWAP", 10); ok {
+		t.Fatalf("trade should be skipped before ctVal is loaded")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := rest.cachedContractValue("BTC-USDT-SWAP"); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	f.handleRawMessage(trade)
+	trades, ok := c.GetTrades(schema.OKX, schema.FUTURESUSDT, "BTC-USDT-SWAP", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached after ctVal loaded")
+	}
+	if tr := trades[0]; tr.Quantity.String() != "0.3" || tr.QuoteQty.String() != "12600" || tr.Side != schema.OrderSideBuy {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+}
+
+// 币本位合约面值以 USD 计，张数按各档价格换算为基础币数量，成交额为 张数 × 面值
+func TestInverse_ContractsToBase(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("instId") != "BTC-USD-SWAP" {
+			t.Errorf("unexpected instId: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USD-SWAP","ctVal":"100","ctMult":"1","ctValCcy":"USD"}]}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	rest := NewREST(Coin)
+	rest.http.SetBaseURL(srv.URL)
+	f := NewWS(Coin, c, cache.NewSubscriptionManager(), rest)
+	ctx := context.Background()
+	if err := f.SubscribeDepth(ctx, []string{"BTC-USD-SWAP"}); err != nil {
+		t.Fatalf("subscribe depth: %v", err)
+	}
+	if err := f.SubscribeTicker(ctx, []string{"BTC-USD-SWAP"}); err != nil {
+		t.Fatalf("subscribe ticker: %v", err)
+	}
+
+	f.handleDepth("BTC-USD-SWAP", "snapshot", bookPush(
+		[][]string{{"40000", "8", "0", "1"}}, [][]string{{"50000", "20", "0", "1"}},
+		-1, 10, crc("40000:8:50000:20")))
+	d, ok := c.GetDepth(schema.OKX, schema.FUTURESCOIN, "BTC-USD-SWAP")
+	if !ok {
+		t.Fatalf("depth not cached")
+	}
+	if d.Bids[0].Quantity.String() != "0.02" || d.Asks[0].Quantity.String() != "0.04" {
+		t.Fatalf("unexpected depth: %+v", d)
+	}
+
+	f.handleRawMessage([]byte(`{"arg":{"channel":"trades","instId":"BTC-USD-SWAP"},"data":[{"instId":"BTC-USD-SWAP","tradeId":"1","px":"40000","sz":"8","side":"sell","ts":"1700000000000"}]}`))
+	trades, ok := c.GetTrades(schema.OKX, schema.FUTURESCOIN, "BTC-USD-SWAP", 10)
+	if !ok || len(trades) != 1 {
+		t.Fatalf("trade not cached")
+	}
+	if tr := trades[0]; tr.Quantity.String() != "0.02" || tr.QuoteQty.String() != "800" || tr.Side != schema.OrderSideSell {
+		t.Fatalf("unexpected trade: %+v", tr)
+	}
+
+	f.handleRawMessage([]byte(`{"arg":{"channel":"tickers","instId":"BTC-USD-SWAP"},"data":[{"instType":"SWAP","instId":"BTC-USD-SWAP","last":"40000","open24h":"39000","high24h":"41000","low24h":"38000","vol24h":"1500","volCcy24h":"3.75","ts":"1700000000000"}]}`))
+	tk, ok := c.GetTicker(schema.OKX, schema.FUTURESCOIN, "BTC-USD-SWAP")
+	if !ok {
+		t.Fatalf("ticker not cached")
+	}
+	if tk.Volume.String() != "3.75" || tk.QuoteVol.String() != "150000" {
+		t.Fatalf("unexpected ticker: %+v", tk)
+	}
+}
+
+// 交易规则使用 OKX 服务器时间；U本位合约最小下单金额按 最小张数 × 面值 × 最新价 估算
+func TestGetExchangeInfo_ServerTimeAndMinNotional(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case apiV5PublicInstrument:
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[` +
+				`{"instId":"BTC-USDT-SWAP","uly":"BTC-USDT","settleCcy":"USDT","ctType":"linear","ctVal":"0.01","ctMult":"1","tickSz":"0.1","lotSz":"0.01","minSz":"0.01","maxLmtSz":"10000","state":"live"},` +
+				`{"instId":"BTC-USD-SWAP","uly":"BTC-USD","settleCcy":"BTC","ctType":"inverse","ctVal":"100","ctMult":"1","tickSz":"0.1","lotSz":"1","minSz":"1","maxLmtSz":"10000","state":"live"}]}`))
+		case apiV5PublicTime:
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ts":"1700000000123"}]}`))
+		case apiV5MarketTickers:
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","last":"40000"},{"instId":"BTC-USD-SWAP","last":"40010"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	rest := NewREST(USDT)
+	rest.http.SetBaseURL(srv.URL)
+	info, err := rest.GetExchangeInfo(context.Background())
+	if err != nil {
+		t.Fatalf("exchange info: %v", err)
+	}
+	if info.ServerTime.UnixMilli() != 1700000000123 {
+		t.Fatalf("unexpected server time: %s", info.ServerTime)
+	}
+	if len(info.Symbols) != 1 {
+		t.Fatalf("unexpected symbols: %+v", info.Symbols)
+	}
+	// 0.01 张 × 0.01 BTC × 40000 = 4 USDT
+	if s := info.Symbols[0]; s.MinQuantity != "0.0001" || s.MinNotional != "4" {
+		t.Fatalf("unexpected BTC-USDT-SWAP: %+v", s)
+	}
+}
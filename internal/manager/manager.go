@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/kingsmao/exchange-connector/internal/cache"
 	"github.com/kingsmao/exchange-connector/pkg/interfaces"
@@ -18,18 +20,29 @@ type ExchangeInfo struct {
 	Weight   int
 }
 
+// DefaultExchangeInfoRefreshInterval 交易规则默认刷新间隔
+const DefaultExchangeInfoRefreshInterval = time.Hour
+
 // Manager coordinates exchanges and exposes read APIs backed by cache.
 type Manager struct {
 	cache     *cache.MemoryCache
 	exchanges map[string]*ExchangeInfo
 	mu        sync.RWMutex
+
+	// 交易规则缓存，StartWS 时加载并按 infoInterval 定时刷新
+	exchangeInfo   *cache.ExchangeInfoCache
+	infoInterval   atomic.Int64 // time.Duration
+	infoRefreshing atomic.Bool
 }
 
 func NewManager() *Manager {
-	return &Manager{
-		cache:     cache.NewMemoryCache(),
-		exchanges: make(map[string]*ExchangeInfo),
+	m := &Manager{
+		cache:        cache.NewMemoryCache(),
+		exchanges:    make(map[string]*ExchangeInfo),
+		exchangeInfo: cache.NewExchangeInfoCache(),
 	}
+	m.infoInterval.Store(int64(DefaultExchangeInfoRefreshInterval))
+	return m
 }
 
 func (m *Manager) AddExchange(ex interfaces.Exchange, weight int) {
@@ -65,6 +78,7 @@ func (m *Manager) RemoveExchange(name schema.ExchangeName, market schema.MarketT
 
 	// 从map中删除
 	delete(m.exchanges, key)
+	m.exchangeInfo.Clear(name, market)
 	logger.Info("交易所 %s %s 已从manager中删除", name, market)
 
 	return nil
@@ -106,8 +120,89 @@ func (m *Manager) GetExchangeInfo(name schema.ExchangeName, market schema.Market
 
 func (m *Manager) Cache() *cache.MemoryCache { return m.cache }
 
+func (m *Manager) ExchangeInfoCache() *cache.ExchangeInfoCache { return m.exchangeInfo }
+
+// SetExchangeInfoRefreshInterval sets how often exchange info is reloaded, non-positive values restore the default
+func (m *Manager) SetExchangeInfoRefreshInterval(d time.Duration) {
+	if d <= 0 {
+		d = DefaultExchangeInfoRefreshInterval
+	}
+	m.infoInterval.Store(int64(d))
+}
+
+// LoadExchangeInfo loads exchange info of every added exchange whose cache entry is missing or expired,
+// force reloads all of them when force is true
+func (m *Manager) LoadExchangeInfo(ctx context.Context, force bool) {
+	m.mu.RLock()
+	exchanges := make([]interfaces.Exchange, 0, len(m.exchanges))
+	for _, exInfo := range m.exchanges {
+		if exInfo.Exchange.REST() != nil {
+			exchanges = append(exchanges, exInfo.Exchange)
+		}
+	}
+	m.mu.RUnlock()
+
+	expire := time.Duration(m.infoInterval.Load())
+	var wg sync.WaitGroup
+	for _, ex := range exchanges {
+		if !force && !m.exchangeInfo.IsExpired(ex.Name(), ex.Market(), expire) {
+			continue
+		}
+		wg.Add(1)
+		go func(ex interfaces.Exchange) {
+			defer wg.Done()
+			if err := m.exchangeInfo.Refresh(ctx, ex.Name(), ex.Market(), ex.REST()); err != nil {
+				logger.Warn("交易所 %s-%s 交易规则加载失败: %v", ex.Name(), ex.Market(), err)
+			}
+		}(ex)
+	}
+	wg.Wait()
+}
+
+// startExchangeInfoRefresh 启动交易规则定时刷新，同一时间只运行一个刷新循环，ctx 结束后退出
+func (m *Manager) startExchangeInfoRefresh(ctx context.Context) {
+	if !m.infoRefreshing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer m.infoRefreshing.Store(false)
+		for {
+			timer := time.NewTimer(time.Duration(m.infoInterval.Load()))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				m.LoadExchangeInfo(ctx, true)
+			}
+		}
+	}()
+}
+
+// GetSymbolInfo returns the cached exchange info of a formatted symbol
+func (m *Manager) GetSymbolInfo(name schema.ExchangeName, market schema.MarketType, symbol string) (schema.Symbol, bool) {
+	sym, ok := m.exchangeInfo.GetSymbol(name, market, symbol)
+	if !ok {
+		return schema.Symbol{}, false
+	}
+	return *sym, true
+}
+
+// ListSymbols returns all cached symbols of an exchange market
+func (m *Manager) ListSymbols(name schema.ExchangeName, market schema.MarketType) ([]schema.Symbol, bool) {
+	symbols, ok := m.exchangeInfo.GetAllSymbols(name, market)
+	if !ok {
+		return nil, false
+	}
+	return append([]schema.Symbol(nil), symbols...), true
+}
+
 // StartWS starts all exchange WS loops.
+// 连接前先加载尚未缓存或已过期的交易规则，并启动定时刷新
 func (m *Manager) StartWS(ctx context.Context) error {
+	m.LoadExchangeInfo(ctx, false)
+	m.startExchangeInfoRefresh(ctx)
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -180,14 +275,24 @@ func (m *Manager) StartWS(ctx context.Context) error {
 
 // Subscribe simple façade methods (kline/depth)
 
-func (m *Manager) SubscribeKline(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+// SubscribeKline 订阅K线，intervals 为空时订阅默认的1m周期，可一次订阅多个周期
+func (m *Manager) SubscribeKline(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string, intervals ...schema.Interval) error {
 	ex, ok := m.GetExchange(name, market)
 	if !ok || ex.WS() == nil {
 		return errors.New("ws exchange not found")
 	}
 
-	// 固定订阅1m K线数据
-	return ex.WS().SubscribeKline(ctx, symbols)
+	return ex.WS().SubscribeKline(ctx, symbols, intervals...)
+}
+
+// UnsubscribeKline 退订K线，intervals 为空时退订该币对的所有周期
+func (m *Manager) UnsubscribeKline(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string, intervals ...schema.Interval) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().UnsubscribeKline(ctx, symbols, intervals...)
 }
 
 func (m *Manager) SubscribeDepth(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
@@ -200,6 +305,240 @@ func (m *Manager) SubscribeDepth(ctx context.Context, name schema.ExchangeName,
 	return ex.WS().SubscribeDepth(ctx, symbols)
 }
 
+func (m *Manager) SubscribeTrades(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().SubscribeTrades(ctx, symbols)
+}
+
+func (m *Manager) UnsubscribeTrades(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().UnsubscribeTrades(ctx, symbols)
+}
+
+func (m *Manager) SubscribeBookTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().SubscribeBookTicker(ctx, symbols)
+}
+
+func (m *Manager) UnsubscribeBookTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().UnsubscribeBookTicker(ctx, symbols)
+}
+
+func (m *Manager) SubscribeTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().SubscribeTicker(ctx, symbols)
+}
+
+func (m *Manager) UnsubscribeTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	return ex.WS().UnsubscribeTicker(ctx, symbols)
+}
+
+// SubscribeFunding subscribes to mark price, index price and funding rate, only futures markets are supported
+func (m *Manager) SubscribeFunding(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	fws, ok := ex.WS().(interfaces.FuturesWSConnector)
+	if !ok {
+		return fmt.Errorf("funding not supported for %s %s", name, market)
+	}
+	return fws.SubscribeFunding(ctx, symbols)
+}
+
+func (m *Manager) UnsubscribeFunding(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	fws, ok := ex.WS().(interfaces.FuturesWSConnector)
+	if !ok {
+		return fmt.Errorf("funding not supported for %s %s", name, market)
+	}
+	return fws.UnsubscribeFunding(ctx, symbols)
+}
+
+// SubscribeOpenInterest subscribes to open interest, only connectors whose exchange pushes open interest are supported
+func (m *Manager) SubscribeOpenInterest(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	ows, ok := ex.WS().(interfaces.OpenInterestWSConnector)
+	if !ok {
+		return fmt.Errorf("open interest stream not supported for %s %s", name, market)
+	}
+	return ows.SubscribeOpenInterest(ctx, symbols)
+}
+
+func (m *Manager) UnsubscribeOpenInterest(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	ows, ok := ex.WS().(interfaces.OpenInterestWSConnector)
+	if !ok {
+		return fmt.Errorf("open interest stream not supported for %s %s", name, market)
+	}
+	return ows.UnsubscribeOpenInterest(ctx, symbols)
+}
+
+// SubscribeLiquidations subscribes to liquidation orders, events are delivered to handlers registered by OnLiquidation
+func (m *Manager) SubscribeLiquidations(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	lws, ok := ex.WS().(interfaces.LiquidationWSConnector)
+	if !ok {
+		return fmt.Errorf("liquidation stream not supported for %s %s", name, market)
+	}
+	return lws.SubscribeLiquidations(ctx, symbols)
+}
+
+func (m *Manager) UnsubscribeLiquidations(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.WS() == nil {
+		return errors.New("ws exchange not found")
+	}
+
+	lws, ok := ex.WS().(interfaces.LiquidationWSConnector)
+	if !ok {
+		return fmt.Errorf("liquidation stream not supported for %s %s", name, market)
+	}
+	return lws.UnsubscribeLiquidations(ctx, symbols)
+}
+
+// OnLiquidation registers a handler receiving every liquidation event of all exchanges
+func (m *Manager) OnLiquidation(h schema.LiquidationHandler) {
+	m.cache.OnLiquidation(h)
+}
+
+// OnKlineGapRepaired registers a handler called after klines missed during a WebSocket outage are backfilled via REST
+func (m *Manager) OnKlineGapRepaired(h schema.KlineGapHandler) {
+	m.cache.OnKlineGapRepaired(h)
+}
+
+// futuresREST returns the futures REST client of the exchange
+func (m *Manager) futuresREST(name schema.ExchangeName, market schema.MarketType) (interfaces.FuturesRESTClient, error) {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.REST() == nil {
+		return nil, errors.New("rest exchange not found")
+	}
+
+	frest, ok := ex.REST().(interfaces.FuturesRESTClient)
+	if !ok {
+		return nil, fmt.Errorf("futures data not supported for %s %s", name, market)
+	}
+	return frest, nil
+}
+
+// FetchOpenInterest fetches current open interest from REST API and caches the result
+func (m *Manager) FetchOpenInterest(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string) (schema.OpenInterest, error) {
+	frest, err := m.futuresREST(name, market)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+
+	oi, err := frest.GetOpenInterest(ctx, symbol)
+	if err != nil {
+		return schema.OpenInterest{}, err
+	}
+	m.cache.SetOpenInterest(oi)
+	return oi, nil
+}
+
+// FetchOpenInterestHistory fetches open interest history from REST API
+func (m *Manager) FetchOpenInterestHistory(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	frest, err := m.futuresREST(name, market)
+	if err != nil {
+		return nil, err
+	}
+	return frest.GetOpenInterestHistory(ctx, symbol, period, limit)
+}
+
+// FetchLongShortRatio fetches long/short account ratio history from REST API
+func (m *Manager) FetchLongShortRatio(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	frest, err := m.futuresREST(name, market)
+	if err != nil {
+		return nil, err
+	}
+	return frest.GetLongShortRatio(ctx, symbol, period, limit)
+}
+
+// FetchTicker fetches 24h ticker from REST API and caches the result
+func (m *Manager) FetchTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string) (schema.Ticker, error) {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.REST() == nil {
+		return schema.Ticker{}, errors.New("rest exchange not found")
+	}
+
+	ticker, err := ex.REST().GetTicker(ctx, symbol)
+	if err != nil {
+		return schema.Ticker{}, err
+	}
+	m.cache.SetTicker(ticker)
+	return ticker, nil
+}
+
+// FetchTickers fetches 24h tickers of all symbols from REST API and caches the results
+func (m *Manager) FetchTickers(ctx context.Context, name schema.ExchangeName, market schema.MarketType) ([]schema.Ticker, error) {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.REST() == nil {
+		return nil, errors.New("rest exchange not found")
+	}
+
+	tickers, err := ex.REST().GetTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tickers {
+		m.cache.SetTicker(t)
+	}
+	return tickers, nil
+}
+
+// FetchKlines fetches historical klines within [from, to] from REST API, oldest first.
+// 历史数据不写入缓存，避免覆盖实时K线序列
+func (m *Manager) FetchKlines(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval, from, to time.Time) ([]schema.Kline, error) {
+	ex, ok := m.GetExchange(name, market)
+	if !ok || ex.REST() == nil {
+		return nil, errors.New("rest exchange not found")
+	}
+	return ex.REST().GetKlines(ctx, symbol, interval, from, to)
+}
+
 // FetchDepth fetches depth data from REST API
 func (m *Manager) FetchDepth(ctx context.Context, market schema.MarketType, base, quote string, limit int) (schema.Depth, error) {
 	// Try to get from any available exchange for this market
@@ -221,15 +560,25 @@ func (m *Manager) FetchDepth(ctx context.Context, market schema.MarketType, base
 	return schema.Depth{}, errors.New("no exchange available for fetching depth")
 }
 
-// WatchKline returns kline data from WebSocket subscriptions
-func (m *Manager) WatchKline(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.Kline, bool) {
-	if klines, ok := m.cache.GetKline(exchange, market, symbol, "1m"); ok && len(klines) > 0 {
+// WatchKline returns kline data of the given interval from WebSocket subscriptions
+func (m *Manager) WatchKline(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval) (schema.Kline, bool) {
+	if klines, ok := m.cache.GetKline(exchange, market, symbol, interval); ok && len(klines) > 0 {
 		return klines[0], true // 返回最新的一条K线数据
 	}
 	// 如果没有找到数据，返回空K线
 	return schema.Kline{}, false
 }
 
+// WatchKlines returns the most recent limit klines of the given interval, oldest first
+func (m *Manager) WatchKlines(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval, limit int) ([]schema.Kline, bool) {
+	return m.cache.GetKlines(exchange, market, symbol, interval, limit)
+}
+
+// WatchKlinesRange returns cached klines whose open time falls within [from, to], oldest first
+func (m *Manager) WatchKlinesRange(exchange schema.ExchangeName, market schema.MarketType, symbol string, interval schema.Interval, from, to time.Time) ([]schema.Kline, bool) {
+	return m.cache.GetKlinesRange(exchange, market, symbol, interval, from, to)
+}
+
 // WatchDepth returns depth data from WebSocket subscriptions
 func (m *Manager) WatchDepth(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.Depth, bool) {
 	if depth, ok := m.cache.GetDepth(exchange, market, symbol); ok {
@@ -239,6 +588,34 @@ func (m *Manager) WatchDepth(exchange schema.ExchangeName, market schema.MarketT
 	return schema.Depth{}, false
 }
 
+// WatchTrades returns the most recent n public trades from WebSocket subscriptions
+func (m *Manager) WatchTrades(exchange schema.ExchangeName, market schema.MarketType, symbol string, n int) ([]schema.Trade, bool) {
+	if trades, ok := m.cache.GetTrades(exchange, market, symbol, n); ok && len(trades) > 0 {
+		return trades, true
+	}
+	return []schema.Trade{}, false
+}
+
+// WatchBookTicker returns the latest best bid/offer from WebSocket subscriptions
+func (m *Manager) WatchBookTicker(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.BookTicker, bool) {
+	return m.cache.GetBookTicker(exchange, market, symbol)
+}
+
+// WatchTicker returns the latest 24h ticker from WebSocket subscriptions
+func (m *Manager) WatchTicker(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.Ticker, bool) {
+	return m.cache.GetTicker(exchange, market, symbol)
+}
+
+// WatchFunding returns the latest mark price, index price and funding rate from WebSocket subscriptions
+func (m *Manager) WatchFunding(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.FundingInfo, bool) {
+	return m.cache.GetFunding(exchange, market, symbol)
+}
+
+// WatchOpenInterest returns the latest open interest from WebSocket subscriptions or REST fetches
+func (m *Manager) WatchOpenInterest(exchange schema.ExchangeName, market schema.MarketType, symbol string) (schema.OpenInterest, bool) {
+	return m.cache.GetOpenInterest(exchange, market, symbol)
+}
+
 // formatSymbol formats base and quote into exchange-specific symbol format
 func (m *Manager) formatSymbol(name schema.ExchangeName, market schema.MarketType, base, quote string) string {
 	switch name {
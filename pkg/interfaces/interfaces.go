@@ -12,27 +12,89 @@ import (
 
 // SubscriptionManager manages subscription state for WebSocket connections.
 type SubscriptionManager interface {
-	// SubscribeSymbols adds symbols to all subscriptions (kline, depth, etc.), returns newly added symbols
+	// SubscribeSymbols adds symbols to kline and depth subscriptions, returns newly added symbols
 	SubscribeSymbols(symbols []string) []string
 
-	// UnsubscribeSymbols removes symbols from all subscriptions, returns actually removed symbols
+	// UnsubscribeSymbols removes symbols from kline and depth subscriptions, returns actually removed symbols
 	UnsubscribeSymbols(symbols []string) []string
 
 	// GetSubscribedSymbols returns all currently subscribed symbols
 	GetSubscribedSymbols() []string
 
-	// SubscribeKlineSymbols adds symbols to kline subscription only
-	SubscribeKlineSymbols(symbols []string) []string
+	// SubscribeKlineSymbols adds symbols to kline subscription only, each symbol is subscribed on every given interval
+	// (schema.DefaultKlineInterval when none given), returns newly added symbol/interval pairs
+	SubscribeKlineSymbols(symbols []string, intervals ...schema.Interval) []schema.KlineSubscription
+
+	// UnsubscribeKlineSymbols removes the given intervals (all intervals when none given) from kline subscription only,
+	// returns actually removed symbol/interval pairs
+	UnsubscribeKlineSymbols(symbols []string, intervals ...schema.Interval) []schema.KlineSubscription
 
 	// SubscribeDepthSymbols adds symbols to depth subscription only
 	SubscribeDepthSymbols(symbols []string) []string
 
-	// GetKlineSymbols returns all currently subscribed kline symbols
+	// GetKlineSymbols returns all symbols with at least one subscribed kline interval
 	GetKlineSymbols() []string
 
+	// GetKlineSubscriptions returns all currently subscribed kline symbol/interval pairs
+	GetKlineSubscriptions() []schema.KlineSubscription
+
 	// GetDepthSymbols returns all currently subscribed depth symbols
 	GetDepthSymbols() []string
 
+	// SubscribeTradeSymbols adds symbols to trade subscription only, returns newly added symbols
+	SubscribeTradeSymbols(symbols []string) []string
+
+	// UnsubscribeTradeSymbols removes symbols from trade subscription only, returns actually removed symbols
+	UnsubscribeTradeSymbols(symbols []string) []string
+
+	// GetTradeSymbols returns all currently subscribed trade symbols
+	GetTradeSymbols() []string
+
+	// SubscribeBookTickerSymbols adds symbols to book ticker (best bid/offer) subscription only, returns newly added symbols
+	SubscribeBookTickerSymbols(symbols []string) []string
+
+	// UnsubscribeBookTickerSymbols removes symbols from book ticker subscription only, returns actually removed symbols
+	UnsubscribeBookTickerSymbols(symbols []string) []string
+
+	// GetBookTickerSymbols returns all currently subscribed book ticker symbols
+	GetBookTickerSymbols() []string
+
+	// SubscribeTickerSymbols adds symbols to 24h ticker subscription only, returns newly added symbols
+	SubscribeTickerSymbols(symbols []string) []string
+
+	// UnsubscribeTickerSymbols removes symbols from 24h ticker subscription only, returns actually removed symbols
+	UnsubscribeTickerSymbols(symbols []string) []string
+
+	// GetTickerSymbols returns all currently subscribed 24h ticker symbols
+	GetTickerSymbols() []string
+
+	// SubscribeFundingSymbols adds symbols to funding (mark/index price and funding rate) subscription only, returns newly added symbols
+	SubscribeFundingSymbols(symbols []string) []string
+
+	// UnsubscribeFundingSymbols removes symbols from funding subscription only, returns actually removed symbols
+	UnsubscribeFundingSymbols(symbols []string) []string
+
+	// GetFundingSymbols returns all currently subscribed funding symbols
+	GetFundingSymbols() []string
+
+	// SubscribeOpenInterestSymbols adds symbols to open interest subscription only, returns newly added symbols
+	SubscribeOpenInterestSymbols(symbols []string) []string
+
+	// UnsubscribeOpenInterestSymbols removes symbols from open interest subscription only, returns actually removed symbols
+	UnsubscribeOpenInterestSymbols(symbols []string) []string
+
+	// GetOpenInterestSymbols returns all currently subscribed open interest symbols
+	GetOpenInterestSymbols() []string
+
+	// SubscribeLiquidationSymbols adds symbols to liquidation subscription only, returns newly added symbols
+	SubscribeLiquidationSymbols(symbols []string) []string
+
+	// UnsubscribeLiquidationSymbols removes symbols from liquidation subscription only, returns actually removed symbols
+	UnsubscribeLiquidationSymbols(symbols []string) []string
+
+	// GetLiquidationSymbols returns all currently subscribed liquidation symbols
+	GetLiquidationSymbols() []string
+
 	// ClearAll clears all subscriptions
 	ClearAll()
 }
@@ -69,12 +131,27 @@ type WSConnector interface {
 	// SendMessage sends a message to WebSocket server
 	SendMessage(ctx context.Context, message interface{}) error
 
-	SubscribeKline(ctx context.Context, symbols []string) error
-	UnsubscribeKline(ctx context.Context, symbols []string) error
+	// SubscribeKline subscribes klines on the given intervals (schema.DefaultKlineInterval when none given),
+	// returns an error without subscribing anything when an interval is not supported by the exchange
+	SubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error
+	// UnsubscribeKline unsubscribes the given intervals, all subscribed intervals when none given
+	UnsubscribeKline(ctx context.Context, symbols []string, intervals ...schema.Interval) error
 
 	SubscribeDepth(ctx context.Context, symbols []string) error
 	UnsubscribeDepth(ctx context.Context, symbols []string) error
 
+	// SubscribeTrades subscribes to public trades, results are buffered in cache per symbol
+	SubscribeTrades(ctx context.Context, symbols []string) error
+	UnsubscribeTrades(ctx context.Context, symbols []string) error
+
+	// SubscribeBookTicker subscribes to best bid/offer updates, only the latest value is kept in cache
+	SubscribeBookTicker(ctx context.Context, symbols []string) error
+	UnsubscribeBookTicker(ctx context.Context, symbols []string) error
+
+	// SubscribeTicker subscribes to 24h rolling ticker updates, only the latest value is kept in cache
+	SubscribeTicker(ctx context.Context, symbols []string) error
+	UnsubscribeTicker(ctx context.Context, symbols []string) error
+
 	// StartReading starts read loop, handling heartbeats & reconnection internally.
 	StartReading(ctx context.Context) error
 
@@ -87,10 +164,40 @@ type WSConnector interface {
 	StartHealthCheck(ctx context.Context) error
 }
 
+// FuturesWSConnector defines WebSocket subscriptions only available on perpetual futures markets.
+type FuturesWSConnector interface {
+	// SubscribeFunding subscribes to mark price, index price and funding rate updates, only the latest value is kept in cache
+	SubscribeFunding(ctx context.Context, symbols []string) error
+	UnsubscribeFunding(ctx context.Context, symbols []string) error
+}
+
+// OpenInterestWSConnector defines open interest streaming, implemented only by futures connectors whose exchange pushes open interest.
+type OpenInterestWSConnector interface {
+	// SubscribeOpenInterest subscribes to open interest updates, only the latest value is kept in cache
+	SubscribeOpenInterest(ctx context.Context, symbols []string) error
+	UnsubscribeOpenInterest(ctx context.Context, symbols []string) error
+}
+
+// LiquidationWSConnector defines liquidation streaming, implemented only by futures connectors whose exchange pushes public liquidations.
+type LiquidationWSConnector interface {
+	// SubscribeLiquidations subscribes to liquidation orders, every event is published to handlers instead of being cached
+	SubscribeLiquidations(ctx context.Context, symbols []string) error
+	UnsubscribeLiquidations(ctx context.Context, symbols []string) error
+}
+
 // RESTClient defines HTTP APIs to fetch data.
 type RESTClient interface {
+	// GetTicker 获取单个币对的24小时行情
+	GetTicker(ctx context.Context, symbol string) (schema.Ticker, error)
+
+	// GetTickers 获取当前市场全部币对的24小时行情
+	GetTickers(ctx context.Context) ([]schema.Ticker, error)
+
 	GetDepth(ctx context.Context, symbol string, limit int) (schema.Depth, error)
 
+	// GetKlines 获取开盘时间在 [start, end] 内的历史K线，按交易所单次上限自动分页，去重后按开盘时间由旧到新排列
+	GetKlines(ctx context.Context, symbol string, interval schema.Interval, start, end time.Time) ([]schema.Kline, error)
+
 	// GetExchangeInfo 获取交易规则和交易对信息
 	GetExchangeInfo(ctx context.Context) (schema.ExchangeInfo, error)
 
@@ -100,6 +207,19 @@ type RESTClient interface {
 	// PlaceOrder(ctx context.Context, req any) (any, error)
 }
 
+// FuturesRESTClient defines HTTP APIs only available on futures markets.
+// 历史数据按时间由旧到新排列，period 取值受交易所限制（通常为 5m 到 1d）。
+type FuturesRESTClient interface {
+	// GetOpenInterest 获取合约当前持仓量
+	GetOpenInterest(ctx context.Context, symbol string) (schema.OpenInterest, error)
+
+	// GetOpenInterestHistory 获取合约持仓量历史
+	GetOpenInterestHistory(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error)
+
+	// GetLongShortRatio 获取多空账户比历史
+	GetLongShortRatio(ctx context.Context, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error)
+}
+
 // Exchange bundles market type and available clients.
 type Exchange interface {
 	Name() schema.ExchangeName
@@ -0,0 +1,19 @@
+package schema
+
+import "time"
+
+// 系统级配置常量，所有交易所 WebSocket 实现统一使用
+
+const (
+	// HealthCheckInterval WebSocket健康检查间隔
+	HealthCheckInterval = 1 * time.Second
+
+	// WebSocketTimeout 超过该时间未收到任何消息视为连接异常
+	WebSocketTimeout = 30 * time.Second
+
+	// MaxReconnectWaitTime 重连最大等待时间
+	MaxReconnectWaitTime = 30 * time.Second
+
+	// ReconnectThreshold 重连次数阈值，超过后使用固定最大等待时间
+	ReconnectThreshold = 30
+)
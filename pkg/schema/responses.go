@@ -1,25 +1,42 @@
 package schema
 
+import (
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+)
+
 // Binance API Response Types
 
-// BinanceTickerResponse represents Binance ticker API response
+// BinanceTickerResponse represents Binance spot 24hr ticker API response (/api/v3/ticker/24hr)
 type BinanceTickerResponse struct {
-	Symbol string `json:"symbol"`
-	Price  string `json:"price"`
+	Symbol      string `json:"symbol"`
+	LastPrice   string `json:"lastPrice"`
+	OpenPrice   string `json:"openPrice"`
+	HighPrice   string `json:"highPrice"`
+	LowPrice    string `json:"lowPrice"`
+	Volume      string `json:"volume"`      // 基础币成交量
+	QuoteVolume string `json:"quoteVolume"` // 计价币成交额
+	CloseTime   int64  `json:"closeTime"`
 }
 
-// BinanceFuturesTickerResponse represents Binance futures ticker API response
+// BinanceFuturesTickerResponse represents Binance futures 24hr ticker API response (/fapi/v1/ticker/24hr, /dapi/v1/ticker/24hr)
+// U本位合约 volume 为基础币数量、quoteVolume 为USDT成交额；币本位合约 volume 为张数、baseVolume 为基础币数量
 type BinanceFuturesTickerResponse struct {
-	Symbol    string `json:"symbol"`
-	Price     string `json:"price"`
-	Volume    string `json:"volume"`
-	QuoteVol  string `json:"quoteVolume"`
-	Timestamp int64  `json:"time"`
+	Symbol      string `json:"symbol"`
+	LastPrice   string `json:"lastPrice"`
+	OpenPrice   string `json:"openPrice"`
+	HighPrice   string `json:"highPrice"`
+	LowPrice    string `json:"lowPrice"`
+	Volume      string `json:"volume"`
+	QuoteVolume string `json:"quoteVolume"`
+	BaseVolume  string `json:"baseVolume"`
+	CloseTime   int64  `json:"closeTime"`
 }
 
 // BinanceKlineResponse represents Binance kline API response
 // [0] Open time, [1] Open, [2] High, [3] Low, [4] Close, [5] Volume, [6] Close time, [7] Quote asset volume, [8] Number of trades
-type BinanceKlineResponse [][]interface{}
+type BinanceKlineResponse [][]json.Number
 
 // BinanceDepthResponse represents Binance depth API response
 type BinanceDepthResponse struct {
@@ -28,20 +45,66 @@ type BinanceDepthResponse struct {
 	Asks         [][]string `json:"asks"`
 }
 
+// BinanceLongShortRatioResponse represents an item of Binance futures long/short account ratio (/futures/data/globalLongShortAccountRatio)
+// U本位按 symbol 查询，币本位按 pair 查询
+type BinanceLongShortRatioResponse struct {
+	Symbol         string `json:"symbol"`
+	Pair           string `json:"pair"`
+	LongShortRatio string `json:"longShortRatio"`
+	LongAccount    string `json:"longAccount"`
+	ShortAccount   string `json:"shortAccount"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
 // OKX API Response Types
 
-// OKXTickerResponse represents OKX ticker API response
+// OKXTickerResponse represents OKX ticker API response (/api/v5/market/ticker, /api/v5/market/tickers)
 type OKXTickerResponse struct {
+	Code string      `json:"code"`
+	Msg  string      `json:"msg"`
+	Data []OKXTicker `json:"data"`
+}
+
+// OKXTicker represents a single OKX ticker
+// 现货 vol24h 为基础币、volCcy24h 为计价币；永续合约 vol24h 为张数、volCcy24h 为基础币
+type OKXTicker struct {
+	InstID    string `json:"instId"`
+	Last      string `json:"last"`
+	Open24h   string `json:"open24h"`
+	High24h   string `json:"high24h"`
+	Low24h    string `json:"low24h"`
+	Vol24h    string `json:"vol24h"`
+	VolCcy24h string `json:"volCcy24h"`
+	Ts        string `json:"ts"`
+}
+
+// OKXRubikResponse represents OKX trading statistics API response (/api/v5/rubik/stat/...)
+// data 每项为字符串数组，按时间倒序返回
+type OKXRubikResponse struct {
+	Code string     `json:"code"`
+	Msg  string     `json:"msg"`
+	Data [][]string `json:"data"`
+}
+
+// OKXOpenInterestResponse represents OKX open interest API response (/api/v5/public/open-interest)
+// oi 为张数，oiCcy 为基础币数量，oiUsd 为USD价值
+type OKXOpenInterestResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
 	Data []struct {
-		Last   string `json:"last"`
-		Vol24h string `json:"vol24h"`
 		InstID string `json:"instId"`
+		Oi     string `json:"oi"`
+		OiCcy  string `json:"oiCcy"`
+		OiUsd  string `json:"oiUsd"`
 		Ts     string `json:"ts"`
 	} `json:"data"`
 }
 
 // OKXKlineResponse represents OKX kline API response
+// [0] ts, [1] o, [2] h, [3] l, [4] c, [5] vol, [6] volCcy, [7] volCcyQuote, [8] confirm
 type OKXKlineResponse struct {
+	Code string     `json:"code"`
+	Msg  string     `json:"msg"`
 	Data [][]string `json:"data"`
 }
 
@@ -56,17 +119,62 @@ type OKXDepthResponse struct {
 
 // Bybit API Response Types
 
-// BybitTickerResponse represents Bybit ticker API response
+// BybitTickerResponse represents Bybit ticker API response (/v5/market/tickers)
 type BybitTickerResponse struct {
 	RetCode int    `json:"retCode"`
 	RetMsg  string `json:"retMsg"`
 	Result  struct {
-		Category string `json:"category"`
-		List     []struct {
+		Category string        `json:"category"`
+		List     []BybitTicker `json:"list"`
+	} `json:"result"`
+	Time int64 `json:"time"`
+}
+
+// BybitTicker represents a single Bybit ticker, also used by the tickers.{symbol} WebSocket topic
+// 现货和U本位合约 volume24h 为基础币、turnover24h 为计价币；币本位合约 volume24h 为USD、turnover24h 为基础币
+type BybitTicker struct {
+	Symbol       string `json:"symbol"`
+	LastPrice    string `json:"lastPrice"`
+	PrevPrice24h string `json:"prevPrice24h"`
+	HighPrice24h string `json:"highPrice24h"`
+	LowPrice24h  string `json:"lowPrice24h"`
+	Volume24h    string `json:"volume24h"`
+	Turnover24h  string `json:"turnover24h"`
+
+	// 以下字段仅合约提供
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	FundingRate     string `json:"fundingRate"`
+	NextFundingTime string `json:"nextFundingTime"` // 毫秒时间戳
+
+	// U本位合约 openInterest 为基础币、openInterestValue 为USDT；币本位合约 openInterest 为USD、openInterestValue 为基础币
+	OpenInterest      string `json:"openInterest"`
+	OpenInterestValue string `json:"openInterestValue"`
+}
+
+// BybitOpenInterestResponse represents Bybit open interest history API response (/v5/market/open-interest)，按时间倒序返回
+type BybitOpenInterestResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Symbol string `json:"symbol"`
+		List   []struct {
+			OpenInterest string `json:"openInterest"`
+			Timestamp    string `json:"timestamp"`
+		} `json:"list"`
+	} `json:"result"`
+}
+
+// BybitAccountRatioResponse represents Bybit long/short account ratio API response (/v5/market/account-ratio)，按时间倒序返回
+type BybitAccountRatioResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
 			Symbol    string `json:"symbol"`
-			LastPrice string `json:"lastPrice"`
-			Volume24h string `json:"turnover24h"`
-			Time      int64  `json:"time"`
+			BuyRatio  string `json:"buyRatio"`
+			SellRatio string `json:"sellRatio"`
+			Timestamp string `json:"timestamp"`
 		} `json:"list"`
 	} `json:"result"`
 }
@@ -97,12 +205,38 @@ type BybitDepthResponse struct {
 
 // Gate API Response Types
 
-// GateTickerResponse represents Gate ticker API response
+// GateTickerResponse represents Gate spot ticker API response (/spot/tickers 返回数组)
 type GateTickerResponse struct {
 	CurrencyPair string `json:"currency_pair"`
 	Last         string `json:"last"`
+	High24h      string `json:"high_24h"`
+	Low24h       string `json:"low_24h"`
 	BaseVolume   string `json:"base_volume"`
-	Time         int64  `json:"timestamp"`
+	QuoteVolume  string `json:"quote_volume"`
+}
+
+// GateFuturesTickerResponse represents Gate futures ticker API response (/futures/{settle}/tickers 返回数组)
+type GateFuturesTickerResponse struct {
+	Contract       string `json:"contract"`
+	Last           string `json:"last"`
+	High24h        string `json:"high_24h"`
+	Low24h         string `json:"low_24h"`
+	Volume24hBase  string `json:"volume_24h_base"`
+	Volume24hQuote string `json:"volume_24h_quote"`
+	MarkPrice      string `json:"mark_price"`
+	IndexPrice     string `json:"index_price"`
+	FundingRate    string `json:"funding_rate"` // 下一期资金费率，接口不提供结算时间
+	TotalSize      string `json:"total_size"`   // 持仓张数
+}
+
+// GateContractStat represents an item of Gate futures contract stats API response (/futures/{settle}/contract_stats)
+// open_interest 为持仓张数，open_interest_usd 为USD价值，lsr_account 为多空账户比，按时间升序返回
+type GateContractStat struct {
+	Time            int64   `json:"time"` // 秒
+	LsrAccount      float64 `json:"lsr_account"`
+	OpenInterest    int64   `json:"open_interest"`
+	OpenInterestUsd float64 `json:"open_interest_usd"`
+	MarkPrice       float64 `json:"mark_price"`
 }
 
 // GateKlineResponse represents Gate kline API response
@@ -118,16 +252,35 @@ type GateDepthResponse struct {
 
 // MEXC API Response Types
 
-// MEXCTickerResponse represents MEXC ticker API response
+// MEXCTickerResponse represents MEXC spot 24hr ticker API response (/api/v3/ticker/24hr)
 type MEXCTickerResponse struct {
-	Symbol    string `json:"symbol"`
-	Price     string `json:"price"`
-	Volume    string `json:"volume"`
-	Timestamp int64  `json:"timestamp"`
+	Symbol      string `json:"symbol"`
+	LastPrice   string `json:"lastPrice"`
+	OpenPrice   string `json:"openPrice"`
+	HighPrice   string `json:"highPrice"`
+	LowPrice    string `json:"lowPrice"`
+	Volume      string `json:"volume"`      // 基础币成交量
+	QuoteVolume string `json:"quoteVolume"` // 计价币成交额
+	CloseTime   int64  `json:"closeTime"`
+}
+
+// MEXCFuturesTicker represents a MEXC contract ticker (/api/v1/contract/ticker, push.ticker)，数值字段为 JSON 数字
+// volume24 为张数，amount24 为成交额
+type MEXCFuturesTicker struct {
+	Symbol       string          `json:"symbol"`
+	LastPrice    decimal.Decimal `json:"lastPrice"`
+	High24Price  decimal.Decimal `json:"high24Price"`
+	Lower24Price decimal.Decimal `json:"lower24Price"`
+	Volume24     decimal.Decimal `json:"volume24"`
+	Amount24     decimal.Decimal `json:"amount24"`
+	HoldVol      decimal.Decimal `json:"holdVol"`   // 持仓张数
+	FairPrice    decimal.Decimal `json:"fairPrice"` // 合理价格（标记价格）
+	Timestamp    int64           `json:"timestamp"`
 }
 
-// MEXCKlineResponse represents MEXC kline API response
-type MEXCKlineResponse [][]interface{}
+// MEXCKlineResponse represents MEXC spot kline API response
+// [0] Open time, [1] Open, [2] High, [3] Low, [4] Close, [5] Volume, [6] Close time, [7] Quote asset volume
+type MEXCKlineResponse [][]json.Number
 
 // MEXCDepthResponse represents MEXC depth API response
 type MEXCDepthResponse struct {
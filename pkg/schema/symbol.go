@@ -20,6 +20,10 @@ type Symbol struct {
 	MinQuantity       string `json:"minQuantity"`       // 最小下单数量
 	MinNotional       string `json:"minNotional"`       // 最小下单金额
 	MaxQuantity       string `json:"maxQuantity"`       // 最大下单数量（可选）
+	TickSize          string `json:"tickSize"`          // 价格最小变动单位
+	StepSize          string `json:"stepSize"`          // 数量最小变动单位
+
+	Status SymbolStatus `json:"status"` // 交易状态，各交易所原始状态归一为 SymbolStatus 常量
 }
 
 // NewSymbol 创建一个新的Symbol实例
@@ -54,6 +58,11 @@ func (s *Symbol) String() string {
 	return fmt.Sprintf("%s/%s", s.Base, s.Quote)
 }
 
+// IsTrading 判断交易对当前是否可交易
+func (s *Symbol) IsTrading() bool {
+	return s.Status == SymbolStatusTrading
+}
+
 // IsSpot 判断是否为现货市场
 func (s *Symbol) IsSpot() bool {
 	return s.MarketType == SPOT
@@ -246,11 +255,11 @@ func formatBinanceSymbol(symbol *Symbol, marketType MarketType) string {
 // formatOKXSymbol 格式化OKX币对
 func formatOKXSymbol(symbol *Symbol, marketType string) string {
 	switch marketType {
-	case "SPOT":
+	case string(SPOT):
 		return symbol.Base + "-" + symbol.Quote // BTC-USDT
-	case "FUTURESUSDT":
+	case string(FUTURESUSDT):
 		return symbol.Base + "-" + symbol.Quote + "-SWAP" // BTC-USDT-SWAP
-	case "FUTURESCOIN":
+	case string(FUTURESCOIN):
 		return symbol.Base + "-" + symbol.Quote + "-SWAP" // BTC-USD-SWAP
 	default:
 		return symbol.Base + "-" + symbol.Quote
@@ -280,8 +289,10 @@ func formatGateSymbol(symbol *Symbol, marketType string) string {
 // formatMEXCSymbol 格式化MEXC币对
 func formatMEXCSymbol(symbol *Symbol, marketType string) string {
 	switch marketType {
-	case "SPOT", "FUTURESUSDT", "FUTURESCOIN":
-		return symbol.Base + symbol.Quote // BTCUSDT, BTCUSD
+	case string(SPOT):
+		return symbol.Base + symbol.Quote // BTCUSDT
+	case string(FUTURESUSDT), string(FUTURESCOIN):
+		return symbol.Base + "_" + symbol.Quote // BTC_USDT, BTC_USD
 	default:
 		return symbol.Base + symbol.Quote
 	}
@@ -405,9 +416,20 @@ func reverseParseGateSymbol(symbol, marketType string) (base, quote string, err
 	return "", "", fmt.Errorf("cannot parse Gate symbol: %s", symbol)
 }
 
-// reverseParseMEXCSymbol 反解析MEXC币对
+// reverseParseMEXCSymbol 反解析MEXC币对，现货无分隔符（BTCUSDT），合约按下划线分割（BTC_USDT）
 func reverseParseMEXCSymbol(symbol, marketType string) (base, quote string, err error) {
-	return smartReverseParse(symbol)
+	if marketType != string(FUTURESUSDT) && marketType != string(FUTURESCOIN) {
+		return smartReverseParse(symbol)
+	}
+	symbol = strings.ToUpper(symbol)
+
+	// 按下划线分割
+	parts := strings.Split(symbol, "_")
+	if len(parts) >= 2 {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "", fmt.Errorf("cannot parse MEXC symbol: %s", symbol)
 }
 
 // smartReverseParse 智能反解析币对
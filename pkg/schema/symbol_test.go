@@ -244,6 +244,27 @@ func TestParser_FormatSymbol(t *testing.T) {
 			expected:     "BTCUSDT",
 			expectError:  false,
 		},
+		{
+			name:         "OKX U本位合约",
+			symbol:       NewSymbol("", "BTC", "USDT", "USDT", OKX, FUTURESUSDT),
+			exchangeName: OKX,
+			expected:     "BTC-USDT-SWAP",
+			expectError:  false,
+		},
+		{
+			name:         "OKX 币本位合约",
+			symbol:       NewSymbol("", "BTC", "USD", "BTC", OKX, FUTURESCOIN),
+			exchangeName: OKX,
+			expected:     "BTC-USD-SWAP",
+			expectError:  false,
+		},
+		{
+			name:         "MEXC U本位合约",
+			symbol:       NewSymbol("", "BTC", "USDT", "USDT", MEXC, FUTURESUSDT),
+			exchangeName: MEXC,
+			expected:     "BTC_USDT",
+			expectError:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -322,7 +343,7 @@ func TestParser_ReverseParseSymbol(t *testing.T) {
 			exchangeName: "BINANCE",
 			marketType:   "FUTURESCOIN",
 			expected: &Symbol{
-				Symbol:       "BTCUSD",
+				Symbol:       "BTCUSD_PERP",
 				Base:         "BTC",
 				Quote:        "USD",
 				Margin:       "BTC",
@@ -388,6 +409,39 @@ func TestParser_ReverseParseSymbol(t *testing.T) {
 	}
 }
 
+func TestParseExchangeSymbol_MEXCRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		symbol   *Symbol
+		expected string
+	}{
+		{"MEXC spot", NewSymbol("", "BTC", "USDT", "", MEXC, SPOT), "BTCUSDT"},
+		{"MEXC U本位合约", NewSymbol("", "BTC", "USDT", "USDT", MEXC, FUTURESUSDT), "BTC_USDT"},
+		{"MEXC 币本位合约", NewSymbol("", "BTC", "USD", "BTC", MEXC, FUTURESCOIN), "BTC_USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted, err := FormatSymbol(tt.symbol, MEXC)
+			if err != nil {
+				t.Fatalf("Unexpected format error: %v", err)
+			}
+			if formatted != tt.expected {
+				t.Fatalf("Expected '%s', got '%s'", tt.expected, formatted)
+			}
+
+			parsed, err := ParseExchangeSymbol(formatted, string(MEXC), string(tt.symbol.MarketType))
+			if err != nil {
+				t.Fatalf("Unexpected parse error: %v", err)
+			}
+			if parsed.Base != tt.symbol.Base || parsed.Quote != tt.symbol.Quote || parsed.Margin != tt.symbol.Margin {
+				t.Errorf("Expected %s/%s:%s, got %s/%s:%s", tt.symbol.Base, tt.symbol.Quote, tt.symbol.Margin,
+					parsed.Base, parsed.Quote, parsed.Margin)
+			}
+		})
+	}
+}
+
 func TestNormalizeFunctions(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1,6 +1,9 @@
 package schema
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -40,6 +43,31 @@ const (
 	Interval1d  Interval = "1d"
 )
 
+var intervalDurations = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval3m:  3 * time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval1h:  time.Hour,
+	Interval4h:  4 * time.Hour,
+	Interval1d:  24 * time.Hour,
+}
+
+// Duration 返回周期时长，未知周期返回0
+func (i Interval) Duration() time.Duration {
+	return intervalDurations[i]
+}
+
+// DefaultKlineInterval 订阅K线时未指定周期使用的默认周期
+const DefaultKlineInterval = Interval1m
+
+// KlineSubscription 单个币对在单个周期上的K线订阅
+type KlineSubscription struct {
+	Symbol   string
+	Interval Interval
+}
+
 // PriceLevel represents a single order book level.
 type PriceLevel struct {
 	Price    decimal.Decimal `json:"price"`
@@ -57,17 +85,115 @@ type Depth struct {
 	LastUpdateId string       `json:"rawVersion,omitempty"`
 }
 
-// Ticker represents the latest price.
+// BookTicker represents the best bid/offer (top of book).
+// 合约数量与 Depth 一致，统一换算为基础币数量。
+type BookTicker struct {
+	Exchange     ExchangeName    `json:"exchange"`
+	Market       MarketType      `json:"market"`
+	Symbol       string          `json:"symbol"`
+	BidPrice     decimal.Decimal `json:"bidPrice"`
+	BidQty       decimal.Decimal `json:"bidQty"`
+	AskPrice     decimal.Decimal `json:"askPrice"`
+	AskQty       decimal.Decimal `json:"askQty"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+	LastUpdateId string          `json:"rawVersion,omitempty"`
+}
+
+// Ticker represents the latest price with 24h rolling statistics.
+// Volume 为基础币成交量，QuoteVol 为计价币成交额，合约统一换算为基础币数量；
+// 交易所未提供的字段为 0。
 type Ticker struct {
 	Exchange  ExchangeName    `json:"exchange"`
 	Market    MarketType      `json:"market"`
 	Symbol    string          `json:"symbol"`
 	Price     decimal.Decimal `json:"price"`
+	Open      decimal.Decimal `json:"open,omitempty"` // 24小时前的开盘价
+	High      decimal.Decimal `json:"high,omitempty"` // 24小时最高价
+	Low       decimal.Decimal `json:"low,omitempty"`  // 24小时最低价
 	Volume    decimal.Decimal `json:"volume,omitempty"`
 	QuoteVol  decimal.Decimal `json:"quoteVolume,omitempty"`
 	Timestamp time.Time       `json:"timestamp"`
 }
 
+// FundingInfo represents mark price, index price and funding rate of a perpetual contract.
+// 各字段可能来自不同频道，交易所未提供的字段为零值。
+type FundingInfo struct {
+	Exchange        ExchangeName    `json:"exchange"`
+	Market          MarketType      `json:"market"`
+	Symbol          string          `json:"symbol"`
+	MarkPrice       decimal.Decimal `json:"markPrice"`
+	IndexPrice      decimal.Decimal `json:"indexPrice"`
+	FundingRate     decimal.Decimal `json:"fundingRate"`               // 当期资金费率
+	NextFundingTime time.Time       `json:"nextFundingTime,omitempty"` // 下次结算时间
+	Timestamp       time.Time       `json:"timestamp"`
+}
+
+// OpenInterest represents open interest of a futures contract.
+// OpenInterest 为基础币数量（合约张数已换算），Value 为持仓价值（U本位为USDT，币本位为USD）；
+// 交易所未提供的字段为 0。
+type OpenInterest struct {
+	Exchange     ExchangeName    `json:"exchange"`
+	Market       MarketType      `json:"market"`
+	Symbol       string          `json:"symbol"`
+	OpenInterest decimal.Decimal `json:"openInterest"`
+	Value        decimal.Decimal `json:"value,omitempty"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+// LongShortRatio represents the long/short account ratio of a futures contract.
+// LongAccount、ShortAccount 为多空账户占比（0.6 表示 60%）。
+type LongShortRatio struct {
+	Exchange       ExchangeName    `json:"exchange"`
+	Market         MarketType      `json:"market"`
+	Symbol         string          `json:"symbol"`
+	LongShortRatio decimal.Decimal `json:"longShortRatio"`
+	LongAccount    decimal.Decimal `json:"longAccount"`
+	ShortAccount   decimal.Decimal `json:"shortAccount"`
+	Timestamp      time.Time       `json:"timestamp"`
+}
+
+// NewLongShortRatio 根据多空比计算多空账户占比，适用于只提供比值的交易所
+func NewLongShortRatio(exchange ExchangeName, market MarketType, symbol string, ratio decimal.Decimal, ts time.Time) LongShortRatio {
+	r := LongShortRatio{Exchange: exchange, Market: market, Symbol: symbol, LongShortRatio: ratio, Timestamp: ts}
+	if ratio.IsPositive() {
+		total := ratio.Add(decimal.NewFromInt(1))
+		r.LongAccount = ratio.Div(total)
+		r.ShortAccount = decimal.NewFromInt(1).Div(total)
+	}
+	return r
+}
+
+// Liquidation represents a forced liquidation order of a futures contract.
+// Side 为强平订单方向：卖出表示多头仓位被强平，买入表示空头仓位被强平；
+// Quantity 为基础币数量（合约张数已换算），QuoteQty 为强平金额（U本位为USDT，币本位为USD）。
+type Liquidation struct {
+	Exchange  ExchangeName    `json:"exchange"`
+	Market    MarketType      `json:"market"`
+	Symbol    string          `json:"symbol"`
+	Side      OrderSide       `json:"side"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	QuoteQty  decimal.Decimal `json:"quoteQty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// LiquidationHandler 接收强平事件，在连接器读协程中同步调用，应尽快返回
+type LiquidationHandler func(Liquidation)
+
+// KlineGapRepaired 断线重连后通过 REST 补齐K线缺口的事件，From、To 为补齐范围内首尾K线的开盘时间
+type KlineGapRepaired struct {
+	Exchange ExchangeName `json:"exchange"`
+	Market   MarketType   `json:"market"`
+	Symbol   string       `json:"symbol"`
+	Interval Interval     `json:"interval"`
+	From     time.Time    `json:"from"`
+	To       time.Time    `json:"to"`
+	Count    int          `json:"count"`
+}
+
+// KlineGapHandler 接收K线缺口补齐事件，在连接器重连协程中同步调用，应尽快返回
+type KlineGapHandler func(KlineGapRepaired)
+
 // Kline represents a normalized candle.
 type Kline struct {
 	Exchange    ExchangeName    `json:"exchange"`
@@ -88,6 +214,48 @@ type Kline struct {
 	AdaptVolume decimal.Decimal `json:"-"`
 }
 
+// NormalizeKlines 将分页拉取的K线按开盘时间升序排列并去重（相同开盘时间保留最后拉取的一条），
+// 只保留开盘时间在 [start, end] 内的K线，end 为零值时不限制结束时间
+func NormalizeKlines(klines []Kline, start, end time.Time) []Kline {
+	sort.SliceStable(klines, func(i, j int) bool { return klines[i].OpenTime.Before(klines[j].OpenTime) })
+
+	out := make([]Kline, 0, len(klines))
+	for _, kl := range klines {
+		if kl.OpenTime.Before(start) || (!end.IsZero() && kl.OpenTime.After(end)) {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].OpenTime.Equal(kl.OpenTime) {
+			out[n-1] = kl
+			continue
+		}
+		out = append(out, kl)
+	}
+	return out
+}
+
+// LatestKlines 通过按开盘时间范围分页拉取的 getKlines 获取最近 limit 根K线，含尚未收盘的当前K线，
+// 供只提供时间范围接口的连接器实现 GetKline
+func LatestKlines(ctx context.Context, getKlines func(ctx context.Context, symbol string, interval Interval, start, end time.Time) ([]Kline, error),
+	symbol string, interval Interval, limit int) ([]Kline, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid kline limit: %d", limit)
+	}
+	step := interval.Duration()
+	if step == 0 {
+		return nil, fmt.Errorf("unsupported interval: %s", interval)
+	}
+	// 从当前周期向前取 limit 根
+	start := time.Now().Truncate(step).Add(-time.Duration(limit-1) * step)
+	klines, err := getKlines(ctx, symbol, interval, start, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if len(klines) > limit {
+		klines = klines[len(klines)-limit:]
+	}
+	return klines, nil
+}
+
 // OrderSide defines the side of an order.
 type OrderSide string
 
@@ -142,6 +310,8 @@ type Order struct {
 }
 
 // Trade represents a completed trade.
+// 公共成交推送中 Side 为主动成交（taker）方向，OrderID/手续费等私有字段为空；
+// 合约成交数量统一换算为基础币数量，QuoteQty 为计价币成交额。
 type Trade struct {
 	Exchange        ExchangeName    `json:"exchange"`        // 交易所
 	Market          MarketType      `json:"market"`          // 市场类型
@@ -199,6 +369,7 @@ type SymbolStatus string
 
 const (
 	SymbolStatusTrading      SymbolStatus = "TRADING"       // 正常交易
+	SymbolStatusPreTrading   SymbolStatus = "PRE_TRADING"   // 已上架未开盘
 	SymbolStatusHalt         SymbolStatus = "HALT"          // 暂停交易
 	SymbolStatusBreak        SymbolStatus = "BREAK"         // 交易暂停
 	SymbolStatusAuctionMatch SymbolStatus = "AUCTION_MATCH" // 集合竞价
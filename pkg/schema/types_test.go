@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNormalizeKlines(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	kline := func(minute int, close int64) Kline {
+		return Kline{OpenTime: base.Add(time.Duration(minute) * time.Minute), Close: decimal.NewFromInt(close)}
+	}
+
+	// 倒序分页且首尾重叠，超出范围的K线被过滤
+	pages := []Kline{kline(4, 4), kline(3, 3), kline(2, 2), kline(2, 20), kline(1, 1), kline(0, 0)}
+	out := NormalizeKlines(pages, base.Add(time.Minute), base.Add(3*time.Minute))
+
+	if len(out) != 3 {
+		t.Fatalf("expected 3 klines, got %d", len(out))
+	}
+	for i, want := range []int64{1, 20, 3} {
+		if !out[i].OpenTime.Equal(base.Add(time.Duration(i+1)*time.Minute)) || out[i].Close.IntPart() != want {
+			t.Fatalf("out[%d] = %v close %v, want close %d", i, out[i].OpenTime, out[i].Close, want)
+		}
+	}
+}
+
+func TestLatestKlines(t *testing.T) {
+	var gotStart, gotEnd time.Time
+	getKlines := func(ctx context.Context, symbol string, interval Interval, start, end time.Time) ([]Kline, error) {
+		gotStart, gotEnd = start, end
+		var out []Kline
+		for ts := start.Add(-2 * time.Minute); !ts.After(time.Now()); ts = ts.Add(time.Minute) {
+			out = append(out, Kline{OpenTime: ts})
+		}
+		return out, nil
+	}
+
+	out, err := LatestKlines(context.Background(), getKlines, "BTCUSDT", Interval1m, 3)
+	if err != nil {
+		t.Fatalf("LatestKlines: %v", err)
+	}
+	// 从当前分钟向前 2 根开始拉取，不限制结束时间，只保留最近 3 根
+	if !gotEnd.IsZero() || gotStart.Second() != 0 || time.Since(gotStart) < 2*time.Minute {
+		t.Fatalf("unexpected range: start=%v end=%v", gotStart, gotEnd)
+	}
+	if len(out) != 3 || !out[0].OpenTime.Equal(gotStart) {
+		t.Fatalf("unexpected klines: %+v", out)
+	}
+
+	if _, err := LatestKlines(context.Background(), getKlines, "BTCUSDT", Interval1m, 0); err == nil {
+		t.Fatalf("limit 0 should be rejected")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kingsmao/exchange-connector/internal/cache"
 	binancefuturescoin "github.com/kingsmao/exchange-connector/internal/exchange/binance/futures_coin"
@@ -36,10 +37,66 @@ type ExchangeConfig struct {
 
 // SymbolConfig 币对配置
 type SymbolConfig struct {
-	Base   string            // 基础货币
-	Quote  string            // 计价货币
-	Margin string            // 保证金币种（期货时存在）
-	Market schema.MarketType // 市场类型
+	Base     string              // 基础货币
+	Quote    string              // 计价货币
+	Margin   string              // 保证金币种（期货时存在）
+	Market   schema.MarketType   // 市场类型
+	Exchange schema.ExchangeName // 只在该交易所订阅，为空时订阅所有支持该市场的交易所
+}
+
+// SubscribeStatus 单个币对在单个交易所的订阅结果
+type SubscribeStatus string
+
+const (
+	SubscribeStatusSubscribed SubscribeStatus = "subscribed" // 已订阅
+	SubscribeStatusPartial    SubscribeStatus = "partial"    // 部分数据流订阅失败，Reason 说明失败的数据流
+	SubscribeStatusSkipped    SubscribeStatus = "skipped"    // 交易所未上架该币对，未订阅
+	SubscribeStatusRejected   SubscribeStatus = "rejected"   // 币对无法解析、未处于交易状态或订阅请求失败
+)
+
+// SubscribeResult 单个币对在单个交易所的订阅结果，币对无法解析时 Exchange 为空
+type SubscribeResult struct {
+	Symbol         string              // 统一格式币对，如 "BTC/USDT:USDT"
+	Exchange       schema.ExchangeName // 交易所名称
+	Market         schema.MarketType   // 市场类型
+	ExchangeSymbol string              // 交易所格式的币对
+	Status         SubscribeStatus     // 订阅结果
+	Reason         string              // 跳过或拒绝的原因
+}
+
+// SubscribeReport 批量订阅结果，每个币对与交易所的组合一条
+type SubscribeReport struct {
+	Results []SubscribeResult
+}
+
+// Subscribed 返回已订阅的组合
+func (r SubscribeReport) Subscribed() []SubscribeResult {
+	return r.filter(SubscribeStatusSubscribed)
+}
+
+// Partial 返回部分数据流订阅失败的组合
+func (r SubscribeReport) Partial() []SubscribeResult {
+	return r.filter(SubscribeStatusPartial)
+}
+
+// Skipped 返回因交易所未上架而跳过的组合
+func (r SubscribeReport) Skipped() []SubscribeResult {
+	return r.filter(SubscribeStatusSkipped)
+}
+
+// Rejected 返回被拒绝的组合
+func (r SubscribeReport) Rejected() []SubscribeResult {
+	return r.filter(SubscribeStatusRejected)
+}
+
+func (r SubscribeReport) filter(status SubscribeStatus) []SubscribeResult {
+	var out []SubscribeResult
+	for _, res := range r.Results {
+		if res.Status == status {
+			out = append(out, res)
+		}
+	}
+	return out
 }
 
 // SDK provides a high-level interface for exchange operations
@@ -172,125 +229,222 @@ func (sdk *SDK) AddSymbols(configs []SymbolConfig) {
 	sdk.symbolConfigs = append(sdk.symbolConfigs, configs...)
 }
 
-// AddSymbolsByExchange 按交易所批量添加币对（自动识别市场类型）
+// AddSymbolsByExchange 按交易所批量添加币对（自动识别市场类型），币对只在该交易所订阅
 func (sdk *SDK) AddSymbolsByExchange(exchange schema.ExchangeName, symbols []string) {
-	for _, symbolStr := range symbols {
-		// 使用现有的ParseSymbol函数解析币对格式 [base]/[quote]:[margin]
-		parsedSymbol, err := schema.ParseSymbol(symbolStr)
-		if err != nil {
-			// 如果解析失败，记录错误但继续处理其他币对
-			continue
-		}
-
-		sdk.symbolConfigs = append(sdk.symbolConfigs, SymbolConfig{
-			Base:   parsedSymbol.Base,
-			Quote:  parsedSymbol.Quote,
-			Margin: parsedSymbol.Margin,
-			Market: parsedSymbol.MarketType,
-		})
-	}
+	// 解析失败的币对忽略，继续处理其他币对
+	configs, _ := parseSymbolConfigs(exchange, symbols)
+	sdk.symbolConfigs = append(sdk.symbolConfigs, configs...)
 }
 
 // AddSymbolsAndSubscribe 添加币对并自动订阅WebSocket（一步完成）
-func (sdk *SDK) AddSymbolsAndSubscribe(ctx context.Context, symbols []string) error {
-	// 1. 添加币对
+// 订阅前按交易规则校验每个交易所的币对，返回每个币对与交易所组合的订阅结果
+func (sdk *SDK) AddSymbolsAndSubscribe(ctx context.Context, symbols []string) (SubscribeReport, error) {
+	// 1. 添加币对，无法解析的币对记为拒绝
+	configs, rejected := parseSymbolConfigs("", symbols)
+	sdk.symbolConfigs = append(sdk.symbolConfigs, configs...)
+
+	// 2. 只订阅本次添加的币对
+	report, err := sdk.autoSubscribe(ctx, configs)
+	report.Results = append(rejected, report.Results...)
+	return report, err
+}
+
+// AddSymbolsByExchangeAndSubscribe 按交易所添加币对并自动订阅WebSocket（一步完成），币对只在该交易所订阅
+func (sdk *SDK) AddSymbolsByExchangeAndSubscribe(ctx context.Context, exchange schema.ExchangeName, symbols []string) (SubscribeReport, error) {
+	// 1. 按交易所添加币对，无法解析的币对记为拒绝
+	configs, rejected := parseSymbolConfigs(exchange, symbols)
+	sdk.symbolConfigs = append(sdk.symbolConfigs, configs...)
+
+	// 2. 只订阅本次添加的币对
+	report, err := sdk.autoSubscribe(ctx, configs)
+	report.Results = append(rejected, report.Results...)
+	return report, err
+}
+
+// parseSymbolConfigs 解析 [base]/[quote]:[margin] 格式的币对，exchange 非空时限定订阅的交易所；
+// 无法解析的币对作为拒绝结果返回
+func parseSymbolConfigs(exchange schema.ExchangeName, symbols []string) ([]SymbolConfig, []SubscribeResult) {
+	var (
+		configs  []SymbolConfig
+		rejected []SubscribeResult
+	)
 	for _, symbolStr := range symbols {
-		// 使用现有的ParseSymbol函数解析币对格式 [base]/[quote]:[margin]
 		// 自动识别市场类型：现货为 [base]/[quote]，合约为 [base]/[quote]:[margin]
 		parsedSymbol, err := schema.ParseSymbol(symbolStr)
 		if err != nil {
-			// 如果解析失败，记录错误但继续处理其他币对
+			rejected = append(rejected, SubscribeResult{
+				Symbol: symbolStr,
+				Status: SubscribeStatusRejected,
+				Reason: fmt.Sprintf("failed to parse symbol: %v", err),
+			})
 			continue
 		}
 
-		sdk.symbolConfigs = append(sdk.symbolConfigs, SymbolConfig{
-			Base:   parsedSymbol.Base,
-			Quote:  parsedSymbol.Quote,
-			Margin: parsedSymbol.Margin,
-			Market: parsedSymbol.MarketType,
+		configs = append(configs, SymbolConfig{
+			Base:     parsedSymbol.Base,
+			Quote:    parsedSymbol.Quote,
+			Margin:   parsedSymbol.Margin,
+			Market:   parsedSymbol.MarketType,
+			Exchange: exchange,
 		})
 	}
-
-	// 2. 自动订阅
-	return sdk.autoSubscribe(ctx)
+	return configs, rejected
 }
 
-// AddSymbolsByExchangeAndSubscribe 按交易所添加币对并自动订阅WebSocket（一步完成）
-func (sdk *SDK) AddSymbolsByExchangeAndSubscribe(ctx context.Context, exchange schema.ExchangeName, symbols []string) error {
-	// 1. 按交易所添加币对
-	sdk.AddSymbolsByExchange(exchange, symbols)
-
-	// 2. 自动订阅
-	return sdk.autoSubscribe(ctx)
+// subscriptionGroup 同一交易所同一市场待批量订阅的币对，results 为每个币对在结果中的位置
+type subscriptionGroup struct {
+	exchange schema.ExchangeName
+	market   schema.MarketType
+	symbols  []string
+	results  []int
 }
 
-// autoSubscribe 自动订阅所有配置的交易所和币对（内部方法）
-func (sdk *SDK) autoSubscribe(ctx context.Context) error {
-	// 1. 启动所有WebSocket连接
+// autoSubscribe 启动WebSocket连接并订阅给定的币对（内部方法），结果只包含这些币对
+func (sdk *SDK) autoSubscribe(ctx context.Context, configs []SymbolConfig) (SubscribeReport, error) {
+	// 1. 启动所有WebSocket连接（同时加载交易规则）
 	if err := sdk.manager.StartWS(ctx); err != nil {
-		return err
+		return SubscribeReport{}, err
 	}
 
-	// 3. 自动订阅所有币对（按市场类型分组，批量订阅）
-	// 按交易所和市场类型分组币对
-	subscriptionGroups := make(map[string][]string) // key: "exchangeName_marketType"
+	// 2. 按交易所和市场类型分组币对
+	report, groups := sdk.planSubscriptions(configs)
 
-	for _, symbolConfig := range sdk.symbolConfigs {
-		// 找到支持该市场的交易所
-		for _, exchangeConfig := range sdk.exchangeConfigs {
-			if exchangeConfig.Market == symbolConfig.Market {
-				// 生成分组key（使用分隔符避免市场类型中的下划线问题）
-				groupKey := fmt.Sprintf("%s|%s", exchangeConfig.Name, exchangeConfig.Market)
-
-				// 使用FormatSymbolByExchange格式化币对名称
-				formattedSymbol, err := schema.FormatSymbolByExchange(
-					exchangeConfig.Name,
-					symbolConfig.Base,
-					symbolConfig.Quote,
-					symbolConfig.Margin,
-					exchangeConfig.Market,
-				)
-				if err != nil {
-					logger.Warn("格式化币对符号失败 %s/%s: %v", symbolConfig.Base, symbolConfig.Quote, err)
-					continue
-				}
-
-				// 添加到对应的分组
-				subscriptionGroups[groupKey] = append(subscriptionGroups[groupKey], formattedSymbol)
-			}
-		}
-	}
+	// 3. 批量订阅每个分组
+	for _, group := range groups {
+		logger.Info("批量订阅 %s %s: %v", group.exchange, group.market, group.symbols)
 
-	// 批量订阅每个分组
-	for groupKey, symbols := range subscriptionGroups {
-		// 解析分组key
-		parts := strings.Split(groupKey, "|")
-		if len(parts) != 2 {
-			logger.Warn("无效的分组key: %s", groupKey)
-			continue
+		// 批量订阅K线数据（默认1m，其它周期通过 SubscribeKline 订阅）
+		klineErr := sdk.manager.SubscribeKline(ctx, group.exchange, group.market, group.symbols)
+		if klineErr != nil {
+			logger.Warn("订阅K线数据失败 %s %s: %v", group.exchange, group.market, klineErr)
 		}
 
-		exchangeName := schema.ExchangeName(parts[0])
-		marketType := schema.MarketType(parts[1])
+		// 批量订阅深度数据
+		depthErr := sdk.manager.SubscribeDepth(ctx, group.exchange, group.market, group.symbols)
+		if depthErr != nil {
+			logger.Warn("订阅深度数据失败 %s %s: %v", group.exchange, group.market, depthErr)
+		}
 
-		if len(symbols) == 0 {
-			continue
+		status, reason := streamSubscribeStatus(klineErr, depthErr)
+		for _, i := range group.results {
+			report.Results[i].Status, report.Results[i].Reason = status, reason
 		}
+	}
 
-		logger.Info("批量订阅 %s %s: %v", exchangeName, marketType, symbols)
+	return report, nil
+}
+
+// streamSubscribeStatus 由K线和深度数据流的订阅结果得出币对的订阅状态：
+// 全部失败时拒绝，只有一个失败时为部分订阅，Reason 列出失败的数据流
+func streamSubscribeStatus(klineErr, depthErr error) (SubscribeStatus, string) {
+	var reasons []string
+	if klineErr != nil {
+		reasons = append(reasons, fmt.Sprintf("kline subscription failed: %v", klineErr))
+	}
+	if depthErr != nil {
+		reasons = append(reasons, fmt.Sprintf("depth subscription failed: %v", depthErr))
+	}
+	switch len(reasons) {
+	case 0:
+		return SubscribeStatusSubscribed, ""
+	case 1:
+		return SubscribeStatusPartial, reasons[0]
+	default:
+		return SubscribeStatusRejected, strings.Join(reasons, "; ")
+	}
+}
+
+// planSubscriptions 将币对按交易所和市场类型分组，币对限定了交易所时只匹配该交易所。
+// 格式化后的币对先按交易规则校验：未上架的跳过，非交易状态的拒绝；交易规则未加载时不校验直接订阅
+func (sdk *SDK) planSubscriptions(configs []SymbolConfig) (SubscribeReport, []*subscriptionGroup) {
+	var report SubscribeReport
+	var groups []*subscriptionGroup
+	groupIndex := make(map[string]*subscriptionGroup) // key: "exchangeName|marketType"
+
+	for _, symbolConfig := range configs {
+		symbolName := (&schema.Symbol{Base: symbolConfig.Base, Quote: symbolConfig.Quote, Margin: symbolConfig.Margin}).String()
+
+		// 找到支持该市场的交易所
+		matched := false
+		for _, exchangeConfig := range sdk.exchangeConfigs {
+			if exchangeConfig.Market != symbolConfig.Market {
+				continue
+			}
+			if symbolConfig.Exchange != "" && exchangeConfig.Name != symbolConfig.Exchange {
+				continue
+			}
+			matched = true
+			result := SubscribeResult{
+				Symbol:   symbolName,
+				Exchange: exchangeConfig.Name,
+				Market:   exchangeConfig.Market,
+			}
 
-		// 批量订阅K线数据（固定1m）
-		if err := sdk.manager.SubscribeKline(ctx, exchangeName, marketType, symbols); err != nil {
-			logger.Warn("订阅K线数据失败 %s %s: %v", exchangeName, marketType, err)
+			// 使用FormatSymbolByExchange格式化币对名称
+			formattedSymbol, err := schema.FormatSymbolByExchange(
+				exchangeConfig.Name,
+				symbolConfig.Base,
+				symbolConfig.Quote,
+				symbolConfig.Margin,
+				exchangeConfig.Market,
+			)
+			if err != nil {
+				logger.Warn("格式化币对符号失败 %s/%s: %v", symbolConfig.Base, symbolConfig.Quote, err)
+				result.Status, result.Reason = SubscribeStatusRejected, fmt.Sprintf("failed to format symbol: %v", err)
+				report.Results = append(report.Results, result)
+				continue
+			}
+			result.ExchangeSymbol = formattedSymbol
+
+			// 按交易规则校验币对
+			if status, reason, ok := sdk.validateSymbol(exchangeConfig.Name, exchangeConfig.Market, formattedSymbol); !ok {
+				logger.Warn("%s %s 跳过币对 %s: %s", exchangeConfig.Name, exchangeConfig.Market, formattedSymbol, reason)
+				result.Status, result.Reason = status, reason
+				report.Results = append(report.Results, result)
+				continue
+			}
+
+			// 添加到对应的分组
+			groupKey := fmt.Sprintf("%s|%s", exchangeConfig.Name, exchangeConfig.Market)
+			group, exists := groupIndex[groupKey]
+			if !exists {
+				group = &subscriptionGroup{exchange: exchangeConfig.Name, market: exchangeConfig.Market}
+				groupIndex[groupKey] = group
+				groups = append(groups, group)
+			}
+			group.symbols = append(group.symbols, formattedSymbol)
+			group.results = append(group.results, len(report.Results))
+			report.Results = append(report.Results, result)
 		}
 
-		// 批量订阅深度数据
-		if err := sdk.manager.SubscribeDepth(ctx, exchangeName, marketType, symbols); err != nil {
-			logger.Warn("订阅深度数据失败 %s %s: %v", exchangeName, marketType, err)
+		// 限定的交易所未添加或不支持该市场
+		if !matched && symbolConfig.Exchange != "" {
+			report.Results = append(report.Results, SubscribeResult{
+				Symbol:   symbolName,
+				Exchange: symbolConfig.Exchange,
+				Market:   symbolConfig.Market,
+				Status:   SubscribeStatusRejected,
+				Reason:   fmt.Sprintf("exchange %s %s not configured", symbolConfig.Exchange, symbolConfig.Market),
+			})
 		}
 	}
+	return report, groups
+}
 
-	return nil
+// validateSymbol 按交易规则校验交易所格式的币对，不可订阅时返回对应的结果和原因
+func (sdk *SDK) validateSymbol(exchange schema.ExchangeName, market schema.MarketType, symbol string) (SubscribeStatus, string, bool) {
+	if _, loaded := sdk.manager.ExchangeInfoCache().Get(exchange, market); !loaded {
+		// 交易规则加载失败时无法校验，保持原有行为直接订阅
+		return "", "", true
+	}
+	info, listed := sdk.manager.GetSymbolInfo(exchange, market, symbol)
+	if !listed {
+		return SubscribeStatusSkipped, "symbol not listed", false
+	}
+	if !info.IsTrading() {
+		return SubscribeStatusRejected, fmt.Sprintf("symbol status is %s", info.Status), false
+	}
+	return "", "", true
 }
 
 // createExchange 根据配置创建交易所实例
@@ -390,9 +544,14 @@ func (sdk *SDK) createMEXCExchange(market schema.MarketType, cache *cache.Memory
 	}
 }
 
-// SubscribeKline subscribes to kline data for specified symbols (fixed to 1m interval)
-func (sdk *SDK) SubscribeKline(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
-	return sdk.manager.SubscribeKline(ctx, name, market, symbols)
+// SubscribeKline subscribes to kline data for specified symbols on one or more intervals (1m when none given)
+func (sdk *SDK) SubscribeKline(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string, intervals ...schema.Interval) error {
+	return sdk.manager.SubscribeKline(ctx, name, market, symbols, intervals...)
+}
+
+// UnsubscribeKline unsubscribes kline data for specified symbols on the given intervals (all subscribed intervals when none given)
+func (sdk *SDK) UnsubscribeKline(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string, intervals ...schema.Interval) error {
+	return sdk.manager.UnsubscribeKline(ctx, name, market, symbols, intervals...)
 }
 
 // SubscribeDepth subscribes to depth data for specified symbols
@@ -400,13 +559,197 @@ func (sdk *SDK) SubscribeDepth(ctx context.Context, name schema.ExchangeName, ma
 	return sdk.manager.SubscribeDepth(ctx, name, market, symbols)
 }
 
+// SubscribeTrades subscribes to public trades for specified symbols
+func (sdk *SDK) SubscribeTrades(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.SubscribeTrades(ctx, name, market, symbols)
+}
+
+// UnsubscribeTrades unsubscribes public trades for specified symbols
+func (sdk *SDK) UnsubscribeTrades(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.UnsubscribeTrades(ctx, name, market, symbols)
+}
+
+// SubscribeBookTicker subscribes to best bid/offer for specified symbols
+func (sdk *SDK) SubscribeBookTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.SubscribeBookTicker(ctx, name, market, symbols)
+}
+
+// UnsubscribeBookTicker unsubscribes best bid/offer for specified symbols
+func (sdk *SDK) UnsubscribeBookTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.UnsubscribeBookTicker(ctx, name, market, symbols)
+}
+
+// SubscribeTicker subscribes to 24h rolling ticker for specified symbols
+func (sdk *SDK) SubscribeTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.SubscribeTicker(ctx, name, market, symbols)
+}
+
+// UnsubscribeTicker unsubscribes 24h rolling ticker for specified symbols
+func (sdk *SDK) UnsubscribeTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.UnsubscribeTicker(ctx, name, market, symbols)
+}
+
+// SubscribeFunding subscribes to mark price, index price and funding rate for specified futures symbols
+func (sdk *SDK) SubscribeFunding(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.SubscribeFunding(ctx, name, market, symbols)
+}
+
+// UnsubscribeFunding unsubscribes mark price, index price and funding rate for specified futures symbols
+func (sdk *SDK) UnsubscribeFunding(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.UnsubscribeFunding(ctx, name, market, symbols)
+}
+
+// SubscribeOpenInterest subscribes to open interest for specified futures symbols (not available on Binance)
+func (sdk *SDK) SubscribeOpenInterest(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.SubscribeOpenInterest(ctx, name, market, symbols)
+}
+
+// UnsubscribeOpenInterest unsubscribes open interest for specified futures symbols
+func (sdk *SDK) UnsubscribeOpenInterest(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.UnsubscribeOpenInterest(ctx, name, market, symbols)
+}
+
+// SubscribeLiquidations subscribes to liquidation orders for specified futures symbols (Binance, OKX and Bybit)
+// 强平事件不写入缓存，通过 OnLiquidation 注册的回调逐条接收
+func (sdk *SDK) SubscribeLiquidations(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.SubscribeLiquidations(ctx, name, market, symbols)
+}
+
+// UnsubscribeLiquidations unsubscribes liquidation orders for specified futures symbols
+func (sdk *SDK) UnsubscribeLiquidations(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbols []string) error {
+	return sdk.manager.UnsubscribeLiquidations(ctx, name, market, symbols)
+}
+
+// OnLiquidation registers a handler receiving every liquidation event, handlers run on the connector's read goroutine and should return quickly
+func (sdk *SDK) OnLiquidation(handler schema.LiquidationHandler) {
+	sdk.manager.OnLiquidation(handler)
+}
+
+// OnKlineGapRepaired 注册K线缺口补齐回调：WebSocket 重连后通过 REST 补齐断线期间缺失的已完结K线时触发
+func (sdk *SDK) OnKlineGapRepaired(handler schema.KlineGapHandler) {
+	sdk.manager.OnKlineGapRepaired(handler)
+}
+
+// FetchOpenInterest fetches current open interest of a futures symbol from REST API
+func (sdk *SDK) FetchOpenInterest(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string) (schema.OpenInterest, error) {
+	return sdk.manager.FetchOpenInterest(ctx, name, market, symbol)
+}
+
+// FetchOpenInterestHistory fetches open interest history of a futures symbol from REST API, oldest first
+func (sdk *SDK) FetchOpenInterestHistory(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string, period schema.Interval, limit int) ([]schema.OpenInterest, error) {
+	return sdk.manager.FetchOpenInterestHistory(ctx, name, market, symbol, period, limit)
+}
+
+// FetchLongShortRatio fetches long/short account ratio history of a futures symbol from REST API, oldest first
+func (sdk *SDK) FetchLongShortRatio(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string, period schema.Interval, limit int) ([]schema.LongShortRatio, error) {
+	return sdk.manager.FetchLongShortRatio(ctx, name, market, symbol, period, limit)
+}
+
+// FetchTicker fetches 24h ticker of a symbol from REST API
+func (sdk *SDK) FetchTicker(ctx context.Context, name schema.ExchangeName, market schema.MarketType, symbol string) (schema.Ticker, error) {
+	return sdk.manager.FetchTicker(ctx, name, market, symbol)
+}
+
+// FetchTickers fetches 24h tickers of all symbols in the market from REST API
+func (sdk *SDK) FetchTickers(ctx context.Context, name schema.ExchangeName, market schema.MarketType) ([]schema.Ticker, error) {
+	return sdk.manager.FetchTickers(ctx, name, market)
+}
+
 // FetchDepth fetches depth data from REST API
 func (sdk *SDK) FetchDepth(ctx context.Context, market schema.MarketType, base, quote string, limit int) (schema.Depth, error) {
 	return sdk.manager.FetchDepth(ctx, market, base, quote, limit)
 }
 
-// WatchKline 根据币对符号智能读取K线数据（自动判断市场类型，按默认顺序查找）
-func (sdk *SDK) WatchKline(symbol string) (schema.Kline, bool) {
+// FetchKlines 根据币对符号通过 REST 获取开盘时间在 [from, to] 内的历史K线（按开盘时间由旧到新，自动分页，按默认顺序选择已添加的交易所）
+func (sdk *SDK) FetchKlines(ctx context.Context, symbol string, interval schema.Interval, from, to time.Time) ([]schema.Kline, error) {
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse symbol %s: %w", symbol, err)
+	}
+
+	lastErr := fmt.Errorf("no exchange available for fetching klines of %s", symbol)
+	for _, exchange := range sdk.getDefaultExchangeOrder() {
+		if _, ok := sdk.manager.GetExchange(exchange, parsedSymbol.MarketType); !ok {
+			continue
+		}
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return nil, err
+		}
+		klines, err := sdk.manager.FetchKlines(ctx, exchange, parsedSymbol.MarketType, formattedSymbol, interval, from, to)
+		if err != nil {
+			logger.Warn("%s 获取K线失败 %s: %v", exchange, formattedSymbol, err)
+			lastErr = err
+			continue
+		}
+		return klines, nil
+	}
+
+	return nil, lastErr
+}
+
+// GetSymbolInfo 根据币对符号读取各交易所的交易规则（价格步长、数量步长、最小下单金额、交易状态等），
+// 按默认顺序返回已加载交易规则且上架该币对的交易所，如 GetSymbolInfo("BTC/USDT:USDT")
+func (sdk *SDK) GetSymbolInfo(symbol string) ([]schema.Symbol, bool) {
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return nil, false
+	}
+
+	var out []schema.Symbol
+	for _, exchange := range sdk.getDefaultExchangeOrder() {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			continue
+		}
+		if info, ok := sdk.manager.GetSymbolInfo(exchange, parsedSymbol.MarketType, formattedSymbol); ok {
+			out = append(out, info)
+		}
+	}
+
+	return out, len(out) > 0
+}
+
+// ListSymbols 读取指定交易所和市场已加载的全部交易对（含暂停交易的交易对，可通过 Status 区分）
+func (sdk *SDK) ListSymbols(exchange schema.ExchangeName, market schema.MarketType) ([]schema.Symbol, bool) {
+	return sdk.manager.ListSymbols(exchange, market)
+}
+
+// RefreshExchangeInfo 立即重新加载所有已添加交易所的交易规则
+func (sdk *SDK) RefreshExchangeInfo(ctx context.Context) {
+	sdk.manager.LoadExchangeInfo(ctx, true)
+}
+
+// SetExchangeInfoRefreshInterval 设置交易规则定时刷新间隔（默认 1 小时）
+func (sdk *SDK) SetExchangeInfoRefreshInterval(d time.Duration) {
+	sdk.manager.SetExchangeInfoRefreshInterval(d)
+}
+
+// SetKlineHistorySize 设置每个币对每个周期在内存中保留的已完结K线条数（默认 1000）
+func (sdk *SDK) SetKlineHistorySize(n int) {
+	sdk.manager.Cache().SetKlineHistorySize(n)
+}
+
+// SetKlineAggregation 设置由1m K线在本地合成的周期（默认 3m/5m/15m/30m/1h/4h/1d），不传周期时关闭合成
+func (sdk *SDK) SetKlineAggregation(intervals ...schema.Interval) {
+	sdk.manager.Cache().SetKlineAggregation(intervals...)
+}
+
+// WatchKline 根据币对符号读取指定周期的K线数据（自动判断市场类型，按默认顺序查找）
+func (sdk *SDK) WatchKline(symbol string, interval schema.Interval) (schema.Kline, bool) {
 	// 解析币对符号
 	parsedSymbol, err := schema.ParseSymbol(symbol)
 	if err != nil {
@@ -427,7 +770,7 @@ func (sdk *SDK) WatchKline(symbol string) (schema.Kline, bool) {
 		if err != nil {
 			return schema.Kline{}, false
 		}
-		if kline, ok := sdk.manager.WatchKline(exchange, parsedSymbol.MarketType, formattedSymbol); ok {
+		if kline, ok := sdk.manager.WatchKline(exchange, parsedSymbol.MarketType, formattedSymbol, interval); ok {
 			return kline, true
 		}
 	}
@@ -435,6 +778,66 @@ func (sdk *SDK) WatchKline(symbol string) (schema.Kline, bool) {
 	return schema.Kline{}, false
 }
 
+// WatchKlines 根据币对符号读取指定周期最近 limit 条K线（按开盘时间由旧到新，包含未完结K线，自动判断市场类型，按默认顺序查找）
+func (sdk *SDK) WatchKlines(symbol string, interval schema.Interval, limit int) ([]schema.Kline, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return []schema.Kline{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return []schema.Kline{}, false
+		}
+		if klines, ok := sdk.manager.WatchKlines(exchange, parsedSymbol.MarketType, formattedSymbol, interval, limit); ok {
+			return klines, true
+		}
+	}
+
+	return []schema.Kline{}, false
+}
+
+// WatchKlinesRange 根据币对符号读取开盘时间在 [from, to] 内的缓存K线（按开盘时间由旧到新，自动判断市场类型，按默认顺序查找）
+func (sdk *SDK) WatchKlinesRange(symbol string, interval schema.Interval, from, to time.Time) ([]schema.Kline, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return []schema.Kline{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return []schema.Kline{}, false
+		}
+		if klines, ok := sdk.manager.WatchKlinesRange(exchange, parsedSymbol.MarketType, formattedSymbol, interval, from, to); ok {
+			return klines, true
+		}
+	}
+
+	return []schema.Kline{}, false
+}
+
 // WatchDepth 根据币对符号智能读取深度数据（自动判断市场类型，按默认顺序查找）
 func (sdk *SDK) WatchDepth(symbol string) (schema.Depth, bool) {
 	// 解析币对符号
@@ -465,6 +868,162 @@ func (sdk *SDK) WatchDepth(symbol string) (schema.Depth, bool) {
 	return schema.Depth{}, false
 }
 
+// WatchTrades 根据币对符号读取最近 n 条公共成交（按时间由旧到新，自动判断市场类型，按默认顺序查找）
+func (sdk *SDK) WatchTrades(symbol string, n int) ([]schema.Trade, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return []schema.Trade{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return []schema.Trade{}, false
+		}
+		if trades, ok := sdk.manager.WatchTrades(exchange, parsedSymbol.MarketType, formattedSymbol, n); ok {
+			return trades, true
+		}
+	}
+
+	return []schema.Trade{}, false
+}
+
+// WatchBookTicker 根据币对符号读取最优买卖价（自动判断市场类型，按默认顺序查找），无需拷贝完整深度
+func (sdk *SDK) WatchBookTicker(symbol string) (schema.BookTicker, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return schema.BookTicker{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return schema.BookTicker{}, false
+		}
+		if bt, ok := sdk.manager.WatchBookTicker(exchange, parsedSymbol.MarketType, formattedSymbol); ok {
+			return bt, true
+		}
+	}
+
+	return schema.BookTicker{}, false
+}
+
+// WatchTicker 根据币对符号读取24小时行情（自动判断市场类型，按默认顺序查找）
+func (sdk *SDK) WatchTicker(symbol string) (schema.Ticker, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return schema.Ticker{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return schema.Ticker{}, false
+		}
+		if t, ok := sdk.manager.WatchTicker(exchange, parsedSymbol.MarketType, formattedSymbol); ok {
+			return t, true
+		}
+	}
+
+	return schema.Ticker{}, false
+}
+
+// WatchFunding 根据合约币对符号读取标记价格、指数价格和资金费率（按默认顺序查找），现货币对返回 false
+func (sdk *SDK) WatchFunding(symbol string) (schema.FundingInfo, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return schema.FundingInfo{}, false
+	}
+	if parsedSymbol.MarketType == schema.SPOT {
+		return schema.FundingInfo{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return schema.FundingInfo{}, false
+		}
+		if f, ok := sdk.manager.WatchFunding(exchange, parsedSymbol.MarketType, formattedSymbol); ok {
+			return f, true
+		}
+	}
+
+	return schema.FundingInfo{}, false
+}
+
+// WatchOpenInterest 根据合约币对符号读取持仓量（按默认顺序查找），现货币对返回 false
+func (sdk *SDK) WatchOpenInterest(symbol string) (schema.OpenInterest, bool) {
+	// 解析币对符号
+	parsedSymbol, err := schema.ParseSymbol(symbol)
+	if err != nil {
+		logger.Warn("解析币对符号失败 %s: %v", symbol, err)
+		return schema.OpenInterest{}, false
+	}
+	if parsedSymbol.MarketType == schema.SPOT {
+		return schema.OpenInterest{}, false
+	}
+
+	// 按默认顺序查找数据
+	exchanges := sdk.getDefaultExchangeOrder()
+	for _, exchange := range exchanges {
+		formattedSymbol, err := schema.FormatSymbolByExchange(
+			exchange,
+			parsedSymbol.Base,
+			parsedSymbol.Quote,
+			parsedSymbol.Margin,
+			parsedSymbol.MarketType,
+		)
+		if err != nil {
+			return schema.OpenInterest{}, false
+		}
+		if oi, ok := sdk.manager.WatchOpenInterest(exchange, parsedSymbol.MarketType, formattedSymbol); ok {
+			return oi, true
+		}
+	}
+
+	return schema.OpenInterest{}, false
+}
+
 // getDefaultExchangeOrder 获取默认的交易所查找顺序
 func (sdk *SDK) getDefaultExchangeOrder() []schema.ExchangeName {
 	return []schema.ExchangeName{
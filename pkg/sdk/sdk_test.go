@@ -1,7 +1,9 @@
 package sdk
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/kingsmao/exchange-connector/pkg/schema"
@@ -366,7 +368,7 @@ func TestSDKSmartDataReading(t *testing.T) {
 
 	t.Run("Test WatchKline", func(t *testing.T) {
 		// 测试现货币对
-		kline, ok := sdk.WatchKline("BTC/USDT")
+		kline, ok := sdk.WatchKline("BTC/USDT", schema.Interval1m)
 		if ok {
 			t.Logf("现货BTC/USDT K线数据: 开盘价=%s, 最高价=%s, 最低价=%s, 收盘价=%s",
 				kline.Open, kline.High, kline.Low, kline.Close)
@@ -375,7 +377,7 @@ func TestSDKSmartDataReading(t *testing.T) {
 		}
 
 		// 测试U本位合约币对
-		kline, ok = sdk.WatchKline("BTC/USDT:USDT")
+		kline, ok = sdk.WatchKline("BTC/USDT:USDT", schema.Interval1m)
 		if ok {
 			t.Logf("U本位BTC/USDT:USDT K线数据: 开盘价=%s, 最高价=%s, 最低价=%s, 收盘价=%s",
 				kline.Open, kline.High, kline.Low, kline.Close)
@@ -384,7 +386,7 @@ func TestSDKSmartDataReading(t *testing.T) {
 		}
 
 		// 测试币本位合约币对
-		kline, ok = sdk.WatchKline("BTC/USD:BTC")
+		kline, ok = sdk.WatchKline("BTC/USD:BTC", schema.Interval1m)
 		if ok {
 			t.Logf("币本位BTC/USD:BTC K线数据: 开盘价=%s, 最高价=%s, 最低价=%s, 收盘价=%s",
 				kline.Open, kline.High, kline.Low, kline.Close)
@@ -393,7 +395,7 @@ func TestSDKSmartDataReading(t *testing.T) {
 		}
 
 		// 测试无效币对
-		kline, ok = sdk.WatchKline("INVALID/SYMBOL")
+		kline, ok = sdk.WatchKline("INVALID/SYMBOL", schema.Interval1m)
 		if ok {
 			t.Error("无效币对应该返回false")
 		}
@@ -543,3 +545,120 @@ func TestSDKBatchSubscription(t *testing.T) {
 		}
 	})
 }
+
+func TestSDKSymbolInfo(t *testing.T) {
+	sdk := NewSDK()
+
+	okxSymbol, err := schema.FormatSymbolByExchange(schema.OKX, "BTC", "USDT", "USDT", schema.FUTURESUSDT)
+	if err != nil {
+		t.Fatalf("format symbol: %v", err)
+	}
+	binanceSymbol, err := schema.FormatSymbolByExchange(schema.BINANCE, "BTC", "USDT", "USDT", schema.FUTURESUSDT)
+	if err != nil {
+		t.Fatalf("format symbol: %v", err)
+	}
+
+	infoCache := sdk.manager.ExchangeInfoCache()
+	infoCache.Set(schema.ExchangeInfo{Exchange: schema.OKX, Market: schema.FUTURESUSDT, Symbols: []schema.Symbol{
+		{Symbol: okxSymbol, ExchangeName: schema.OKX, MarketType: schema.FUTURESUSDT, TickSize: "0.1", StepSize: "0.0001", Status: schema.SymbolStatusTrading},
+	}})
+	infoCache.Set(schema.ExchangeInfo{Exchange: schema.BINANCE, Market: schema.FUTURESUSDT, Symbols: []schema.Symbol{
+		{Symbol: binanceSymbol, ExchangeName: schema.BINANCE, MarketType: schema.FUTURESUSDT, TickSize: "0.10", StepSize: "0.001", MinNotional: "100", Status: schema.SymbolStatusTrading},
+		{Symbol: "ETHUSDT", ExchangeName: schema.BINANCE, MarketType: schema.FUTURESUSDT, Status: schema.SymbolStatusHalt},
+	}})
+
+	infos, ok := sdk.GetSymbolInfo("BTC/USDT:USDT")
+	if !ok || len(infos) != 2 {
+		t.Fatalf("expected symbol info of 2 exchanges, got %+v", infos)
+	}
+	// 按默认交易所顺序返回
+	if infos[0].ExchangeName != schema.BINANCE || infos[0].MinNotional != "100" || infos[1].ExchangeName != schema.OKX || infos[1].TickSize != "0.1" {
+		t.Fatalf("unexpected symbol info: %+v", infos)
+	}
+
+	if _, ok := sdk.GetSymbolInfo("DOGE/USDT"); ok {
+		t.Fatalf("unexpected symbol info for unlisted symbol")
+	}
+
+	symbols, ok := sdk.ListSymbols(schema.BINANCE, schema.FUTURESUSDT)
+	if !ok || len(symbols) != 2 || symbols[1].IsTrading() {
+		t.Fatalf("unexpected symbols: %+v", symbols)
+	}
+}
+
+func TestSDKSymbolValidation(t *testing.T) {
+	sdk := NewSDK()
+	sdk.manager.ExchangeInfoCache().Set(schema.ExchangeInfo{Exchange: schema.BINANCE, Market: schema.SPOT, Symbols: []schema.Symbol{
+		{Symbol: "BTCUSDT", Status: schema.SymbolStatusTrading},
+		{Symbol: "LUNAUSDT", Status: schema.SymbolStatusBreak},
+	}})
+
+	if _, _, ok := sdk.validateSymbol(schema.BINANCE, schema.SPOT, "BTCUSDT"); !ok {
+		t.Errorf("listed trading symbol should pass")
+	}
+	if status, _, ok := sdk.validateSymbol(schema.BINANCE, schema.SPOT, "LUNAUSDT"); ok || status != SubscribeStatusRejected {
+		t.Errorf("non-trading symbol should be rejected, got %s", status)
+	}
+	if status, _, ok := sdk.validateSymbol(schema.BINANCE, schema.SPOT, "FOOUSDT"); ok || status != SubscribeStatusSkipped {
+		t.Errorf("unlisted symbol should be skipped, got %s", status)
+	}
+	// 交易规则未加载时不校验
+	if _, _, ok := sdk.validateSymbol(schema.OKX, schema.SPOT, "FOO-USDT"); !ok {
+		t.Errorf("symbol should pass when exchange info is not loaded")
+	}
+
+	configs, rejected := parseSymbolConfigs("", []string{"BTC/USDT", "invalid", "ETH/USDT:USDT"})
+	if len(configs) != 2 || len(rejected) != 1 || rejected[0].Symbol != "invalid" {
+		t.Fatalf("unexpected parse result: %+v %+v", configs, rejected)
+	}
+
+	report := SubscribeReport{Results: append(rejected, SubscribeResult{Symbol: "BTC/USDT", Exchange: schema.BINANCE, Status: SubscribeStatusSubscribed})}
+	if len(report.Subscribed()) != 1 || len(report.Rejected()) != 1 || len(report.Skipped()) != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestStreamSubscribeStatus(t *testing.T) {
+	if status, reason := streamSubscribeStatus(nil, nil); status != SubscribeStatusSubscribed || reason != "" {
+		t.Errorf("unexpected status for successful streams: %s %q", status, reason)
+	}
+	// 只有深度订阅失败时K线仍在推送，记为部分订阅并说明失败的数据流
+	status, reason := streamSubscribeStatus(nil, errors.New("not connected"))
+	if status != SubscribeStatusPartial || reason != "depth subscription failed: not connected" {
+		t.Errorf("unexpected status for failed depth stream: %s %q", status, reason)
+	}
+	status, reason = streamSubscribeStatus(errors.New("timeout"), errors.New("not connected"))
+	if status != SubscribeStatusRejected || !strings.Contains(reason, "kline") || !strings.Contains(reason, "depth") {
+		t.Errorf("unexpected status for failed streams: %s %q", status, reason)
+	}
+}
+
+func TestSDKPlanSubscriptions(t *testing.T) {
+	sdk := NewSDK()
+	sdk.exchangeConfigs = []ExchangeConfig{
+		{Name: schema.BINANCE, Market: schema.SPOT, Weight: 1},
+		{Name: schema.OKX, Market: schema.SPOT, Weight: 1},
+	}
+	sdk.AddSymbols([]SymbolConfig{{Base: "ETH", Quote: "USDT", Market: schema.SPOT}})
+
+	// 限定交易所的币对只在该交易所订阅，且只处理本次传入的币对
+	configs, _ := parseSymbolConfigs(schema.OKX, []string{"BTC/USDT", "BTC/USDT:USDT"})
+	report, groups := sdk.planSubscriptions(configs)
+	if len(groups) != 1 || groups[0].exchange != schema.OKX || len(groups[0].symbols) != 1 || groups[0].symbols[0] != "BTC-USDT" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("unexpected results: %+v", report.Results)
+	}
+	// 限定的交易所未添加该市场时记为拒绝
+	if r := report.Results[1]; r.Exchange != schema.OKX || r.Market != schema.FUTURESUSDT || r.Status != SubscribeStatusRejected {
+		t.Fatalf("unexpected result for unconfigured market: %+v", r)
+	}
+
+	// 未限定交易所时匹配所有支持该市场的交易所
+	configs, _ = parseSymbolConfigs("", []string{"BTC/USDT"})
+	report, groups = sdk.planSubscriptions(configs)
+	if len(groups) != 2 || len(report.Results) != 2 {
+		t.Fatalf("unexpected plan: %+v %+v", groups, report.Results)
+	}
+}
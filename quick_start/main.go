@@ -60,9 +60,16 @@ func main() {
 	ctx := context.Background()
 
 	// 方式A：批量添加币对并订阅
-	if err := sdkInstance.AddSymbolsAndSubscribe(ctx, allSymbols); err != nil {
+	report, err := sdkInstance.AddSymbolsAndSubscribe(ctx, allSymbols)
+	if err != nil {
 		panic(err)
 	}
+	for _, r := range append(report.Skipped(), report.Rejected()...) {
+		fmt.Printf("未订阅 %s %s %s: %s\n", r.Exchange, r.Market, r.Symbol, r.Reason)
+	}
+	for _, r := range report.Partial() {
+		fmt.Printf("部分订阅 %s %s %s: %s\n", r.Exchange, r.Market, r.Symbol, r.Reason)
+	}
 
 	// 5. 启动数据监控循环
 	fmt.Println("启动数据监控循环，每3秒打印一次...")
@@ -85,21 +92,21 @@ func main() {
 			fmt.Printf("\n=== %s ===\n", time.Now().Format("2006-01-02 15:04:05"))
 
 			// 读取K线数据
-			if kline, ok := sdkInstance.WatchKline("BTC/USDT"); ok {
+			if kline, ok := sdkInstance.WatchKline("BTC/USDT", schema.Interval1m); ok {
 				fmt.Printf("现货BTC/USDT K线: 开盘=%s, 最高=%s, 最低=%s, 收盘=%s, 成交量=%s\n",
 					kline.Open, kline.High, kline.Low, kline.Close, kline.Volume)
 			} else {
 				fmt.Println("现货BTC/USDT K线: 暂无数据")
 			}
 
-			if kline, ok := sdkInstance.WatchKline("BTC/USDT:USDT"); ok {
+			if kline, ok := sdkInstance.WatchKline("BTC/USDT:USDT", schema.Interval1m); ok {
 				fmt.Printf("U本位BTC/USDT:USDT K线: 开盘=%s, 最高=%s, 最低=%s, 收盘=%s, 成交量=%s\n",
 					kline.Open, kline.High, kline.Low, kline.Close, kline.Volume)
 			} else {
 				fmt.Println("U本位BTC/USDT:USDT K线: 暂无数据")
 			}
 
-			if kline, ok := sdkInstance.WatchKline("ETH/USD:ETH"); ok {
+			if kline, ok := sdkInstance.WatchKline("ETH/USD:ETH", schema.Interval1m); ok {
 				fmt.Printf("币本位ETH/USD:ETH K线: 开盘=%s, 最高=%s, 最低=%s, 收盘=%s, 成交量=%s\n",
 					kline.Open, kline.High, kline.Low, kline.Close, kline.Volume)
 			} else {